@@ -3,9 +3,12 @@ package main
 import (
 	"encoding/xml"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -19,6 +22,8 @@ func newConfigCmd() *cobra.Command {
 Subcommands:
   init    - Generiert eine neue config.toml mit Standard-Einstellungen
   convert - Konvertiert alte VB.NET XML-Config zu TOML-Format
+  show    - Zeigt die (effektive) Konfiguration mit der Quelle jedes Werts
+  schema  - Gibt ein JSON Schema der Konfiguration für Editoren aus
 
 Die Konfigurationsdatei steuert:
   - Datenbank-Einstellungen (Pfad, SQLite-Pragmas)
@@ -31,12 +36,20 @@ Die Konfigurationsdatei steuert:
   esdedb config init --output ./my-config.toml
 
   # VB.NET XML-Config zu TOML konvertieren
-  esdedb config convert --input ApplicationSettings.xml --output config.toml`,
+  esdedb config convert --input ApplicationSettings.xml --output config.toml
+
+  # Effektive Konfiguration mit Quellen anzeigen
+  esdedb config show --effective
+
+  # JSON Schema für Editoren erzeugen
+  esdedb config schema --output config.schema.json`,
 	}
 
 	// Subcommands hinzufügen
 	cmd.AddCommand(newConfigInitCmd())
 	cmd.AddCommand(newConfigConvertCmd())
+	cmd.AddCommand(newConfigShowCmd())
+	cmd.AddCommand(newConfigSchemaCmd())
 
 	return cmd
 }
@@ -86,14 +99,25 @@ func newConfigConvertCmd() *cobra.Command {
 		Long: `Convert command konvertiert alte VB.NET XML-Konfigurationen zu TOML-Format.
 
 Dieser Befehl ist für die Migration von der Legacy VB.NET Version gedacht.
-Er liest eine ApplicationSettings.xml und konvertiert die bekannten Felder:
-  - SelectedDB → Ignoriert (Go-Version nur SQLite)
-  - SelectedLanguage → import.language
-  - SQLiteDBPath → database.path
-  - SDEPath → import.sde_path
-  - ThreadCount → import.workers
-
-Neue Felder (z.B. logging.level) werden mit Standardwerten gefüllt.`,
+Er liest eine ApplicationSettings.xml und bildet die Felder so ab:
+  - FinalDBPath + DatabaseName → database.path (<FinalDBPath>\<DatabaseName>.sqlite)
+  - SDEDirectory → import.sde_path
+  - SelectedLanguage → import.language (unbekannte Sprache = Fehler)
+  - SelectedDB = CSV → [export.csv] path (<FinalDBPath>\<DatabaseName>_CSV)
+  - CSVEUCheck → export.csv.delimiter = ";", decimal_comma = true
+
+Liegen neben der XML-Datei die Begleitdateien der VB.NET-Version, werden
+sie ebenfalls übernommen:
+  - GridSettings.txt (ausgewählte SDE-Dateien) → import.tables; wie in der
+    VB.NET-Version wird translationLanguages ergänzt, wenn eine ausgewählte
+    Datei Übersetzungen benötigt; hat keine ausgewählte Datei eine Tabelle,
+    ist das ein Fehler
+  - NumberofThreads.txt → import.workers (-1 = Auto)
+
+Einstellungen ohne Entsprechung (Server-Verbindungen anderer Datenbanktypen,
+Passwörter, DownloadFolderPath, unbekannte Elemente) werden nicht übernommen
+und in der Ausgabe sowie als Kommentar in der config.toml gemeldet. Neue
+Felder (z.B. logging.level) werden mit Standardwerten gefüllt.`,
 		Example: `  # XML zu TOML konvertieren
   esdedb config convert --input ApplicationSettings.xml --output config.toml
 
@@ -113,6 +137,55 @@ Neue Felder (z.B. logging.level) werden mit Standardwerten gefüllt.`,
 	return cmd
 }
 
+// newConfigSchemaCmd erstellt das 'config schema' Subcommand
+func newConfigSchemaCmd() *cobra.Command {
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print a JSON Schema for config.toml",
+		Long: `Schema command gibt ein JSON Schema (Draft 07) der Konfiguration aus.
+
+Editoren mit TOML-Unterstützung (z.B. Taplo / Even Better TOML für VS Code)
+nutzen es für Autovervollständigung, Beschreibungen und Prüfung der Werte.
+Das Schema wird aus der Config-Struktur erzeugt und enthält alle Abschnitte
+inklusive [tables.<name>] und [profiles.<name>]; unbekannte Schlüssel sind
+nicht erlaubt.
+
+Einbinden in config.toml (Taplo):
+  #:schema ./config.schema.json`,
+		Example: `  # Schema ausgeben
+  esdedb config schema
+
+  # Schema neben die Konfiguration schreiben
+  esdedb config schema --output config.schema.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigSchema(cmd.OutOrStdout(), outputPath)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Ausgabe-Pfad (leer = stdout)")
+
+	return cmd
+}
+
+// runConfigSchema schreibt das JSON Schema nach outputPath bzw. out
+func runConfigSchema(out io.Writer, outputPath string) error {
+	schema, err := config.JSONSchema(registeredTables())
+	if err != nil {
+		return err
+	}
+	if outputPath == "" {
+		_, err := out.Write(schema)
+		return err
+	}
+	if err := os.WriteFile(outputPath, schema, 0644); err != nil {
+		return fmt.Errorf("failed to write schema file: %w", err)
+	}
+	fmt.Fprintf(out, "✅ Successfully wrote JSON schema to %s\n", outputPath)
+	return nil
+}
+
 // runConfigInit generiert eine neue config.toml mit Standardwerten
 func runConfigInit(outputPath string) error {
 	// Prüfen ob Datei bereits existiert
@@ -126,14 +199,31 @@ version = "1.0.0"
 
 [database]
 path = "./eve_sde.db"
-journal_mode = "WAL"
-cache_size_mb = 64
+journal_mode = "WAL"     # WAL, DELETE, TRUNCATE, PERSIST, MEMORY, OFF
+synchronous = "NORMAL"   # OFF, NORMAL, FULL, EXTRA
+cache_size_mb = 64       # page cache in MB
+mmap_size_mb = 0         # memory-mapped I/O in MiB (0 = disabled)
+busy_timeout_ms = 5000   # wait time for a locked database
+page_size = 0            # bytes, power of two 512-65536 (0 = SQLite default; new databases only)
+read_only = false        # open with mode=ro (not allowed for import)
+immutable = false        # open with immutable=1, implies read_only
 
 [import]
 sde_path = "./sde-JSONL"
 language = "en"  # en, de, fr, ja, ru, zh, es, ko
 workers = 4      # 0 = auto (runtime.NumCPU())
 
+[import.finalize]
+# Post-import steps (flags: --analyze, --optimize, --checkpoint, --vacuum,
+# --vacuum-into, --integrity-check; --finalize=false disables analyze,
+# optimize and checkpoint, which are on by default)
+analyze = true           # ANALYZE (query planner statistics)
+optimize = true          # PRAGMA optimize
+checkpoint = true        # WAL checkpoint with truncate
+vacuum = false           # VACUUM (release free pages)
+vacuum_into = ""         # write a compacted copy to this file (empty = none)
+integrity_check = false  # PRAGMA integrity_check, the import fails if not ok
+
 [logging]
 level = "info"   # debug, info, warn, error
 format = "text"  # text, json
@@ -141,6 +231,23 @@ format = "text"  # text, json
 [update]
 enabled = false
 check_url = ""
+
+[export.csv]
+path = "./eve_sde_csv"  # output directory (one file per table)
+delimiter = ","         # exactly one character, e.g. ";" or "\t"
+decimal_comma = false   # write decimals with a comma (European spreadsheets)
+header = true           # first row with the column names
+null = ""               # representation of NULL values, e.g. "\\N"
+gzip = false            # write <table>.csv.gz
+tables = []             # glob patterns or groups as in import.tables (empty = all)
+exclude_tables = []
+
+[export.postgres]
+path = "./eve_sde.sql"  # output file (load with: psql -f eve_sde.sql)
+schema = ""             # PostgreSQL schema for the tables (empty = public)
+drop_tables = false     # DROP TABLE IF EXISTS before CREATE TABLE
+tables = []             # glob patterns or groups as in import.tables (empty = all)
+exclude_tables = []
 `
 
 	// Verzeichnis erstellen falls nicht vorhanden
@@ -163,16 +270,6 @@ check_url = ""
 	return nil
 }
 
-// XMLSettings repräsentiert die VB.NET XML-Konfiguration
-type XMLSettings struct {
-	XMLName          xml.Name `xml:"Settings"`
-	SelectedDB       string   `xml:"SelectedDB"`
-	SelectedLanguage string   `xml:"SelectedLanguage"`
-	SQLiteDBPath     string   `xml:"SQLiteDBPath"`
-	SDEPath          string   `xml:"SDEPath"`
-	ThreadCount      int      `xml:"ThreadCount"`
-}
-
 // runConfigConvert konvertiert XML zu TOML
 func runConfigConvert(inputPath, outputPath string) error {
 	// Prüfen ob Input existiert
@@ -192,49 +289,12 @@ func runConfigConvert(inputPath, outputPath string) error {
 		return fmt.Errorf("failed to parse XML: %w", err)
 	}
 
-	// Sprache konvertieren (English → en, etc.)
-	language := convertLanguage(settings.SelectedLanguage)
-
-	// Default-Werte setzen falls nicht vorhanden
-	dbPath := settings.SQLiteDBPath
-	if dbPath == "" {
-		dbPath = "./eve_sde.db"
-	}
-
-	sdePath := settings.SDEPath
-	if sdePath == "" {
-		sdePath = "./sde-JSONL"
-	}
-
-	workers := settings.ThreadCount
-	if workers <= 0 {
-		workers = 4
+	// Einstellungen und Begleitdateien (GridSettings.txt, NumberofThreads.txt) abbilden
+	conv, err := convertLegacySettings(settings, filepath.Dir(inputPath))
+	if err != nil {
+		return err
 	}
 
-	// TOML Content generieren
-	tomlContent := fmt.Sprintf(`# EVE SDE Database Builder Configuration
-# Converted from VB.NET XML config: %s
-version = "1.0.0"
-
-[database]
-path = "%s"
-journal_mode = "WAL"
-cache_size_mb = 64
-
-[import]
-sde_path = "%s"
-language = "%s"
-workers = %d
-
-[logging]
-level = "info"   # debug, info, warn, error
-format = "text"  # text, json
-
-[update]
-enabled = false
-check_url = ""
-`, inputPath, dbPath, sdePath, language, workers)
-
 	// Output-Verzeichnis erstellen falls nicht vorhanden
 	dir := filepath.Dir(outputPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -242,46 +302,44 @@ check_url = ""
 	}
 
 	// TOML-Datei schreiben
-	if err := os.WriteFile(outputPath, []byte(tomlContent), 0644); err != nil {
+	if err := os.WriteFile(outputPath, []byte(renderConvertedConfig(inputPath, conv)), 0644); err != nil {
 		return fmt.Errorf("failed to write TOML file: %w", err)
 	}
 
 	fmt.Printf("✅ Successfully converted XML to TOML\n")
 	fmt.Printf("\nConversion Summary:\n")
 	fmt.Printf("  Input:      %s\n", inputPath)
+	for _, companion := range conv.Companions {
+		fmt.Printf("              %s\n", companion)
+	}
 	fmt.Printf("  Output:     %s\n", outputPath)
-	fmt.Printf("  Database:   %s\n", dbPath)
-	fmt.Printf("  SDE Path:   %s\n", sdePath)
-	fmt.Printf("  Language:   %s (from %s)\n", language, settings.SelectedLanguage)
-	fmt.Printf("  Workers:    %d\n", workers)
-	fmt.Printf("\nNote: SelectedDB field (%s) was ignored (Go version is SQLite-only)\n", settings.SelectedDB)
+	fmt.Printf("  Database:   %s\n", conv.DatabasePath)
+	fmt.Printf("  SDE Path:   %s\n", conv.SDEPath)
+	fmt.Printf("  Language:   %s (from %s)\n", conv.Language, settings.SelectedLanguage)
+	fmt.Printf("  Workers:    %d\n", conv.Workers)
+	if len(conv.Tables) > 0 {
+		fmt.Printf("  Tables:     %s\n", strings.Join(conv.Tables, ", "))
+	} else {
+		fmt.Printf("  Tables:     all\n")
+	}
+	if conv.CSV != nil {
+		fmt.Printf("  CSV Export: %s (delimiter %q, decimal comma: %t)\n", conv.CSV.Path, conv.CSV.Delimiter, conv.CSV.DecimalComma)
+	}
+	if conv.Postgres != nil {
+		fmt.Printf("  PostgreSQL: %s (esdedb export postgres)\n", conv.Postgres.Path)
+	}
+	for _, note := range conv.Notes {
+		fmt.Printf("\nNote: %s\n", note)
+	}
+	if len(conv.Unmapped) > 0 {
+		fmt.Printf("\nNot migrated (%d):\n", len(conv.Unmapped))
+		for _, u := range conv.Unmapped {
+			fmt.Printf("  %s: %s\n", u.Key, u.Reason)
+		}
+	}
 	fmt.Printf("\nNext steps:\n")
 	fmt.Printf("  1. Review %s for correctness\n", outputPath)
 	fmt.Printf("  2. Validate: esdedb validate --config %s\n", outputPath)
 
 	return nil
 }
-
-// convertLanguage konvertiert VB.NET Sprach-Namen zu ISO-Codes
-func convertLanguage(vbLang string) string {
-	languageMap := map[string]string{
-		"English":  "en",
-		"German":   "de",
-		"French":   "fr",
-		"Japanese": "ja",
-		"Russian":  "ru",
-		"Chinese":  "zh",
-		"Spanish":  "es",
-		"Korean":   "ko",
-	}
-
-	if code, ok := languageMap[vbLang]; ok {
-		return code
-	}
-
-	// Fallback: lowercase first 2 chars oder default "en"
-	if len(vbLang) >= 2 {
-		return vbLang[:2]
-	}
-	return "en"
-}
@@ -0,0 +1,526 @@
+package main
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/config"
+)
+
+// Begleitdateien der VB.NET-Version, die neben ApplicationSettings.xml liegen
+const (
+	legacyGridSettingsFile = "GridSettings.txt"    // ausgewählte SDE-Dateien, eine pro Zeile
+	legacyThreadsFile      = "NumberofThreads.txt" // Thread-Anzahl (-1 = alle Kerne)
+)
+
+// legacyUniverseEntry ist der Eintrag der Tabellenauswahl für alle Universe-Dateien
+const legacyUniverseEntry = "Universe Data (multiple files)"
+
+// XMLSettings repräsentiert die ApplicationSettings.xml der VB.NET-Version
+// (ProgramSettings.vb). Das Root-Element wird nicht geprüft; Elemente ohne
+// eigenes Feld landen in Other und werden als nicht übernommen gemeldet.
+type XMLSettings struct {
+	XMLName            xml.Name
+	SelectedDB         string `xml:"SelectedDB"`
+	SDEDirectory       string `xml:"SDEDirectory"`
+	DatabaseName       string `xml:"DatabaseName"`
+	FinalDBPath        string `xml:"FinalDBPath"`
+	DownloadFolderPath string `xml:"DownloadFolderPath"`
+
+	SQLConnectionString        string `xml:"SQLConnectionString"`
+	SQLUserName                string `xml:"SQLUserName"`
+	SQLPassword                string `xml:"SQLPassword"`
+	AccessPassword             string `xml:"AccessPassword"`
+	PostgreSQLConnectionString string `xml:"PostgreSQLConnectionString"`
+	PostgreSQLUserName         string `xml:"PostgreSQLUserName"`
+	PostgreSQLPassword         string `xml:"PostgreSQLPassword"`
+	PostgreSQLPort             string `xml:"PostgreSQLPort"`
+	MySQLConnectionString      string `xml:"MySQLConnectionString"`
+	MySQLUserName              string `xml:"MySQLUserName"`
+	MySQLPassword              string `xml:"MySQLPassword"`
+	MySQLPort                  string `xml:"MySQLPort"`
+
+	CSVEUCheck       bool   `xml:"CSVEUCheck"` // Semikolon als Trenner, Dezimalkomma
+	SelectedLanguage string `xml:"SelectedLanguage"`
+	UseLargerVersion bool   `xml:"UseLargerVersion"`
+
+	// Schlüssel des vereinfachten Formats früherer esdedb-Versionen
+	SQLiteDBPath string `xml:"SQLiteDBPath"`
+	SDEPath      string `xml:"SDEPath"`
+	ThreadCount  int    `xml:"ThreadCount"`
+
+	Other []xmlElement `xml:",any"`
+}
+
+// xmlElement ist ein Element ohne eigenes Feld in XMLSettings
+type xmlElement struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+// Defaults der VB.NET-Version; gleiche Werte werden nicht als nicht
+// übernommen gemeldet
+const (
+	legacyDefaultPostgreSQLPort = "5432"
+	legacyDefaultMySQLPort      = "3306"
+)
+
+// legacyPostgresReason begründet, warum PostgreSQL-Verbindungsdaten nicht
+// übernommen werden
+const legacyPostgresReason = "export.postgres writes a dump file; load it with psql instead of connecting"
+
+// legacyLanguages ordnet die Sprachnamen der VB.NET-Version ISO-Codes zu
+var legacyLanguages = map[string]string{
+	"English":  "en",
+	"German":   "de",
+	"French":   "fr",
+	"Japanese": "ja",
+	"Russian":  "ru",
+	"Chinese":  "zh",
+	"Spanish":  "es",
+	"Korean":   "ko",
+}
+
+// legacyTables ordnet die SDE-Dateien der VB.NET-Tabellenauswahl den
+// Tabellen (bzw. Tabellengruppen) des JSONL-Imports zu. Dateien mit nil
+// haben im JSONL-SDE keine Entsprechung.
+var legacyTables = map[string][]string{
+	legacyUniverseEntry:                         {"universe"},
+	"types.yaml":                                {"invTypes"},
+	"groups.yaml":                               {"invGroups"},
+	"categories.yaml":                           {"invCategories"},
+	"invCategories.yaml":                        {"invCategories"},
+	"marketGroups.yaml":                         {"invMarketGroups"},
+	"metaGroups.yaml":                           {"invMetaGroups"},
+	"blueprints.yaml":                           {"industryBlueprints"},
+	"dogmaAttributes.yaml":                      {"dogmaAttributes"},
+	"dogmaEffects.yaml":                         {"dogmaEffects"},
+	"dogmaAttributeCategories.yaml":             {"dogmaAttributeCategories"},
+	"typeDogma.yaml":                            {"typeDogma", "dogmaTypeAttributes", "dogmaTypeEffects"},
+	"eveUnits.yaml":                             {"dogmaUnits"},
+	"landmarks.yaml":                            {"mapLandmarks"},
+	"races.yaml":                                {"chrRaces"},
+	"factions.yaml":                             {"chrFactions"},
+	"ancestries.yaml":                           {"chrAncestries"},
+	"bloodlines.yaml":                           {"chrBloodlines"},
+	"characterAttributes.yaml":                  {"chrAttributes"},
+	"npcCorporations.yaml":                      {"crpNPCCorporations"},
+	"npcCorporationDivisions.yaml":              {"crpNPCCorporationDivisions"},
+	"corporationActivities.yaml":                {"crpActivities"},
+	"staStations.yaml":                          {"staStations"},
+	"stationOperations.yaml":                    {"staOperations"},
+	"stationServices.yaml":                      {"staServices"},
+	"agtAgentTypes.yaml":                        {"agtAgentTypes"},
+	"agents.yaml":                               {"agtAgents"},
+	"agentsInSpace.yaml":                        {"agtAgents"},
+	"certificates.yaml":                         {"certCerts", "certMasteries"},
+	"skins.yaml":                                {"skins"},
+	"skinLicenses.yaml":                         {"skinLicenses"},
+	"skinMaterials.yaml":                        {"skinMaterials"},
+	"translationLanguages.yaml":                 {"translationLanguages"},
+	"sovereigntyUpgrades.yaml":                  {"sovereigntyUpgrades"},
+	"iconIDs.yaml":                              {"eveIcons"},
+	"graphicIDs.yaml":                           {"eveGraphics"},
+	"contrabandTypes.yaml":                      {"contrabandTypes"},
+	"invContrabandTypes.yaml":                   {"contrabandTypes"},
+	"controlTowerResources.yaml":                {"controlTowerResources"},
+	"planetResources.yaml":                      {"planetResources"},
+	"planetSchematics.yaml":                     {"planetSchematics"},
+	"planetSchematicsPinMap.yaml":               {"planetSchematics"},
+	"planetSchematicsTypeMap.yaml":              {"planetSchematics"},
+	"typeMaterials.yaml":                        nil,
+	"invFlags.yaml":                             nil,
+	"invItems.yaml":                             nil,
+	"invNames.yaml":                             nil,
+	"invPositions.yaml":                         nil,
+	"invTypeReactions.yaml":                     nil,
+	"invUniqueNames.yaml":                       nil,
+	"mapUniverse.yaml":                          nil,
+	"researchAgents.yaml":                       nil,
+	"staOperationServices.yaml":                 nil,
+	"staStationTypes.yaml":                      nil,
+	"tournamentRuleSets.yaml":                   nil,
+	"ramActivities.yaml":                        nil,
+	"ramAssemblyLineStations.yaml":              nil,
+	"ramAssemblyLineTypes.yaml":                 nil,
+	"ramInstallationTypeContents.yaml":          nil,
+	"warCombatZones.yaml":                       nil,
+	"warCombatZoneSystems.yaml":                 nil,
+	"ramAssemblyLineTypeDetailPerCategory.yaml": nil,
+	"ramAssemblyLineTypeDetailPerGroup.yaml":    nil,
+}
+
+// legacyTranslationFiles sind die Dateien, für die die VB.NET-Version die
+// Übersetzungsdaten mit importiert (GetRequiredTablesForTranslations); die
+// Universe-Daten setzen das Flag immer.
+var legacyTranslationFiles = map[string]bool{
+	legacyUniverseEntry:            true,
+	"ancestries.yaml":              true,
+	"bloodlines.yaml":              true,
+	"categories.yaml":              true,
+	"characterAttributes.yaml":     true,
+	"corporationActivities.yaml":   true,
+	"dogmaAttributes.yaml":         true,
+	"dogmaEffects.yaml":            true,
+	"factions.yaml":                true,
+	"groups.yaml":                  true,
+	"invCategories.yaml":           true,
+	"landmarks.yaml":               true,
+	"marketGroups.yaml":            true,
+	"metaGroups.yaml":              true,
+	"npcCorporationDivisions.yaml": true,
+	"npcCorporations.yaml":         true,
+	"planetSchematics.yaml":        true,
+	"races.yaml":                   true,
+	"ramActivities.yaml":           true,
+	"stationOperations.yaml":       true,
+	"stationServices.yaml":         true,
+	"types.yaml":                   true,
+}
+
+// unmappedSetting ist eine Einstellung der VB.NET-Version ohne Entsprechung
+// in config.toml
+type unmappedSetting struct {
+	Key    string
+	Reason string
+}
+
+// legacyConversion ist das Ergebnis von convertLegacySettings
+type legacyConversion struct {
+	DatabasePath string
+	SDEPath      string
+	Language     string
+	Workers      int
+	Tables       []string                     // import.tables (leer = alle Tabellen)
+	CSV          *config.CSVExportConfig      // [export.csv] (nil = kein CSV-Ziel)
+	Postgres     *config.PostgresExportConfig // [export.postgres] (nil = kein PostgreSQL-Ziel)
+	Companions   []string                     // gelesene Begleitdateien
+	Notes        []string                     // Hinweise zur Übernahme
+	Unmapped     []unmappedSetting
+}
+
+// unmapped vermerkt eine nicht übernommene Einstellung
+func (c *legacyConversion) unmapped(key, reason string) {
+	c.Unmapped = append(c.Unmapped, unmappedSetting{Key: key, Reason: reason})
+}
+
+// convertLegacySettings bildet die VB.NET-Einstellungen auf config.toml ab.
+//
+// dir ist das Verzeichnis der XML-Datei; dort werden die Begleitdateien
+// GridSettings.txt (Tabellenauswahl) und NumberofThreads.txt gelesen, falls
+// vorhanden. Eine unbekannte Sprache ist ein Fehler.
+func convertLegacySettings(settings XMLSettings, dir string) (*legacyConversion, error) {
+	conv := &legacyConversion{}
+
+	language, err := convertLanguage(settings.SelectedLanguage)
+	if err != nil {
+		return nil, err
+	}
+	conv.Language = language
+
+	// Datenbank: FinalDBPath\DatabaseName.sqlite wie in der VB.NET-Version
+	dbName := settings.DatabaseName
+	if dbName == "" {
+		dbName = "eve_sde"
+	}
+	switch {
+	case settings.SQLiteDBPath != "":
+		conv.DatabasePath = settings.SQLiteDBPath
+	case settings.DatabaseName != "" || settings.FinalDBPath != "":
+		conv.DatabasePath = legacyPath(settings.FinalDBPath, dbName+".sqlite")
+	default:
+		conv.DatabasePath = "./eve_sde.db"
+	}
+
+	switch {
+	case settings.SDEPath != "":
+		conv.SDEPath = settings.SDEPath
+	case settings.SDEDirectory != "":
+		conv.SDEPath = settings.SDEDirectory
+		conv.Notes = append(conv.Notes, "import.sde_path is the YAML SDE directory of the VB.NET version; esdedb reads the JSONL SDE")
+	default:
+		conv.SDEPath = "./sde-JSONL"
+	}
+
+	// Datenbanktyp: SQLite ist die Ziel-Datenbank, CSV und PostgreSQL werden
+	// zu Export-Zielen (PostgreSQL als Dump, der mit psql geladen wird)
+	switch settings.SelectedDB {
+	case "", "SQLite":
+	case "CSV":
+		conv.CSV = &config.CSVExportConfig{
+			Path:      legacyPath(settings.FinalDBPath, dbName+"_CSV"),
+			Delimiter: ",",
+		}
+	case "PostgreSQL":
+		conv.Postgres = &config.PostgresExportConfig{
+			Path: legacyPath(settings.FinalDBPath, dbName+".sql"),
+		}
+	default:
+		conv.unmapped("SelectedDB", fmt.Sprintf("no %s target; the database is built as SQLite (database.path)", settings.SelectedDB))
+	}
+	if settings.CSVEUCheck {
+		if conv.CSV == nil {
+			conv.CSV = &config.CSVExportConfig{}
+		}
+		conv.CSV.Delimiter = ";"
+		conv.CSV.DecimalComma = true
+	}
+
+	// Server-Verbindungen und Zugangsdaten haben keine Entsprechung
+	for _, s := range []struct{ key, value, reason string }{
+		{"SQLConnectionString", settings.SQLConnectionString, "no Microsoft SQL Server target"},
+		{"SQLUserName", settings.SQLUserName, "no Microsoft SQL Server target"},
+		{"SQLPassword", settings.SQLPassword, "credentials are not migrated"},
+		{"AccessPassword", settings.AccessPassword, "credentials are not migrated"},
+		{"PostgreSQLConnectionString", settings.PostgreSQLConnectionString, legacyPostgresReason},
+		{"PostgreSQLUserName", settings.PostgreSQLUserName, legacyPostgresReason},
+		{"PostgreSQLPassword", settings.PostgreSQLPassword, "credentials are not migrated"},
+		{"MySQLConnectionString", settings.MySQLConnectionString, "no MySQL target"},
+		{"MySQLUserName", settings.MySQLUserName, "no MySQL target"},
+		{"MySQLPassword", settings.MySQLPassword, "credentials are not migrated"},
+		{"DownloadFolderPath", settings.DownloadFolderPath, "esdedb does not download the SDE"},
+	} {
+		if s.value != "" {
+			conv.unmapped(s.key, s.reason)
+		}
+	}
+	if settings.PostgreSQLPort != "" && settings.PostgreSQLPort != legacyDefaultPostgreSQLPort {
+		conv.unmapped("PostgreSQLPort", legacyPostgresReason)
+	}
+	if settings.MySQLPort != "" && settings.MySQLPort != legacyDefaultMySQLPort {
+		conv.unmapped("MySQLPort", "no MySQL target")
+	}
+	if settings.UseLargerVersion {
+		conv.unmapped("UseLargerVersion", "window size of the VB.NET user interface")
+	}
+	for _, other := range settings.Other {
+		conv.unmapped(other.XMLName.Local, "unknown setting")
+	}
+
+	if err := conv.readThreads(settings.ThreadCount, filepath.Join(dir, legacyThreadsFile)); err != nil {
+		return nil, err
+	}
+	if err := conv.readTables(filepath.Join(dir, legacyGridSettingsFile)); err != nil {
+		return nil, err
+	}
+	return conv, nil
+}
+
+// readThreads übernimmt die Thread-Anzahl aus ThreadCount oder
+// NumberofThreads.txt (-1 = alle Kerne → 0 = Auto). Ohne beide gilt 4.
+func (c *legacyConversion) readThreads(threadCount int, path string) error {
+	c.Workers = 4
+	if threadCount > 0 {
+		c.Workers = threadCount
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", legacyThreadsFile, err)
+	}
+	c.Companions = append(c.Companions, path)
+
+	value := strings.TrimSpace(string(data))
+	if value == "" {
+		return nil
+	}
+	threads, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid thread count in %s: %q", legacyThreadsFile, value)
+	}
+	switch {
+	case threads < 0:
+		c.Workers = 0
+	case threads > 32:
+		c.Workers = 32
+		c.Notes = append(c.Notes, fmt.Sprintf("%s: %d threads reduced to import.workers = 32 (maximum)", legacyThreadsFile, threads))
+	case threads > 0:
+		c.Workers = threads
+	}
+	return nil
+}
+
+// readTables übernimmt die Tabellenauswahl aus GridSettings.txt nach
+// import.tables. Wie in der VB.NET-Version werden die Übersetzungsdaten
+// (translationLanguages) mit importiert, wenn eine ausgewählte Datei sie
+// benötigt. Hat keine ausgewählte Datei eine Tabelle, ist das ein Fehler.
+func (c *legacyConversion) readTables(path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", legacyGridSettingsFile, err)
+	}
+	defer f.Close()
+	c.Companions = append(c.Companions, path)
+
+	seen := map[string]bool{}
+	translations, selected := false, 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		file := strings.TrimSpace(scanner.Text())
+		if file == "" {
+			continue
+		}
+		selected++
+		tables, known := legacyTables[file]
+		switch {
+		case !known:
+			c.unmapped(legacyGridSettingsFile+": "+file, "unknown SDE file")
+		case len(tables) == 0:
+			c.unmapped(legacyGridSettingsFile+": "+file, "no table in the JSONL SDE")
+		}
+		for _, table := range tables {
+			if !seen[table] {
+				seen[table] = true
+				c.Tables = append(c.Tables, table)
+			}
+		}
+		translations = translations || legacyTranslationFiles[file]
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", legacyGridSettingsFile, err)
+	}
+
+	if translations && !seen["translationLanguages"] && len(c.Tables) > 0 {
+		c.Tables = append(c.Tables, "translationLanguages")
+		c.Notes = append(c.Notes, "translationLanguages added to import.tables: the VB.NET version inserts the translations for the selected files")
+	}
+	// Ein leeres import.tables bedeutet "alle Tabellen": die Auswahl darf
+	// nicht stillschweigend auf einen Voll-Import erweitert werden
+	if selected > 0 && len(c.Tables) == 0 {
+		return fmt.Errorf("none of the %d files selected in %s has a table in the JSONL SDE; an empty import.tables would import all tables", selected, legacyGridSettingsFile)
+	}
+	sort.Strings(c.Tables)
+	return nil
+}
+
+// convertLanguage konvertiert VB.NET Sprach-Namen zu ISO-Codes. Ohne Sprache
+// gilt wie in der VB.NET-Version Englisch; unbekannte Sprachen sind ein Fehler.
+func convertLanguage(vbLang string) (string, error) {
+	if vbLang == "" {
+		return "en", nil
+	}
+	if code, ok := legacyLanguages[vbLang]; ok {
+		return code, nil
+	}
+
+	names := make([]string, 0, len(legacyLanguages))
+	for name := range legacyLanguages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "", fmt.Errorf("unknown SelectedLanguage %q (supported: %s)", vbLang, strings.Join(names, ", "))
+}
+
+// legacyPath setzt einen Pfad aus Verzeichnis und Dateiname zusammen und
+// behält dabei das Trennzeichen des Verzeichnisses bei (Windows-Pfade der
+// VB.NET-Version).
+func legacyPath(dir, name string) string {
+	if dir == "" {
+		return "./" + name
+	}
+	sep := "/"
+	if strings.Contains(dir, `\`) {
+		sep = `\`
+	}
+	return strings.TrimRight(dir, `\/`) + sep + name
+}
+
+// tomlString formatiert s als TOML-String; Pfade mit Backslashes werden als
+// Literal-String ('...') geschrieben, damit sie ohne Escaping lesbar bleiben.
+func tomlString(s string) string {
+	if strings.Contains(s, `\`) && !strings.ContainsAny(s, "'\r\n") {
+		return "'" + s + "'"
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// tomlStringList formatiert values als TOML-Array von Strings
+func tomlStringList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = tomlString(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// renderConvertedConfig erzeugt die config.toml einer Konvertierung; nicht
+// übernommene Einstellungen stehen als Kommentar am Anfang.
+func renderConvertedConfig(inputPath string, conv *legacyConversion) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# EVE SDE Database Builder Configuration\n")
+	fmt.Fprintf(&b, "# Converted from VB.NET XML config: %s\n", inputPath)
+	if len(conv.Unmapped) > 0 {
+		fmt.Fprintf(&b, "#\n# Not migrated:\n")
+		for _, u := range conv.Unmapped {
+			fmt.Fprintf(&b, "#   %s: %s\n", u.Key, u.Reason)
+		}
+	}
+	fmt.Fprintf(&b, `version = "1.0.0"
+
+[database]
+path = %s
+journal_mode = "WAL"
+synchronous = "NORMAL"
+cache_size_mb = 64
+busy_timeout_ms = 5000
+
+[import]
+sde_path = %s
+language = %s
+workers = %d
+`, tomlString(conv.DatabasePath), tomlString(conv.SDEPath), tomlString(conv.Language), conv.Workers)
+	if len(conv.Tables) > 0 {
+		fmt.Fprintf(&b, "tables = %s\n", tomlStringList(conv.Tables))
+	}
+
+	fmt.Fprintf(&b, `
+[logging]
+level = "info"   # debug, info, warn, error
+format = "text"  # text, json
+
+[update]
+enabled = false
+check_url = ""
+`)
+
+	if conv.CSV != nil {
+		fmt.Fprintf(&b, "\n[export.csv]\n")
+		if conv.CSV.Path != "" {
+			fmt.Fprintf(&b, "path = %s\n", tomlString(conv.CSV.Path))
+		}
+		fmt.Fprintf(&b, "delimiter = %s\n", tomlString(conv.CSV.Delimiter))
+		fmt.Fprintf(&b, "decimal_comma = %t\n", conv.CSV.DecimalComma)
+	}
+	if conv.Postgres != nil {
+		fmt.Fprintf(&b, "\n[export.postgres]\n")
+		fmt.Fprintf(&b, "path = %s\n", tomlString(conv.Postgres.Path))
+	}
+	return b.String()
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// configShowValue ist ein Eintrag der JSON-Ausgabe von 'config show'
+type configShowValue struct {
+	Key    string      `json:"key"`
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"`
+	Name   string      `json:"name,omitempty"`
+	Auto   bool        `json:"auto,omitempty"`
+}
+
+// configShowOutput ist die JSON-Ausgabe von 'config show'
+type configShowOutput struct {
+	ConfigFile string            `json:"config_file"`
+	Effective  bool              `json:"effective"`
+	Values     []configShowValue `json:"values"`
+}
+
+// newConfigShowCmd erstellt das 'config show' Subcommand
+func newConfigShowCmd() *cobra.Command {
+	var effective bool
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the configuration with the source of each value",
+		Long: `Show command zeigt die Konfiguration als TOML oder JSON an und nennt für
+jeden Schlüssel die Quelle des Werts:
+  default                  - Standardwert
+  file <pfad>              - Konfigurationsdatei (--config)
+  profile <name>           - Profil aus [profiles.<name>] (--profile)
+  env <variable>           - Environment Variable (ESDEDB_*)
+  flag <--flag>            - explizit gesetztes Flag
+
+Ohne --effective werden nur Defaults und Konfigurationsdatei angezeigt.
+Mit --effective wird die Konfiguration wie bei import aufgelöst
+(Defaults < Datei < Profil < Environment Variables < Flags) und validiert; dabei
+wird import.workers = 0 (Auto) durch die ermittelte Worker-Anzahl ersetzt
+und mit "auto" markiert.
+
+Die Flags --db, --sde-dir, --workers, --tables und --exclude-tables
+verhalten sich wie bei import und wirken nur mit --effective.`,
+		Example: `  # Effektive Konfiguration anzeigen
+  esdedb config show --effective
+
+  # Welche Datenbank würde import mit diesen Flags verwenden?
+  esdedb config show --effective --db ./test.db --workers 4
+
+  # Welche Werte setzt das Profil "web"?
+  esdedb --profile web config show --effective
+
+  # Als JSON für Skripte
+  esdedb config show --effective --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigShow(cmd, effective, format)
+		},
+	}
+
+	cmd.Flags().BoolVar(&effective, "effective", false, "Env Variables und Flags anwenden und Konfiguration validieren")
+	cmd.Flags().StringVar(&format, "format", "toml", "Ausgabeformat (toml oder json)")
+
+	// Dieselben Overrides wie bei import
+	defaults := config.DefaultConfig()
+	cmd.Flags().StringP("db", "d", defaults.Database.Path, "Pfad zur SQLite-Datenbank (überschreibt database.path)")
+	cmd.Flags().StringP("sde-dir", "s", defaults.Import.SDEPath, "Pfad zum SDE JSONL-Verzeichnis (überschreibt import.sde_path)")
+	cmd.Flags().IntP("workers", "w", defaults.Import.Workers, "Anzahl paralleler Worker (überschreibt import.workers; 0 oder -1 = Auto)")
+	cmd.Flags().StringSlice("tables", nil, "Tabellen-Patterns (überschreibt import.tables)")
+	cmd.Flags().StringSlice("exclude-tables", nil, "Auszuschließende Tabellen-Patterns (überschreibt import.exclude_tables)")
+
+	return cmd
+}
+
+// runConfigShow gibt die Konfiguration mit Quellen aus
+func runConfigShow(cmd *cobra.Command, effective bool, format string) error {
+	if format != "toml" && format != "json" {
+		return fmt.Errorf("unsupported format: %s (use 'toml' or 'json')", format)
+	}
+
+	var cfg *config.Config
+	var sources config.Sources
+	if effective {
+		var err error
+		if cfg, sources, err = loadConfigWithSources(cmd); err != nil {
+			return err
+		}
+	} else {
+		for _, b := range bindingsFor(cmd) {
+			if f := cmd.Flags().Lookup(b.Flag); f != nil && f.Changed && b.Flag != "verbose" {
+				return fmt.Errorf("--%s wirkt nur mit --effective", b.Flag)
+			}
+		}
+		fileCfg, fileSources, err := config.LoadFile(configPath)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		cfg, sources = &fileCfg, fileSources
+	}
+
+	if format == "json" {
+		return writeConfigShowJSON(cmd.OutOrStdout(), cfg, sources, effective)
+	}
+	return writeConfigShowTOML(cmd.OutOrStdout(), cfg, sources)
+}
+
+// writeConfigShowJSON schreibt die Konfiguration als JSON-Liste von Schlüsseln
+func writeConfigShowJSON(w io.Writer, cfg *config.Config, sources config.Sources, effective bool) error {
+	out := configShowOutput{ConfigFile: configPath, Effective: effective}
+	for _, f := range cfg.Fields() {
+		src := sources.Get(f.Key)
+		value := f.Value
+		// Leere Listen als [] statt null ausgeben
+		if list, ok := value.([]string); ok && list == nil {
+			value = []string{}
+		}
+		out.Values = append(out.Values, configShowValue{
+			Key:    f.Key,
+			Value:  value,
+			Source: string(src.Kind),
+			Name:   src.Name,
+			Auto:   src.Auto,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// writeConfigShowTOML schreibt die Konfiguration als TOML mit der Quelle
+// jedes Schlüssels als Kommentar
+func writeConfigShowTOML(w io.Writer, cfg *config.Config, sources config.Sources) error {
+	type line struct{ text, source string }
+	var sections []string
+	lines := map[string][]line{}
+
+	for _, f := range cfg.Fields() {
+		section, name := "", f.Key
+		if i := strings.LastIndex(f.Key, "."); i >= 0 {
+			section, name = f.Key[:i], f.Key[i+1:]
+		}
+		value, err := tomlValue(f.Value)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", f.Key, err)
+		}
+		if _, ok := lines[section]; !ok {
+			sections = append(sections, section)
+		}
+		lines[section] = append(lines[section], line{name + " = " + value, sources.Get(f.Key).String()})
+	}
+
+	var buf bytes.Buffer
+	for i, section := range sections {
+		if section != "" {
+			if i > 0 {
+				buf.WriteString("\n")
+			}
+			fmt.Fprintf(&buf, "[%s]\n", section)
+		}
+		width := 0
+		for _, l := range lines[section] {
+			width = max(width, len(l.text))
+		}
+		for _, l := range lines[section] {
+			fmt.Fprintf(&buf, "%-*s  # %s\n", width, l.text, l.source)
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// tomlValue kodiert einen einzelnen Wert als TOML (z.B. "\"WAL\"", "64", "[\"a\"]")
+func tomlValue(v interface{}) (string, error) {
+	if list, ok := v.([]string); ok && list == nil {
+		return "[]", nil
+	}
+	data, err := toml.Marshal(map[string]interface{}{"v": v})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimPrefix(string(data), "v = ")), nil
+}
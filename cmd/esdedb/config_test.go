@@ -2,10 +2,16 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
 	"strings"
 	"testing"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/config"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
 )
 
 // TestConfigInitCmd_DefaultOutput testet config init mit Standard-Output-Pfad
@@ -142,8 +148,8 @@ func TestConfigConvertCmd_ValidXML(t *testing.T) {
 	}
 
 	expectedValues := []string{
-		`path = "C:\EVE\Database.db"`,
-		`sde_path = "C:\EVE\sde"`,
+		`path = 'C:\EVE\Database.db'`,
+		`sde_path = 'C:\EVE\sde'`,
 		`language = "en"`,
 		`workers = 8`,
 	}
@@ -178,27 +184,236 @@ func TestConfigConvertCmd_LanguageConversion(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.vbLang, func(t *testing.T) {
-			result := convertLanguage(tt.vbLang)
-			if result != tt.expected {
+			result, err := convertLanguage(tt.vbLang)
+			if err != nil || result != tt.expected {
 				t.Errorf("convertLanguage(%q) = %q, want %q", tt.vbLang, result, tt.expected)
 			}
 		})
 	}
 }
 
-// TestConfigConvertCmd_LanguageFallback testet Fallback für unbekannte Sprachen
-func TestConfigConvertCmd_LanguageFallback(t *testing.T) {
-	// Unbekannte Sprache sollte auf "en" fallen
-	result := convertLanguage("UnknownLanguage")
-	if result != "Un" && result != "en" {
-		// Accept both: first 2 chars or default "en"
-		t.Errorf("convertLanguage(UnknownLanguage) = %q, want 'Un' or 'en'", result)
+// TestConfigConvertCmd_UnknownLanguage testet, dass unbekannte Sprachen ein Fehler sind
+func TestConfigConvertCmd_UnknownLanguage(t *testing.T) {
+	// Ohne Sprache gilt wie in der VB.NET-Version Englisch
+	if result, err := convertLanguage(""); err != nil || result != "en" {
+		t.Errorf("convertLanguage('') = %q, %v, want 'en'", result, err)
+	}
+
+	if _, err := convertLanguage("Klingon"); err == nil || !strings.Contains(err.Error(), "Klingon") {
+		t.Errorf("expected error for unknown language, got %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	inputPath := filepath.Join(tmpDir, "ApplicationSettings.xml")
+	outputPath := filepath.Join(tmpDir, "config.toml")
+	xmlContent := `<?xml version="1.0" encoding="utf-8"?>
+<ApplicationSettings>
+  <SelectedLanguage>Klingon</SelectedLanguage>
+</ApplicationSettings>`
+	if err := os.WriteFile(inputPath, []byte(xmlContent), 0644); err != nil {
+		t.Fatalf("failed to create XML file: %v", err)
+	}
+	if err := runConfigConvert(inputPath, outputPath); err == nil {
+		t.Fatal("runConfigConvert should fail with unknown language")
+	}
+	if _, err := os.Stat(outputPath); !os.IsNotExist(err) {
+		t.Error("config.toml must not be written for an unknown language")
+	}
+}
+
+// TestConfigConvertCmd_ApplicationSettings testet die Übernahme einer
+// vollständigen ApplicationSettings.xml mit Begleitdateien
+func TestConfigConvertCmd_ApplicationSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputPath := filepath.Join(tmpDir, "ApplicationSettings.xml")
+	outputPath := filepath.Join(tmpDir, "config.toml")
+
+	xmlContent := `<?xml version="1.0" encoding="utf-8"?>
+<ApplicationSettings>
+  <SelectedDB>CSV</SelectedDB>
+  <SDEDirectory>C:\EVE\sde</SDEDirectory>
+  <DatabaseName>EVESDE</DatabaseName>
+  <FinalDBPath>C:\EVE\out</FinalDBPath>
+  <DownloadFolderPath>C:\Downloads</DownloadFolderPath>
+  <SQLConnectionString></SQLConnectionString>
+  <SQLUserName></SQLUserName>
+  <SQLPassword></SQLPassword>
+  <AccessPassword></AccessPassword>
+  <PostgreSQLConnectionString>localhost</PostgreSQLConnectionString>
+  <PostgreSQLUserName></PostgreSQLUserName>
+  <PostgreSQLPassword>secret</PostgreSQLPassword>
+  <PostgreSQLPort>5432</PostgreSQLPort>
+  <MySQLConnectionString></MySQLConnectionString>
+  <MySQLUserName></MySQLUserName>
+  <MySQLPassword></MySQLPassword>
+  <MySQLPort>3306</MySQLPort>
+  <CSVEUCheck>True</CSVEUCheck>
+  <SelectedLanguage>French</SelectedLanguage>
+  <UseLargerVersion>False</UseLargerVersion>
+  <WindowColor>Blue</WindowColor>
+</ApplicationSettings>`
+	grid := "types.yaml\ngroups.yaml\ntypeMaterials.yaml\nUniverse Data (multiple files)\n"
+	files := map[string]string{
+		inputPath: xmlContent,
+		filepath.Join(tmpDir, legacyGridSettingsFile): grid,
+		filepath.Join(tmpDir, legacyThreadsFile):      "-1",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create %s: %v", path, err)
+		}
+	}
+
+	if err := runConfigConvert(inputPath, outputPath); err != nil {
+		t.Fatalf("runConfigConvert failed: %v", err)
+	}
+
+	// Die Ausgabe muss eine gültige Konfiguration sein
+	cfg, err := config.Load(outputPath)
+	if err != nil {
+		t.Fatalf("converted config is invalid: %v", err)
+	}
+	if cfg.Database.Path != `C:\EVE\out\EVESDE.sqlite` || cfg.Import.SDEPath != `C:\EVE\sde` {
+		t.Errorf("unexpected paths: database %q, sde %q", cfg.Database.Path, cfg.Import.SDEPath)
+	}
+	if cfg.Import.Language != "fr" {
+		t.Errorf("expected language fr, got %q", cfg.Import.Language)
+	}
+	if cfg.Import.Workers != runtime.NumCPU() {
+		t.Errorf("expected auto workers for -1 threads, got %d", cfg.Import.Workers)
+	}
+	wantTables := []string{"invGroups", "invTypes", "translationLanguages", "universe"}
+	if strings.Join(cfg.Import.Tables, ",") != strings.Join(wantTables, ",") {
+		t.Errorf("import.tables = %v, want %v", cfg.Import.Tables, wantTables)
+	}
+	csv := cfg.Export.CSV
+	if csv.Path != `C:\EVE\out\EVESDE_CSV` || csv.Delimiter != ";" || !csv.DecimalComma {
+		t.Errorf("unexpected export.csv: %+v", csv)
 	}
 
-	// Leerer String sollte auf "en" fallen
-	result = convertLanguage("")
-	if result != "en" {
-		t.Errorf("convertLanguage('') = %q, want 'en'", result)
+	// Nicht übernommene Einstellungen stehen als Kommentar in der Datei;
+	// leere Werte und VB.NET-Defaults werden nicht gemeldet
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read config.toml: %v", err)
+	}
+	for _, want := range []string{
+		"#   PostgreSQLConnectionString: " + legacyPostgresReason,
+		"#   PostgreSQLPassword: credentials are not migrated",
+		"#   DownloadFolderPath: esdedb does not download the SDE",
+		"#   WindowColor: unknown setting",
+		"#   GridSettings.txt: typeMaterials.yaml: no table in the JSONL SDE",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("config.toml missing %q:\n%s", want, content)
+		}
+	}
+	for _, unwanted := range []string{"SQLPassword:", "PostgreSQLPort", "MySQLPort", "UseLargerVersion"} {
+		if strings.Contains(string(content), "#   "+unwanted) {
+			t.Errorf("config.toml must not report %q:\n%s", unwanted, content)
+		}
+	}
+	if strings.Contains(string(content), "secret") {
+		t.Errorf("config.toml must not contain credentials:\n%s", content)
+	}
+}
+
+// TestConfigConvertCmd_PostgreSQL testet, dass SelectedDB = PostgreSQL zum
+// Export-Ziel [export.postgres] wird
+func TestConfigConvertCmd_PostgreSQL(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputPath := filepath.Join(tmpDir, "ApplicationSettings.xml")
+	outputPath := filepath.Join(tmpDir, "config.toml")
+
+	xmlContent := `<?xml version="1.0" encoding="utf-8"?>
+<ApplicationSettings>
+  <SelectedDB>PostgreSQL</SelectedDB>
+  <DatabaseName>EVESDE</DatabaseName>
+  <FinalDBPath>C:\EVE\out</FinalDBPath>
+  <PostgreSQLConnectionString>localhost</PostgreSQLConnectionString>
+  <PostgreSQLPort>5433</PostgreSQLPort>
+  <SelectedLanguage>English</SelectedLanguage>
+</ApplicationSettings>`
+	if err := os.WriteFile(inputPath, []byte(xmlContent), 0644); err != nil {
+		t.Fatalf("failed to create XML: %v", err)
+	}
+
+	if err := runConfigConvert(inputPath, outputPath); err != nil {
+		t.Fatalf("runConfigConvert failed: %v", err)
+	}
+
+	cfg, err := config.Load(outputPath)
+	if err != nil {
+		t.Fatalf("converted config is invalid: %v", err)
+	}
+	if cfg.Export.Postgres.Path != `C:\EVE\out\EVESDE.sql` {
+		t.Errorf("expected export.postgres.path C:\\EVE\\out\\EVESDE.sql, got %q", cfg.Export.Postgres.Path)
+	}
+	if cfg.Database.Path != `C:\EVE\out\EVESDE.sqlite` {
+		t.Errorf("expected SQLite database C:\\EVE\\out\\EVESDE.sqlite, got %q", cfg.Database.Path)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read config.toml: %v", err)
+	}
+	if strings.Contains(string(content), "#   SelectedDB") || strings.Contains(string(content), "no PostgreSQL target") {
+		t.Errorf("PostgreSQL must not be reported as unsupported:\n%s", content)
+	}
+	for _, want := range []string{
+		"#   PostgreSQLConnectionString: " + legacyPostgresReason,
+		"#   PostgreSQLPort: " + legacyPostgresReason,
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("config.toml missing %q:\n%s", want, content)
+		}
+	}
+}
+
+// TestConfigConvertCmd_NoLegacyTables testet, dass eine Tabellenauswahl ohne
+// JSONL-Tabellen nicht zu einem Voll-Import (leeres import.tables) wird
+func TestConfigConvertCmd_NoLegacyTables(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputPath := filepath.Join(tmpDir, "ApplicationSettings.xml")
+	outputPath := filepath.Join(tmpDir, "config.toml")
+	files := map[string]string{
+		inputPath: `<?xml version="1.0" encoding="utf-8"?>
+<ApplicationSettings>
+  <SelectedLanguage>English</SelectedLanguage>
+</ApplicationSettings>`,
+		filepath.Join(tmpDir, legacyGridSettingsFile): "typeMaterials.yaml\nunknown.yaml\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create %s: %v", path, err)
+		}
+	}
+
+	err := runConfigConvert(inputPath, outputPath)
+	if err == nil || !strings.Contains(err.Error(), legacyGridSettingsFile) {
+		t.Fatalf("expected %s error, got %v", legacyGridSettingsFile, err)
+	}
+	if _, err := os.Stat(outputPath); !os.IsNotExist(err) {
+		t.Error("config.toml must not be written without tables")
+	}
+}
+
+// TestConfigConvertCmd_LegacyTables prüft, dass alle Zuordnungen der
+// Tabellenauswahl auf registrierte Tabellen oder Gruppen zeigen
+func TestConfigConvertCmd_LegacyTables(t *testing.T) {
+	known := parser.RegisterParsers()
+	groups := parser.TableGroups()
+	for file, tables := range legacyTables {
+		for _, table := range tables {
+			if _, ok := known[table]; !ok && groups[table] == nil {
+				t.Errorf("%s: unknown table %q", file, table)
+			}
+		}
+	}
+	for file := range legacyTranslationFiles {
+		if _, ok := legacyTables[file]; !ok {
+			t.Errorf("translation file %s missing in legacyTables", file)
+		}
 	}
 }
 
@@ -389,3 +604,103 @@ func TestConfigConvertCmd_IntegrationWithCLI(t *testing.T) {
 		t.Error("converted config missing ThreadCount conversion")
 	}
 }
+
+// TestConfigShowCmd testet config show mit und ohne --effective
+func TestConfigShowCmd(t *testing.T) {
+	writeSettingsConfig(t, settingsTestConfig)
+	t.Setenv("ESDEDB_LOG_LEVEL", "error")
+
+	// Ohne --effective: nur Defaults und Datei
+	cmd := newConfigShowCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	if err := cmd.ParseFlags(nil); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	if err := runConfigShow(cmd, false, "toml"); err != nil {
+		t.Fatalf("config show failed: %v", err)
+	}
+	if !regexp.MustCompile(`(?m)^path = "\./file\.db" +# file ` + regexp.QuoteMeta(configPath) + `$`).MatchString(out.String()) {
+		t.Errorf("expected database.path from file:\n%s", out.String())
+	}
+	for _, want := range []string{
+		`level = "warn"`,
+		"workers = 6",
+		"[database]",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	// Mit --effective: Env und Flags gewinnen
+	cmd = newConfigShowCmd()
+	out.Reset()
+	cmd.SetOut(&out)
+	if err := cmd.ParseFlags([]string{"--db", "./flag.db", "--workers", "0"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	if err := runConfigShow(cmd, true, "json"); err != nil {
+		t.Fatalf("config show --effective failed: %v", err)
+	}
+	var result configShowOutput
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	values := map[string]configShowValue{}
+	for _, v := range result.Values {
+		values[v.Key] = v
+	}
+	if v := values["database.path"]; v.Value != "./flag.db" || v.Source != "flag" || v.Name != "--db" {
+		t.Errorf("unexpected database.path: %+v", v)
+	}
+	if v := values["logging.level"]; v.Value != "error" || v.Source != "env" || v.Name != "ESDEDB_LOG_LEVEL" {
+		t.Errorf("unexpected logging.level: %+v", v)
+	}
+	if v := values["import.workers"]; v.Value != float64(runtime.NumCPU()) || !v.Auto || v.Source != "flag" {
+		t.Errorf("expected auto-resolved workers, got %+v", v)
+	}
+
+	// Override-Flags nur mit --effective
+	cmd = newConfigShowCmd()
+	if err := cmd.ParseFlags([]string{"--db", "./flag.db"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	if err := runConfigShow(cmd, false, "toml"); err == nil || !strings.Contains(err.Error(), "--effective") {
+		t.Errorf("expected error for --db without --effective, got %v", err)
+	}
+	if err := runConfigShow(cmd, true, "yaml"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+// TestConfigSchemaCmd testet config schema auf stdout und in eine Datei
+func TestConfigSchemaCmd(t *testing.T) {
+	var out bytes.Buffer
+	if err := runConfigSchema(&out, ""); err != nil {
+		t.Fatalf("config schema failed: %v", err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &schema); err != nil {
+		t.Fatalf("invalid JSON schema: %v", err)
+	}
+	if _, ok := schema["definitions"].(map[string]interface{})["database"]; !ok {
+		t.Error("schema missing database definition")
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "config.schema.json")
+	out.Reset()
+	if err := runConfigSchema(&out, outputPath); err != nil {
+		t.Fatalf("config schema --output failed: %v", err)
+	}
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("schema file not written: %v", err)
+	}
+	if !json.Valid(data) {
+		t.Error("schema file is not valid JSON")
+	}
+	if !strings.Contains(out.String(), outputPath) {
+		t.Errorf("expected confirmation, got %q", out.String())
+	}
+}
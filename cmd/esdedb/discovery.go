@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/logger"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/worker"
+)
+
+// discoverImportPlan ordnet die JSONL-Dateien in sdeDir den registrierten
+// Parsern zu, ohne die Datenbank zu öffnen oder Dateien zu lesen.
+//
+// Dateien ohne Parser und über den Suffix-Fallback zugeordnete Dateien werden
+// mit der verwendeten Regel geloggt. Mit --fail-on-unknown-files führen sie
+// zu einem Fehler (z.B. in CI, wenn CCP neue Dateien einführt).
+//
+// selection wählt die Tabellen aus (worker.WithTableFilter bzw.
+// worker.WithTargets bei --all-profiles, jeweils mit worker.WithTableSettings).
+func discoverImportPlan(selection ...worker.OrchestratorOption) (*worker.ImportPlan, error) {
+	log := logger.GetGlobalLogger()
+
+	orch := worker.NewOrchestrator(nil, nil, parser.RegisterParsers(), selection...)
+	plan, err := orch.Discover(sdeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range plan.Unmatched {
+		log.Warn("JSONL file without matching parser",
+			logger.Field{Key: "file", Value: filepath.Base(file)},
+		)
+	}
+	for _, m := range plan.Fallbacks {
+		log.Warn("JSONL file matched via fallback rule",
+			logger.Field{Key: "file", Value: filepath.Base(m.File)},
+			logger.Field{Key: "parser", Value: m.ParserKey},
+			logger.Field{Key: "table", Value: m.Table},
+			logger.Field{Key: "rule", Value: string(m.Rule)},
+		)
+	}
+	if skipped := plan.SkippedTables(); len(skipped) > 0 {
+		log.Info("Skipping tables excluded by table filter or tables.<name>.skip",
+			logger.Field{Key: "skipped_tables", Value: len(skipped)},
+		)
+	}
+
+	if failOnUnknownFiles && plan.HasUnknownFiles() {
+		displayUnmatchedFiles(plan.Unmatched)
+		displayFallbackMatches(plan.Fallbacks)
+		return nil, fmt.Errorf("found %d unmatched and %d fallback-matched files (--fail-on-unknown-files)",
+			len(plan.Unmatched), len(plan.Fallbacks))
+	}
+
+	return plan, nil
+}
+
+// displayUnmatchedFiles listet JSONL-Dateien ohne passenden Parser auf.
+func displayUnmatchedFiles(unmatched []string) {
+	if len(unmatched) == 0 {
+		return
+	}
+	fmt.Printf("\nUnmatched files (no parser registered, %d):\n", len(unmatched))
+	for _, file := range unmatched {
+		fmt.Printf("  %s\n", filepath.Base(file))
+	}
+}
+
+// displayFallbackMatches listet über eine Fallback-Regel zugeordnete Dateien
+// mit Parser und Regel auf.
+func displayFallbackMatches(matches []worker.FileMatch) {
+	if len(matches) == 0 {
+		return
+	}
+	fmt.Printf("\nFallback matches (verify parser assignment, %d):\n", len(matches))
+	for _, m := range matches {
+		fmt.Printf("  %-36s -> %-30s (%s)\n", filepath.Base(m.File), m.ParserKey, m.Rule)
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/logger"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/worker"
+)
+
+// maxListedSkippedLines begrenzt die Anzahl ausgegebener Zeilennummern pro Datei
+const maxListedSkippedLines = 10
+
+// runDryRun führt --dry-run bzw. --parse-only aus, ohne die Datenbank zu öffnen.
+//
+// --dry-run parst und validiert jede Datei vollständig und meldet geplante
+// Tabellen, Spalten, Zeilenzahlen, übersprungene Zeilen und nicht zugeordnete
+// Dateien. --parse-only führt nur die Parse-Phase des Imports aus und meldet
+// den Durchsatz. Fehlgeschlagene Dateien führen zu einem Fehler, außer mit
+// --skip-errors.
+//
+// selection wählt die Tabellen aus (Tabellenfilter und [tables.<name>]).
+func runDryRun(ctx context.Context, parseOnly bool, selection ...worker.OrchestratorOption) error {
+	log := logger.GetGlobalLogger()
+
+	opts := []worker.OrchestratorOption{
+		worker.WithChunkSize(int64(chunkSizeMiB) << 20),
+		worker.WithJobTimeout(jobTimeout),
+	}
+	orch := worker.NewOrchestrator(nil, worker.NewPool(workerCount), parser.RegisterParsers(), append(opts, selection...)...)
+
+	var report *worker.ParseReport
+	var err error
+	if parseOnly {
+		log.Info("Parse-only run (no database access)")
+		report, err = orch.ParseAll(ctx, sdeDir)
+	} else {
+		log.Info("Dry run (no database access)")
+		report, err = orch.DryRun(ctx, sdeDir)
+	}
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			log.Warn("Dry run cancelled by user")
+			return nil
+		}
+		return fmt.Errorf("dry run failed: %w", err)
+	}
+
+	if len(report.Files) == 0 {
+		return fmt.Errorf("no JSONL files with matching parser found in %s", sdeDir)
+	}
+
+	if parseOnly {
+		displayParseOnlyReport(report)
+	} else {
+		displayDryRunReport(report)
+	}
+
+	log.Info("Dry run completed",
+		logger.Field{Key: "files", Value: len(report.Files)},
+		logger.Field{Key: "failed_files", Value: report.FailedFiles()},
+		logger.Field{Key: "unmatched_files", Value: len(report.Unmatched)},
+		logger.Field{Key: "fallback_files", Value: len(report.Fallbacks)},
+		logger.Field{Key: "rows", Value: report.TotalRows()},
+		logger.Field{Key: "duration", Value: report.Duration},
+	)
+
+	if failed := report.FailedFiles(); failed > 0 && !skipErrors {
+		return fmt.Errorf("%d of %d files failed to parse", failed, len(report.Files))
+	}
+	return nil
+}
+
+// displayDryRunReport gibt den Dry-Run-Bericht pro Datei aus.
+func displayDryRunReport(report *worker.ParseReport) {
+	fmt.Printf("\n=== Dry Run (no database opened) ===\n")
+	if build, err := parser.ReadSDEBuildNumber(filepath.Join(sdeDir, parser.SDEMetadataFile)); err == nil {
+		fmt.Printf("SDE Build: %d\n", build)
+	}
+	fmt.Printf("\n%-36s %-30s %12s %8s\n", "File", "Table", "Rows", "Skipped")
+	fmt.Printf("%-36s %-30s %12s %8s\n", "----", "-----", "----", "-------")
+	for _, f := range report.Files {
+		fmt.Printf("%-36s %-30s %12d %8d\n", filepath.Base(f.File), f.Table, f.Rows, len(f.SkippedLines))
+		fmt.Printf("  columns: %s\n", strings.Join(f.Columns, ", "))
+		if len(f.SkippedLines) > 0 {
+			fmt.Printf("  skipped lines: %s\n", formatLineNumbers(f.SkippedLines, maxListedSkippedLines))
+		}
+		if f.InvalidRecords > 0 {
+			fmt.Printf("  invalid records: %d\n", f.InvalidRecords)
+		}
+		if f.Err != nil {
+			fmt.Printf("  ERROR: %v\n", f.Err)
+		}
+	}
+
+	displayUnmatchedFiles(report.Unmatched)
+	displayFallbackMatches(report.Fallbacks)
+	if skipped := report.SkippedTables(); len(skipped) > 0 {
+		fmt.Printf("\n")
+		displaySkippedTables(skipped)
+	}
+
+	fmt.Printf("\nFiles: %d (%d failed), Rows: %d, Duration: %v\n\n",
+		len(report.Files), report.FailedFiles(), report.TotalRows(), report.Duration.Round(time.Millisecond))
+}
+
+// displayParseOnlyReport gibt Parse-Dauer und Durchsatz pro Datei aus.
+func displayParseOnlyReport(report *worker.ParseReport) {
+	fmt.Printf("\n=== Parse Only (no database opened) ===\n")
+	fmt.Printf("%-36s %12s %10s %12s %12s\n", "File", "Rows", "Size", "Duration", "Rows/sec")
+	fmt.Printf("%-36s %12s %10s %12s %12s\n", "----", "----", "----", "--------", "--------")
+	for _, f := range report.Files {
+		if f.Err != nil {
+			fmt.Printf("%-36s ERROR: %v\n", filepath.Base(f.File), f.Err)
+			continue
+		}
+		fmt.Printf("%-36s %12d %10s %12v %12.0f\n",
+			filepath.Base(f.File), f.Rows, formatBytes(f.Bytes), f.Duration.Round(time.Millisecond), perSecond(float64(f.Rows), f.Duration.Seconds()))
+	}
+
+	displayUnmatchedFiles(report.Unmatched)
+	displayFallbackMatches(report.Fallbacks)
+
+	seconds := report.Duration.Seconds()
+	fmt.Printf("\nFiles:      %d (%d failed)\n", len(report.Files), report.FailedFiles())
+	fmt.Printf("Rows:       %d\n", report.TotalRows())
+	if skipped := report.SkippedTables(); len(skipped) > 0 {
+		fmt.Printf("Skipped:    %d tables (%s)\n", len(skipped), strings.Join(skipped, ", "))
+	}
+	fmt.Printf("Duration:   %v\n", report.Duration.Round(time.Millisecond))
+	fmt.Printf("Throughput: %.0f rows/sec, %s/sec\n\n",
+		perSecond(float64(report.TotalRows()), seconds), formatBytes(int64(perSecond(float64(report.TotalBytes()), seconds))))
+}
+
+// formatLineNumbers formatiert Zeilennummern, gekürzt auf max Einträge.
+func formatLineNumbers(lines []int, max int) string {
+	parts := make([]string, 0, max+1)
+	for i, line := range lines {
+		if i == max {
+			parts = append(parts, fmt.Sprintf("... (%d more)", len(lines)-max))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%d", line))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// perSecond berechnet eine Rate und vermeidet Division durch 0.
+func perSecond(value, seconds float64) float64 {
+	if seconds <= 0 {
+		return 0
+	}
+	return value / seconds
+}
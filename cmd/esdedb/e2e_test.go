@@ -1,6 +1,9 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -359,3 +362,762 @@ func TestE2E_VerifyDatabaseSchema(t *testing.T) {
 		}
 	})
 }
+
+// TestE2E_ImportCommand_DeferIndexes tests that --defer-indexes builds indexes after loading data
+func TestE2E_ImportCommand_DeferIndexes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	binary := buildTestBinary(t)
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "deferred-indexes.db")
+
+	testDataDir := filepath.Join("..", "..", "testdata", "sde")
+	absTestDataDir, err := filepath.Abs(testDataDir)
+	if err != nil {
+		t.Fatalf("failed to get absolute path: %v", err)
+	}
+
+	cmd := exec.Command(binary, "import",
+		"--sde-dir", absTestDataDir,
+		"--db", dbPath,
+		"--skip-errors",
+		"--defer-indexes",
+	)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("import failed: %v\nOutput: %s", err, output)
+	}
+
+	// Index build time is reported separately in the summary
+	if !strings.Contains(string(output), "Indexes:") {
+		t.Errorf("expected 'Indexes:' line in summary, got: %s", output)
+	}
+
+	db, err := database.NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer func() { _ = database.Close(db) }()
+
+	for _, index := range []string{"idx_invTypes_groupID", "idx_industryActivities_blueprintTypeID", "idx_mapPlanets_typeID"} {
+		var count int
+		err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='index' AND name=?", index).Scan(&count)
+		if err != nil {
+			t.Errorf("failed to check index %s: %v", index, err)
+		}
+		if count == 0 {
+			t.Errorf("expected index %s to exist after deferred creation", index)
+		}
+	}
+}
+
+// TestE2E_ImportCommand_Finalize tests the post-import finalize phase
+// (analyze, optimize and checkpoint run by default)
+func TestE2E_ImportCommand_Finalize(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	binary := buildTestBinary(t)
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "finalize.db")
+	compactPath := filepath.Join(tmpDir, "compact.db")
+
+	testDataDir := filepath.Join("..", "..", "testdata", "sde")
+	absTestDataDir, err := filepath.Abs(testDataDir)
+	if err != nil {
+		t.Fatalf("failed to get absolute path: %v", err)
+	}
+
+	cmd := exec.Command(binary, "import",
+		"--sde-dir", absTestDataDir,
+		"--db", dbPath,
+		"--skip-errors",
+		"--integrity-check",
+		"--vacuum-into", compactPath,
+	)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("import failed: %v\nOutput: %s", err, output)
+	}
+
+	outputStr := string(output)
+	for _, step := range []string{"=== Finalize ===", "analyze", "optimize", "vacuum_into", "integrity_check", "wal_checkpoint"} {
+		if !strings.Contains(outputStr, step) {
+			t.Errorf("expected %q in finalize summary, got: %s", step, outputStr)
+		}
+	}
+
+	if _, err := os.Stat(compactPath); err != nil {
+		t.Errorf("expected compacted copy at %s: %v", compactPath, err)
+	}
+
+	db, err := database.NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer func() { _ = database.Close(db) }()
+
+	var statCount int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_stat1").Scan(&statCount); err != nil {
+		t.Fatalf("expected sqlite_stat1 after ANALYZE: %v", err)
+	}
+	if statCount == 0 {
+		t.Error("expected sqlite_stat1 to contain statistics")
+	}
+}
+
+// TestE2E_ImportCommand_RecordsImportRun tests that import provenance is stored and shown by stats
+func TestE2E_ImportCommand_RecordsImportRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	binary := buildTestBinary(t)
+	dbPath := filepath.Join(t.TempDir(), "provenance.db")
+
+	absTestDataDir, err := filepath.Abs(filepath.Join("..", "..", "testdata", "sde"))
+	if err != nil {
+		t.Fatalf("failed to get absolute path: %v", err)
+	}
+
+	cmd := exec.Command(binary, "import", "--sde-dir", absTestDataDir, "--db", dbPath, "--skip-errors")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("import failed: %v\nOutput: %s", err, output)
+	}
+
+	db, err := database.NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	runs, err := database.ListImportRuns(context.Background(), db)
+	_ = database.Close(db)
+	if err != nil {
+		t.Fatalf("failed to list import runs: %v", err)
+	}
+	if len(runs) != 1 {
+		t.Fatalf("expected 1 import run, got %d", len(runs))
+	}
+
+	run := runs[0]
+	if run.SDEVersion != "20250101" {
+		t.Errorf("expected SDE version from _sde.jsonl, got %q", run.SDEVersion)
+	}
+	if run.Flags["sde-dir"] != absTestDataDir {
+		t.Errorf("expected sde-dir flag to be recorded, got %v", run.Flags)
+	}
+	if _, ok := run.Flags["workers"]; ok {
+		t.Errorf("expected unset workers flag not to be recorded, got %v", run.Flags)
+	}
+	if len(run.FileChecksums["invTypes.jsonl"]) != 64 {
+		t.Errorf("expected SHA-256 checksum for invTypes.jsonl, got %q", run.FileChecksums["invTypes.jsonl"])
+	}
+	if run.RowCounts["invTypes"] == 0 {
+		t.Errorf("expected row count for invTypes, got %v", run.RowCounts)
+	}
+	if run.FinishedAt.Before(run.StartedAt) {
+		t.Errorf("expected finished_at after started_at, got %v - %v", run.StartedAt, run.FinishedAt)
+	}
+
+	statsCmd := exec.Command(binary, "stats", "--db", dbPath)
+	output, err := statsCmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("stats failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "Last Import Run") || !strings.Contains(string(output), "20250101") {
+		t.Errorf("expected import provenance in stats output, got: %s", output)
+	}
+}
+
+// TestE2E_ImportCommand_DowngradeProtection tests that an older SDE build is not
+// imported over a newer one unless --force is given
+func TestE2E_ImportCommand_DowngradeProtection(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	binary := buildTestBinary(t)
+	dbPath := filepath.Join(t.TempDir(), "downgrade.db")
+
+	absTestDataDir, err := filepath.Abs(filepath.Join("..", "..", "testdata", "sde"))
+	if err != nil {
+		t.Fatalf("failed to get absolute path: %v", err)
+	}
+
+	// Test data contains invalid files: a partial import must not store the build
+	partialPath := filepath.Join(t.TempDir(), "partial.db")
+	cmd := exec.Command(binary, "import", "--sde-dir", absTestDataDir, "--db", partialPath, "--skip-errors")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("import failed: %v\nOutput: %s", err, output)
+	}
+	db, err := database.NewDB(partialPath)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	build, err := database.GetUserVersion(context.Background(), db)
+	_ = database.Close(db)
+	if err != nil {
+		t.Fatalf("failed to read user_version: %v", err)
+	}
+	if build != 0 {
+		t.Errorf("expected user_version 0 after partial import, got %d", build)
+	}
+
+	// A complete import (no table filter, no failed files) stores the build;
+	// the SDE dir holds only valid files
+	sdeDir := filepath.Join(t.TempDir(), "sde")
+	if err := os.MkdirAll(sdeDir, 0755); err != nil {
+		t.Fatalf("failed to create SDE dir: %v", err)
+	}
+	for _, name := range []string{"_sde.jsonl", "invTypes.jsonl"} {
+		data, err := os.ReadFile(filepath.Join(absTestDataDir, name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(sdeDir, name), data, 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	cmd = exec.Command(binary, "import", "--sde-dir", sdeDir, "--db", dbPath)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("import failed: %v\nOutput: %s", err, output)
+	}
+
+	// Build number from _sde.jsonl must be stored in user_version
+	db, err = database.NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	build, err = database.GetUserVersion(context.Background(), db)
+	if err != nil {
+		_ = database.Close(db)
+		t.Fatalf("failed to read user_version: %v", err)
+	}
+	if build != 20250101 {
+		t.Errorf("expected user_version 20250101, got %d", build)
+	}
+
+	// Simulate a database built from a newer SDE (rows are removed so the
+	// forced re-import below does not hit the primary key)
+	newer := &database.ImportRun{SDEBuildNumber: 20250201, StartedAt: time.Now(), FinishedAt: time.Now()}
+	if err := database.RecordImportRun(context.Background(), db, newer); err != nil {
+		_ = database.Close(db)
+		t.Fatalf("failed to record import run: %v", err)
+	}
+	if err := database.SetUserVersion(context.Background(), db, 20250201); err != nil {
+		_ = database.Close(db)
+		t.Fatalf("failed to set user_version: %v", err)
+	}
+	clearInvTypes(t, dbPath)
+	_ = database.Close(db)
+
+	cmd = exec.Command(binary, "import", "--sde-dir", sdeDir, "--db", dbPath)
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected downgrade to be refused\nOutput: %s", output)
+	}
+	if !strings.Contains(string(output), "--force") {
+		t.Errorf("expected error to mention --force, got: %s", output)
+	}
+
+	cmd = exec.Command(binary, "import", "--sde-dir", sdeDir, "--db", dbPath, "--force")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("forced import failed: %v\nOutput: %s", err, output)
+	}
+
+	// After the forced downgrade, a plain re-import of the same build succeeds
+	clearInvTypes(t, dbPath)
+	cmd = exec.Command(binary, "import", "--sde-dir", sdeDir, "--db", dbPath)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("re-import of the downgraded build failed: %v\nOutput: %s", err, output)
+	}
+}
+
+// clearInvTypes removes all invTypes rows, so a re-import does not hit the
+// primary key
+func clearInvTypes(t *testing.T, dbPath string) {
+	t.Helper()
+	db, err := database.NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer func() { _ = database.Close(db) }()
+	if _, err := db.Exec("DELETE FROM invTypes"); err != nil {
+		t.Fatalf("failed to clear invTypes: %v", err)
+	}
+}
+
+// TestE2E_ImportCommand_DryRun tests that --dry-run and --parse-only report
+// without creating a database
+func TestE2E_ImportCommand_DryRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	binary := buildTestBinary(t)
+	dbPath := filepath.Join(t.TempDir(), "dryrun.db")
+
+	absTestDataDir, err := filepath.Abs(filepath.Join("..", "..", "testdata", "sde"))
+	if err != nil {
+		t.Fatalf("failed to get absolute path: %v", err)
+	}
+
+	// Test data contains files whose records do not match the table columns
+	cmd := exec.Command(binary, "import", "--sde-dir", absTestDataDir, "--db", dbPath, "--dry-run")
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected dry run to fail for invalid files\nOutput: %s", output)
+	}
+	if !strings.Contains(string(output), "files failed to parse") {
+		t.Errorf("expected failure summary, got: %s", output)
+	}
+
+	cmd = exec.Command(binary, "import", "--sde-dir", absTestDataDir, "--db", dbPath, "--dry-run", "--skip-errors")
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("dry run failed: %v\nOutput: %s", err, output)
+	}
+	for _, want := range []string{"Dry Run (no database opened)", "invTypes", "columns: typeID", "SDE Build: 20250101"} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("expected dry run output to contain %q", want)
+		}
+	}
+
+	cmd = exec.Command(binary, "import", "--sde-dir", absTestDataDir, "--db", dbPath, "--parse-only", "--skip-errors")
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("parse-only run failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "Throughput:") {
+		t.Errorf("expected throughput in parse-only output, got: %s", output)
+	}
+
+	if _, err := os.Stat(dbPath); !os.IsNotExist(err) {
+		t.Errorf("expected no database to be created, stat error: %v", err)
+	}
+
+	cmd = exec.Command(binary, "import", "--sde-dir", absTestDataDir, "--dry-run", "--parse-only")
+	if output, err := cmd.CombinedOutput(); err == nil {
+		t.Errorf("expected --dry-run and --parse-only to be mutually exclusive\nOutput: %s", output)
+	}
+}
+
+// TestE2E_ImportCommand_TableFilters tests --tables/--exclude-tables and the
+// import.tables config entry in dry-run mode
+func TestE2E_ImportCommand_TableFilters(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	binary := buildTestBinary(t)
+	tmpDir := t.TempDir()
+
+	absTestDataDir, err := filepath.Abs(filepath.Join("..", "..", "testdata", "sde"))
+	if err != nil {
+		t.Fatalf("failed to get absolute path: %v", err)
+	}
+
+	// Excluding the tables with invalid test data makes the dry run pass
+	cmd := exec.Command(binary, "import", "--sde-dir", absTestDataDir, "--dry-run",
+		"--exclude-tables", "character,staOperations")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("dry run with excluded tables failed: %v\nOutput: %s", err, output)
+	}
+	for _, want := range []string{"Skipped:", "crpNPCCorporations", "staStations", "staOperations"} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("expected dry run output to contain %q\nOutput: %s", want, output)
+		}
+	}
+
+	// Table selection from the config file
+	configPath := filepath.Join(tmpDir, "tables.toml")
+	configContent := `version = "1.0.0"
+
+[database]
+path = "./test.db"
+
+[import]
+sde_path = "./sde"
+tables = ["inventory"]
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cmd = exec.Command(binary, "--config", configPath, "import", "--sde-dir", absTestDataDir, "--dry-run")
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("dry run with config table filter failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "invTypes.jsonl") {
+		t.Errorf("expected invTypes to be selected\nOutput: %s", output)
+	}
+	if strings.Contains(string(output), "mapRegions.jsonl") {
+		t.Errorf("expected mapRegions not to be parsed\nOutput: %s", output)
+	}
+
+	// Flags take precedence over the config file
+	cmd = exec.Command(binary, "--config", configPath, "import", "--sde-dir", absTestDataDir, "--dry-run", "--tables", "mapRegions")
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("dry run with --tables failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "mapRegions.jsonl") || strings.Contains(string(output), "invTypes.jsonl") {
+		t.Errorf("expected only mapRegions to be selected\nOutput: %s", output)
+	}
+
+	// Invalid glob patterns are rejected
+	cmd = exec.Command(binary, "import", "--sde-dir", absTestDataDir, "--dry-run", "--tables", "inv[")
+	if output, err := cmd.CombinedOutput(); err == nil {
+		t.Errorf("expected invalid table pattern to fail\nOutput: %s", output)
+	}
+}
+
+// TestE2E_ImportCommand_UnknownFiles tests reporting of unmatched and
+// fallback-matched files and --fail-on-unknown-files
+func TestE2E_ImportCommand_UnknownFiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	binary := buildTestBinary(t)
+	sdeDir := t.TempDir()
+
+	invTypes, err := os.ReadFile(filepath.Join("..", "..", "testdata", "sde", "invTypes.jsonl"))
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	files := map[string][]byte{
+		"invTypes.jsonl":        invTypes,
+		"invTypes_backup.jsonl": invTypes,
+		"newFeature.jsonl":      []byte("{\"_key\": 1}\n"),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(sdeDir, name), content, 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	cmd := exec.Command(binary, "import", "--sde-dir", sdeDir, "--dry-run")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("dry run failed: %v\nOutput: %s", err, output)
+	}
+	for _, want := range []string{"Unmatched files", "newFeature.jsonl", "Fallback matches", "invTypes_backup.jsonl", "suffix_stripped"} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("expected output to contain %q\nOutput: %s", want, output)
+		}
+	}
+
+	dbPath := filepath.Join(t.TempDir(), "unknown.db")
+	cmd = exec.Command(binary, "import", "--sde-dir", sdeDir, "--db", dbPath, "--fail-on-unknown-files")
+	output, err = cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected import to fail with --fail-on-unknown-files\nOutput: %s", output)
+	}
+	if !strings.Contains(string(output), "1 unmatched and 1 fallback-matched files") {
+		t.Errorf("expected unknown file error, got: %s", output)
+	}
+	if _, err := os.Stat(dbPath); !os.IsNotExist(err) {
+		t.Errorf("expected no database to be created, stat error: %v", err)
+	}
+}
+
+// TestE2E_ImportCommand_JSONProgress tests that --progress=json writes
+// newline-delimited progress events to stderr
+func TestE2E_ImportCommand_JSONProgress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	binary := buildTestBinary(t)
+	dbPath := filepath.Join(t.TempDir(), "progress.db")
+
+	absTestDataDir, err := filepath.Abs(filepath.Join("..", "..", "testdata", "sde"))
+	if err != nil {
+		t.Fatalf("failed to get absolute path: %v", err)
+	}
+
+	cmd := exec.Command(binary, "import", "--sde-dir", absTestDataDir, "--db", dbPath, "--skip-errors",
+		"--progress", "json", "--progress-interval", "0")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("import failed: %v\nStdout: %s\nStderr: %s", err, stdout.String(), stderr.String())
+	}
+
+	var events []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(stderr.String()), "\n") {
+		var event map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("stderr line is not JSON: %q (%v)", line, err)
+		}
+		events = append(events, event)
+	}
+	if len(events) < 3 {
+		t.Fatalf("expected at least 3 events, got %d", len(events))
+	}
+	if events[0]["event"] != "start" || events[len(events)-1]["event"] != "done" {
+		t.Errorf("expected start ... done, got %v ... %v", events[0]["event"], events[len(events)-1]["event"])
+	}
+
+	seen := make(map[string]bool)
+	for _, e := range events {
+		seen[e["event"].(string)] = true
+	}
+	for _, want := range []string{"scan", "phase", "progress"} {
+		if !seen[want] {
+			t.Errorf("expected a %q event, got %v", want, seen)
+		}
+	}
+	if rows, _ := events[len(events)-1]["rows"].(float64); rows <= 0 {
+		t.Errorf("expected rows in done event, got %v", events[len(events)-1])
+	}
+
+	// Invalid modes are rejected before the import starts
+	cmd = exec.Command(binary, "import", "--sde-dir", absTestDataDir, "--db", dbPath, "--progress", "fancy")
+	output, err := cmd.CombinedOutput()
+	if err == nil || !strings.Contains(string(output), "invalid progress mode") {
+		t.Errorf("expected invalid progress mode error, got %v\nOutput: %s", err, output)
+	}
+}
+
+// TestE2E_ImportCommand_ConfigPrecedence tests that import resolves settings
+// from the config file and environment, with explicit flags taking precedence
+func TestE2E_ImportCommand_ConfigPrecedence(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	binary := buildTestBinary(t)
+	tmpDir := t.TempDir()
+
+	absTestDataDir, err := filepath.Abs(filepath.Join("..", "..", "testdata", "sde"))
+	if err != nil {
+		t.Fatalf("failed to get absolute path: %v", err)
+	}
+
+	configPath := filepath.Join(tmpDir, "config.toml")
+	configContent := `[database]
+path = "` + filepath.ToSlash(filepath.Join(tmpDir, "file.db")) + `"
+
+[import]
+sde_path = "` + filepath.ToSlash(absTestDataDir) + `"
+tables = ["mapRegions"]
+
+[logging]
+level = "warn"
+format = "json"
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	// sde_path and tables come from the file; info logs are suppressed by logging.level
+	cmd := exec.Command(binary, "--config", configPath, "import", "--dry-run")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("dry run with config failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "mapRegions.jsonl") {
+		t.Errorf("expected sde_path and tables from config\nOutput: %s", output)
+	}
+	if strings.Contains(string(output), `"level":"info"`) {
+		t.Errorf("expected logging.level=warn to suppress info logs\nOutput: %s", output)
+	}
+
+	// ESDEDB_DATABASE_PATH overrides the file, --db overrides the environment
+	envDB := filepath.Join(tmpDir, "env.db")
+	flagDB := filepath.Join(tmpDir, "flag.db")
+	cmd = exec.Command(binary, "--config", configPath, "import", "--skip-errors")
+	cmd.Env = append(os.Environ(), "ESDEDB_DATABASE_PATH="+envDB)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("import failed: %v\nOutput: %s", err, output)
+	}
+	if _, err := os.Stat(envDB); err != nil {
+		t.Errorf("expected database from environment to be created: %v", err)
+	}
+
+	cmd = exec.Command(binary, "--config", configPath, "import", "--skip-errors", "--db", flagDB)
+	cmd.Env = append(os.Environ(), "ESDEDB_DATABASE_PATH="+envDB)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("import failed: %v\nOutput: %s", err, output)
+	}
+	if _, err := os.Stat(flagDB); err != nil {
+		t.Errorf("expected database from --db to be created: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "file.db")); !os.IsNotExist(err) {
+		t.Errorf("expected database path from file to be overridden, stat error: %v", err)
+	}
+}
+
+// TestE2E_ImportCommand_AllProfiles tests building one database per profile in a single run
+func TestE2E_ImportCommand_AllProfiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	binary := buildTestBinary(t)
+	tmpDir := t.TempDir()
+
+	absTestDataDir, err := filepath.Abs(filepath.Join("..", "..", "testdata", "sde"))
+	if err != nil {
+		t.Fatalf("failed to get absolute path: %v", err)
+	}
+
+	dbFile := func(name string) string { return filepath.Join(tmpDir, name+".db") }
+	configPath := filepath.Join(tmpDir, "config.toml")
+	configContent := `[database]
+path = "` + filepath.ToSlash(dbFile("full")) + `"
+
+[import]
+sde_path = "` + filepath.ToSlash(absTestDataDir) + `"
+
+[profiles.full]
+
+[profiles.universe.database]
+path = "` + filepath.ToSlash(dbFile("universe")) + `"
+
+[profiles.universe.import]
+tables = ["mapRegions"]
+
+[profiles.web.database]
+path = "` + filepath.ToSlash(dbFile("web")) + `"
+journal_mode = "DELETE"
+
+[profiles.web.import]
+tables = ["invTypes"]
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cmd := exec.Command(binary, "--config", configPath, "import", "--all-profiles", "--skip-errors", "--progress", "log")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("import --all-profiles failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "=== Profiles ===") {
+		t.Errorf("expected per-profile summary\nOutput: %s", output)
+	}
+
+	// Each profile database contains only its tables
+	counts := map[string]map[string]int{
+		"full":     {"mapRegions": 1, "invTypes": 1},
+		"universe": {"mapRegions": 1, "invTypes": 0},
+		"web":      {"mapRegions": 0, "invTypes": 1},
+	}
+	for profile, tables := range counts {
+		db, err := database.NewDB(dbFile(profile))
+		if err != nil {
+			t.Fatalf("failed to open %s database: %v", profile, err)
+		}
+		for table, want := range tables {
+			var count int
+			if err := db.Get(&count, "SELECT COUNT(*) FROM "+table); err != nil {
+				t.Errorf("%s: failed to count %s: %v", profile, table, err)
+			}
+			if (count > 0) != (want > 0) {
+				t.Errorf("%s: %s has %d rows, expected rows=%v", profile, table, count, want > 0)
+			}
+		}
+		_ = db.Close()
+	}
+
+	// --profile selects a single profile
+	cmd = exec.Command(binary, "--config", configPath, "--profile", "missing", "import")
+	if output, err := cmd.CombinedOutput(); err == nil || !strings.Contains(string(output), `unknown profile "missing"`) {
+		t.Errorf("expected unknown profile error, got %v\nOutput: %s", err, output)
+	}
+}
+
+// TestE2E_ImportCommand_TableSettings tests [tables.<name>] settings: a table
+// with malformed lines imported with error_mode = "skip" and a skipped table
+func TestE2E_ImportCommand_TableSettings(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	binary := buildTestBinary(t)
+	tmpDir := t.TempDir()
+	sdeDir := filepath.Join(tmpDir, "sde")
+	if err := os.MkdirAll(sdeDir, 0755); err != nil {
+		t.Fatalf("failed to create SDE dir: %v", err)
+	}
+	for _, name := range []string{"_sde.jsonl", "invTypes.jsonl", "mapRegions.jsonl"} {
+		data, err := os.ReadFile(filepath.Join("..", "..", "testdata", "sde", name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		if name == "invTypes.jsonl" {
+			data = append(data, "{broken\n"...)
+		}
+		if err := os.WriteFile(filepath.Join(sdeDir, name), data, 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	dbPath := filepath.Join(tmpDir, "test.db")
+	configPath := filepath.Join(tmpDir, "config.toml")
+	configContent := `[database]
+path = "` + filepath.ToSlash(dbPath) + `"
+
+[import]
+sde_path = "` + filepath.ToSlash(sdeDir) + `"
+
+[tables.invTypes]
+error_mode = "skip"
+max_errors = 5
+batch_size = 100
+on_conflict = "ignore"
+
+[tables.mapRegions]
+skip = true
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	// --skip-errors: _sde.jsonl has no table in the schema
+	cmd := exec.Command(binary, "--config", configPath, "import", "--progress", "log", "--skip-errors")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("import failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "1 malformed lines skipped") {
+		t.Errorf("expected skipped line in summary\nOutput: %s", output)
+	}
+	if !strings.Contains(string(output), "Skipped:   1 tables (mapRegions)") {
+		t.Errorf("expected mapRegions to be skipped\nOutput: %s", output)
+	}
+
+	db, err := database.NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	var types, regions int
+	_ = db.Get(&types, "SELECT COUNT(*) FROM invTypes")
+	_ = db.Get(&regions, "SELECT COUNT(*) FROM mapRegions")
+	if types == 0 || regions != 0 {
+		t.Errorf("expected invTypes rows and no mapRegions rows, got %d and %d", types, regions)
+	}
+
+	// Without error_mode = "skip" the malformed line fails the file
+	if err := os.WriteFile(configPath, []byte(strings.Replace(configContent, "error_mode = \"skip\"\nmax_errors = 5\n", "", 1)), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cmd = exec.Command(binary, "--config", configPath, "import", "--progress", "log", "--skip-errors", "--force")
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("import failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "line 4: failed to parse JSON") {
+		t.Errorf("expected invTypes.jsonl to fail without error_mode = \"skip\"\nOutput: %s", output)
+	}
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/cli"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/config"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/export"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/logger"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+	"github.com/jmoiron/sqlx"
+	"github.com/spf13/cobra"
+)
+
+func newExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the database to other formats",
+		Long: `Export command schreibt den Inhalt einer importierten SQLite-Datenbank
+in andere Formate.
+
+Verfügbare Formate:
+  csv      - Eine RFC-4180-CSV-Datei je Tabelle ([export.csv])
+  postgres - PostgreSQL-Dump mit Schema und COPY-Daten in einer .sql-Datei ([export.postgres])`,
+		Example: `  # Alle Tabellen als CSV exportieren
+  esdedb export csv --db ./eve-sde.db --out ./csv
+
+  # PostgreSQL-Dump erstellen und laden
+  esdedb export postgres --db ./eve-sde.db --out ./eve_sde.sql
+  psql -v ON_ERROR_STOP=1 -d eve -f ./eve_sde.sql`,
+	}
+
+	cmd.AddCommand(newExportCSVCmd())
+	cmd.AddCommand(newExportPostgresCmd())
+
+	return cmd
+}
+
+func newExportCSVCmd() *cobra.Command {
+	defaults := config.DefaultConfig()
+
+	cmd := &cobra.Command{
+		Use:   "csv",
+		Short: "Export tables as CSV files",
+		Long: `CSV command schreibt jede Tabelle der Datenbank als RFC-4180-CSV-Datei
+<tabelle>.csv (bzw. <tabelle>.csv.gz mit --gzip) in das Ausgabeverzeichnis.
+
+Format:
+  - Zeilenende CRLF; Felder mit Trennzeichen, Quotes oder Zeilenumbrüchen
+    werden in "..." gesetzt, Quotes verdoppelt
+  - Erste Zeile mit den Spaltennamen (abschaltbar mit --no-header)
+  - NULL als leeres Feld (bzw. --null, z.B. \N)
+  - Dezimalzahlen mit Punkt (bzw. Komma mit --decimal-comma)
+
+Die Zeilen werden aus der Datenbank gestreamt, auch große Tabellen werden
+also nicht in den Speicher geladen. Die Datenbank wird read-only geöffnet;
+bestehende Dateien im Ausgabeverzeichnis werden überschrieben.
+
+Tabellenauswahl wie beim Import: Glob-Patterns (z.B. "map*") oder Gruppen
+(inventory, dogma, universe, industry, character, cosmetics). Die Tabelle
+_import_runs wird nicht exportiert.
+
+Die Flags überschreiben die Einstellungen aus [export.csv] der
+Konfigurationsdatei (path, delimiter, header, null, gzip, decimal_comma,
+tables, exclude_tables).`,
+		Example: `  # Alle Tabellen exportieren
+  esdedb export csv --db ./eve-sde.db --out ./csv
+
+  # Für europäische Tabellenkalkulationen (Semikolon, Dezimalkomma)
+  esdedb export csv --delimiter ";" --decimal-comma
+
+  # Nur Universe-Tabellen, komprimiert, NULL als \N
+  esdedb export csv --tables universe --gzip --null '\N'`,
+		RunE: runExportCSVCmd,
+	}
+
+	// Flags
+	cmd.Flags().StringP("db", "d", defaults.Database.Path, "Pfad zur SQLite-Datenbank (überschreibt database.path)")
+	cmd.Flags().StringP("out", "o", defaults.Export.CSV.Path, "Ausgabeverzeichnis (überschreibt export.csv.path)")
+	cmd.Flags().String("delimiter", defaults.Export.CSV.Delimiter, "Feldtrenner, genau ein Zeichen (überschreibt export.csv.delimiter)")
+	cmd.Flags().Bool("no-header", false, "Keine Kopfzeile mit Spaltennamen schreiben (überschreibt export.csv.header)")
+	cmd.Flags().String("null", defaults.Export.CSV.Null, "Darstellung von NULL-Werten (überschreibt export.csv.null)")
+	cmd.Flags().Bool("gzip", false, "Dateien gzip-komprimiert schreiben (<tabelle>.csv.gz; überschreibt export.csv.gzip)")
+	cmd.Flags().Bool("decimal-comma", false, "Dezimalzahlen mit Komma schreiben (überschreibt export.csv.decimal_comma)")
+	cmd.Flags().StringSlice("tables", nil, "Exportiert nur diese Tabellen (Glob-Patterns oder Gruppen; überschreibt export.csv.tables)")
+	cmd.Flags().StringSlice("exclude-tables", nil, "Schließt diese Tabellen vom Export aus (Glob-Patterns oder Gruppen; überschreibt export.csv.exclude_tables)")
+
+	return cmd
+}
+
+func runExportCSVCmd(cmd *cobra.Command, args []string) error {
+	log := logger.GetGlobalLogger()
+
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+	csvCfg := cfg.Export.CSV
+
+	if _, err := os.Stat(cfg.Database.Path); os.IsNotExist(err) {
+		return fmt.Errorf("database file does not exist: %s", cfg.Database.Path)
+	}
+
+	// Open Database (read-only, übrige SQLite-Optionen aus [database])
+	dbOptions := databaseOptions(cfg.Database)
+	dbOptions.ReadOnly = true
+	db, err := database.NewDBWithOptions(cfg.Database.Path, dbOptions)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	tables, err := exportTables(ctx, db, csvCfg.Tables, csvCfg.ExcludeTables)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(csvCfg.Path, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	opts := export.CSVOptions{
+		Delimiter:    csvCfg.DelimiterRune(),
+		Header:       csvCfg.Header,
+		Null:         csvCfg.Null,
+		DecimalComma: csvCfg.DecimalComma,
+		Gzip:         csvCfg.Gzip,
+	}
+
+	log.Info("Exporting CSV",
+		logger.Field{Key: "db_path", Value: cfg.Database.Path},
+		logger.Field{Key: "out", Value: csvCfg.Path},
+		logger.Field{Key: "tables", Value: len(tables)},
+	)
+
+	fmt.Printf("\nExporting %d tables to %s\n\n", len(tables), csvCfg.Path)
+	fmt.Printf("%-30s %15s  %s\n", "Table Name", "Row Count", "File")
+	fmt.Printf("%-30s %15s  %s\n", "----------", "---------", "----")
+
+	start := time.Now()
+	var totalRows int64
+	for _, table := range tables {
+		path := filepath.Join(csvCfg.Path, export.CSVFileName(table, opts.Gzip))
+		rows, err := export.WriteCSVFile(ctx, db, table, path, opts)
+		if err != nil {
+			return fmt.Errorf("failed to export table %s: %w", table, err)
+		}
+		totalRows += rows
+		fmt.Printf("%-30s %15d  %s\n", table, rows, path)
+	}
+	duration := time.Since(start)
+
+	log.Info("CSV export completed",
+		logger.Field{Key: "tables", Value: len(tables)},
+		logger.Field{Key: "rows", Value: totalRows},
+		logger.Field{Key: "duration", Value: duration.String()},
+	)
+
+	fmt.Println()
+	cli.Success("Exported %d tables (%d rows) to %s in %v", len(tables), totalRows, csvCfg.Path, duration.Round(time.Millisecond))
+
+	return nil
+}
+
+func newExportPostgresCmd() *cobra.Command {
+	defaults := config.DefaultConfig()
+
+	cmd := &cobra.Command{
+		Use:   "postgres",
+		Short: "Export the database as PostgreSQL dump",
+		Long: `Postgres command schreibt die Datenbank als PostgreSQL-Dump in eine
+.sql-Datei, die psql in einer Transaktion lädt:
+
+  psql -v ON_ERROR_STOP=1 -d <datenbank> -f eve_sde.sql
+
+Inhalt:
+  - CREATE TABLE mit PostgreSQL-Typen (INTEGER → BIGINT, REAL → DOUBLE
+    PRECISION, BLOB → BYTEA), NOT NULL, Defaults und Primärschlüsseln
+  - Die Daten je Tabelle als COPY ... FROM stdin
+  - Danach Indizes, UNIQUE-Constraints und Fremdschlüssel zwischen den
+    exportierten Tabellen
+
+Bezeichner werden in "..." gesetzt, Spaltennamen wie typeID behalten also
+ihre Schreibweise. Partielle und Ausdrucks-Indizes sowie Fremdschlüssel auf
+nicht exportierte Tabellen werden übersprungen und im Dump als Kommentar
+vermerkt.
+
+Die Zeilen werden aus der Datenbank gestreamt; die Datenbank wird read-only
+geöffnet, eine bestehende Ausgabedatei wird überschrieben.
+
+Tabellenauswahl wie beim Import: Glob-Patterns (z.B. "map*") oder Gruppen
+(inventory, dogma, universe, industry, character, cosmetics). Die Tabelle
+_import_runs wird nicht exportiert.
+
+Die Flags überschreiben die Einstellungen aus [export.postgres] der
+Konfigurationsdatei (path, schema, drop_tables, tables, exclude_tables).`,
+		Example: `  # Alle Tabellen exportieren
+  esdedb export postgres --db ./eve-sde.db --out ./eve_sde.sql
+
+  # In ein eigenes Schema, bestehende Tabellen ersetzen
+  esdedb export postgres --schema sde --drop-tables
+
+  # Nur Inventory- und Dogma-Tabellen
+  esdedb export postgres --tables inventory,dogma`,
+		RunE: runExportPostgresCmd,
+	}
+
+	// Flags
+	cmd.Flags().StringP("db", "d", defaults.Database.Path, "Pfad zur SQLite-Datenbank (überschreibt database.path)")
+	cmd.Flags().StringP("out", "o", defaults.Export.Postgres.Path, "Ausgabedatei (überschreibt export.postgres.path)")
+	cmd.Flags().String("schema", defaults.Export.Postgres.Schema, "PostgreSQL-Schema für die Tabellen, leer = search_path (überschreibt export.postgres.schema)")
+	cmd.Flags().Bool("drop-tables", false, "Bestehende Tabellen vor dem Anlegen löschen (überschreibt export.postgres.drop_tables)")
+	cmd.Flags().StringSlice("tables", nil, "Exportiert nur diese Tabellen (Glob-Patterns oder Gruppen; überschreibt export.postgres.tables)")
+	cmd.Flags().StringSlice("exclude-tables", nil, "Schließt diese Tabellen vom Export aus (Glob-Patterns oder Gruppen; überschreibt export.postgres.exclude_tables)")
+
+	return cmd
+}
+
+func runExportPostgresCmd(cmd *cobra.Command, args []string) error {
+	log := logger.GetGlobalLogger()
+
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+	pgCfg := cfg.Export.Postgres
+	if pgCfg.Path == "" {
+		return fmt.Errorf("no output file (--out or export.postgres.path)")
+	}
+
+	if _, err := os.Stat(cfg.Database.Path); os.IsNotExist(err) {
+		return fmt.Errorf("database file does not exist: %s", cfg.Database.Path)
+	}
+
+	// Open Database (read-only, übrige SQLite-Optionen aus [database])
+	dbOptions := databaseOptions(cfg.Database)
+	dbOptions.ReadOnly = true
+	db, err := database.NewDBWithOptions(cfg.Database.Path, dbOptions)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	tables, err := exportTables(ctx, db, pgCfg.Tables, pgCfg.ExcludeTables)
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(pgCfg.Path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	log.Info("Exporting PostgreSQL dump",
+		logger.Field{Key: "db_path", Value: cfg.Database.Path},
+		logger.Field{Key: "out", Value: pgCfg.Path},
+		logger.Field{Key: "schema", Value: pgCfg.Schema},
+		logger.Field{Key: "tables", Value: len(tables)},
+	)
+
+	start := time.Now()
+	counts, err := export.WritePostgresFile(ctx, db, tables, pgCfg.Path, export.PostgresOptions{
+		Schema:     pgCfg.Schema,
+		DropTables: pgCfg.DropTables,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export PostgreSQL dump: %w", err)
+	}
+	duration := time.Since(start)
+
+	fmt.Printf("\nExported %d tables to %s\n\n", len(counts), pgCfg.Path)
+	fmt.Printf("%-30s %15s\n", "Table Name", "Row Count")
+	fmt.Printf("%-30s %15s\n", "----------", "---------")
+	var totalRows int64
+	for _, c := range counts {
+		totalRows += c.Rows
+		fmt.Printf("%-30s %15d\n", c.Table, c.Rows)
+	}
+
+	log.Info("PostgreSQL export completed",
+		logger.Field{Key: "tables", Value: len(counts)},
+		logger.Field{Key: "rows", Value: totalRows},
+		logger.Field{Key: "duration", Value: duration.String()},
+	)
+
+	fmt.Println()
+	cli.Success("Exported %d tables (%d rows) to %s in %v", len(counts), totalRows, pgCfg.Path, duration.Round(time.Millisecond))
+	fmt.Printf("Load with: psql -v ON_ERROR_STOP=1 -d <database> -f %s\n", pgCfg.Path)
+
+	return nil
+}
+
+// exportTables ermittelt die zu exportierenden Tabellen der Datenbank aus
+// den Include- und Exclude-Patterns. Patterns, die keine Tabelle der
+// Datenbank treffen, werden als Warnung gemeldet; wird keine Tabelle
+// ausgewählt, ist das ein Fehler.
+func exportTables(ctx context.Context, db *sqlx.DB, include, exclude []string) ([]string, error) {
+	filter, err := parser.NewTableFilter(include, exclude)
+	if err != nil {
+		return nil, fmt.Errorf("invalid table filter: %w", err)
+	}
+
+	all, err := export.Tables(ctx, db, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Je angegebenem Pattern warnen (nicht je Tabelle einer Gruppe, da ein
+	// Teil-Import nicht alle Tabellen einer Gruppe enthält)
+	for _, pattern := range append(append([]string(nil), include...), exclude...) {
+		single, err := parser.NewTableFilter([]string{pattern}, nil)
+		if err != nil || single.IsEmpty() {
+			continue
+		}
+		matched := false
+		for _, table := range all {
+			if single.Match(table) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			cli.Warning("Warning: table pattern %q matches no table in the database", pattern)
+		}
+	}
+
+	var tables []string
+	for _, table := range all {
+		if filter.Match(table) {
+			tables = append(tables, table)
+		}
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no tables to export")
+	}
+	return tables, nil
+}
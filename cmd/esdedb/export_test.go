@@ -0,0 +1,212 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+)
+
+// createExportTestDB erstellt eine Datenbank mit zwei kleinen Tabellen
+func createExportTestDB(t *testing.T) string {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	db, err := database.NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	_, err = db.Exec(`
+		CREATE TABLE invTypes (typeID INTEGER PRIMARY KEY, typeName TEXT, volume REAL);
+		INSERT INTO invTypes VALUES (34, 'Tritanium', 0.01), (35, 'Pyerite', NULL);
+		CREATE TABLE mapRegions (regionID INTEGER PRIMARY KEY, regionName TEXT);
+		INSERT INTO mapRegions VALUES (10000002, 'The Forge');`)
+	if err != nil {
+		t.Fatalf("failed to create test tables: %v", err)
+	}
+	return dbPath
+}
+
+// TestExportCSVCmd testet den Export aller Tabellen mit Defaults
+func TestExportCSVCmd(t *testing.T) {
+	configPath = filepath.Join(t.TempDir(), "missing.toml")
+	dbPath := createExportTestDB(t)
+	outDir := filepath.Join(t.TempDir(), "csv")
+
+	cmd := newExportCmd()
+	cmd.SetArgs([]string{"csv", "--db", dbPath, "--out", outDir})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("export csv failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outDir, "invTypes.csv"))
+	if err != nil {
+		t.Fatalf("invTypes.csv not written: %v", err)
+	}
+	if expected := "typeID,typeName,volume\r\n34,Tritanium,0.01\r\n35,Pyerite,\r\n"; string(data) != expected {
+		t.Errorf("unexpected invTypes.csv: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "mapRegions.csv")); err != nil {
+		t.Errorf("mapRegions.csv not written: %v", err)
+	}
+}
+
+// TestExportCSVCmd_Options testet Flags und [export.csv] aus der Konfigurationsdatei
+func TestExportCSVCmd_Options(t *testing.T) {
+	dbPath := createExportTestDB(t)
+	outDir := filepath.Join(t.TempDir(), "csv")
+
+	configPath = filepath.Join(t.TempDir(), "config.toml")
+	content := `[import]
+tables = ["mapRegions"]
+
+[export.csv]
+path = "` + filepath.ToSlash(outDir) + `"
+delimiter = ";"
+decimal_comma = true
+null = "NULL"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	// --tables wirkt auf export.csv.tables, nicht auf import.tables
+	cmd := newExportCmd()
+	cmd.SetArgs([]string{"csv", "--db", dbPath, "--tables", "inv*", "--no-header"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("export csv failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outDir, "invTypes.csv"))
+	if err != nil {
+		t.Fatalf("invTypes.csv not written: %v", err)
+	}
+	if expected := "34;Tritanium;0,01\r\n35;Pyerite;NULL\r\n"; string(data) != expected {
+		t.Errorf("unexpected invTypes.csv: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "mapRegions.csv")); !os.IsNotExist(err) {
+		t.Error("mapRegions.csv must not be exported")
+	}
+
+	// Gzip und explizit leere NULL-Darstellung
+	cmd = newExportCmd()
+	cmd.SetArgs([]string{"csv", "--db", dbPath, "--tables", "inv*", "--gzip", "--null", ""})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("export csv --gzip failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "invTypes.csv.gz")); err != nil {
+		t.Errorf("invTypes.csv.gz not written: %v", err)
+	}
+}
+
+// TestExportCSVCmd_Errors testet fehlende Datenbank, ungültige Optionen und leere Auswahl
+func TestExportCSVCmd_Errors(t *testing.T) {
+	configPath = filepath.Join(t.TempDir(), "missing.toml")
+	dbPath := createExportTestDB(t)
+	outDir := filepath.Join(t.TempDir(), "csv")
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"missing database", []string{"--db", filepath.Join(t.TempDir(), "missing.db")}, "does not exist"},
+		{"invalid delimiter", []string{"--db", dbPath, "--delimiter", "ab"}, "delimiter"},
+		{"empty delimiter", []string{"--db", dbPath, "--delimiter", ""}, "--delimiter"},
+		{"no tables", []string{"--db", dbPath, "--tables", "dogma"}, "no tables to export"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newExportCmd()
+			cmd.SetArgs(append([]string{"csv", "--out", outDir}, tt.args...))
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestExportPostgresCmd testet den PostgreSQL-Dump mit Flags und [export.postgres]
+func TestExportPostgresCmd(t *testing.T) {
+	dbPath := createExportTestDB(t)
+	outPath := filepath.Join(t.TempDir(), "dump", "eve_sde.sql")
+
+	configPath = filepath.Join(t.TempDir(), "config.toml")
+	content := `[export.csv]
+tables = ["mapRegions"]
+
+[export.postgres]
+path = "` + filepath.ToSlash(outPath) + `"
+schema = "sde"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	// --tables wirkt auf export.postgres.tables, nicht auf export.csv.tables
+	cmd := newExportCmd()
+	cmd.SetArgs([]string{"postgres", "--db", dbPath, "--tables", "inv*", "--drop-tables"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("export postgres failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("dump not written: %v", err)
+	}
+	dump := string(data)
+	for _, want := range []string{
+		`SET search_path TO "sde";`,
+		`DROP TABLE IF EXISTS "invTypes" CASCADE;`,
+		`"typeID" BIGINT,`,
+		`"volume" DOUBLE PRECISION,`,
+		`PRIMARY KEY ("typeID")`,
+		"COPY \"invTypes\" (\"typeID\", \"typeName\", \"volume\") FROM stdin;\n34\tTritanium\t0.01\n35\tPyerite\t\\N\n\\.\n",
+		"COMMIT;",
+	} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("dump does not contain %q:\n%s", want, dump)
+		}
+	}
+	if strings.Contains(dump, "mapRegions") {
+		t.Error("mapRegions must not be exported")
+	}
+}
+
+// TestExportPostgresCmd_Errors testet fehlende Datenbank, ungültige Patterns und leere Auswahl
+func TestExportPostgresCmd_Errors(t *testing.T) {
+	configPath = filepath.Join(t.TempDir(), "missing.toml")
+	dbPath := createExportTestDB(t)
+	outPath := filepath.Join(t.TempDir(), "eve_sde.sql")
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"missing database", []string{"--db", filepath.Join(t.TempDir(), "missing.db")}, "does not exist"},
+		{"invalid pattern", []string{"--db", dbPath, "--tables", "map["}, "export.postgres"},
+		{"no tables", []string{"--db", dbPath, "--tables", "dogma"}, "no tables to export"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newExportCmd()
+			cmd.SetArgs(append([]string{"postgres", "--out", outPath}, tt.args...))
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+	if _, err := os.Stat(outPath); !os.IsNotExist(err) {
+		t.Error("no dump must be written on errors")
+	}
+}
@@ -2,27 +2,61 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
-	"runtime"
+	"path/filepath"
+	"sort"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/cli"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/config"
 	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
 	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/logger"
 	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
 	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/worker"
+	"github.com/jmoiron/sqlx"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/spf13/cobra"
 )
 
 var (
-	sdeDir      string
-	dbPath      string
-	workerCount int
-	skipErrors  bool
+	sdeDir       string
+	dbPath       string
+	workerCount  int
+	skipErrors   bool
+	deferIndexes bool
+	forceImport  bool
+	chunkSizeMiB int
+	jobTimeout   time.Duration
+	dryRun       bool
+	parseOnly    bool
+
+	// Tabellenauswahl (Glob-Patterns oder Gruppen, siehe parser.TableGroups)
+	includeTables []string
+	excludeTables []string
+
+	// Bricht ab, wenn Dateien ohne Parser oder per Fallback zugeordnet werden
+	failOnUnknownFiles bool
+
+	// Importiert alle Profile der Konfigurationsdatei in einem Lauf
+	allProfiles bool
+
+	// Fortschrittsausgabe (auto, bar, log, json) und Intervall der JSON-Ereignisse
+	progressMode     string
+	progressInterval time.Duration
+
+	// Finalize-Phase (nach dem Import)
+	finalize           bool
+	finalizeAnalyze    bool
+	finalizeOptimize   bool
+	finalizeCheckpoint bool
+	vacuum             bool
+	vacuumInto         string
+	integrityCheck     bool
 )
 
 func newImportCmd() *cobra.Command {
@@ -33,19 +67,88 @@ func newImportCmd() *cobra.Command {
 
 Phase 1: Paralleles Parsing (Worker Pool)
   - Mehrere Worker-Threads parsen JSONL-Dateien gleichzeitig
-  - Anzahl der Worker konfigurierbar (Standard: 4, Auto: -1 für NumCPU)
+  - Anzahl der Worker konfigurierbar (Standard: Auto = NumCPU, max. 32)
 
 Phase 2: Sequenzielles Database-Insert (SQLite Single-Writer)
   - Geparste Daten werden in SQLite-Datenbank eingefügt
   - SQLite unterstützt nur einen Writer zur gleichen Zeit
 
-Der Import zeigt einen Fortschrittsbalken mit Live-Metriken:
-  - Anzahl verarbeiteter/fehlgeschlagener Dateien
-  - Eingefügte Rows und Durchsatz (Rows/Sekunde)
-  - Geschätzte verbleibende Zeit`,
-		Example: `  # Import mit Standard-Einstellungen (4 Workers)
+Große Dateien (ab --chunk-size MiB) werden an Zeilengrenzen in Chunks geteilt,
+die von mehreren Workern parallel geparst und danach in Datei-Reihenfolge
+wieder zusammengesetzt werden.
+
+Mit --defer-indexes werden zunächst nur die Tabellen angelegt und die
+Sekundär-Indizes erst nach dem vollständigen Laden der Daten erstellt.
+Bestehende Sekundär-Indizes einer vorhandenen Datenbank werden dazu vor dem
+Import entfernt. Die Index-Build-Zeit wird separat in der Zusammenfassung
+ausgewiesen.
+
+Downgrade-Schutz: Die SDE-Build-Nummer aus _sde.jsonl wird nach einem
+fehlerfreien, vollständigen Import in PRAGMA user_version gespeichert (nicht,
+wenn Dateien mit --skip-errors übersprungen oder Tabellen per --tables,
+--exclude-tables, tables.<name>.skip bzw. Profil ausgelassen wurden). Enthält
+die Ziel-Datenbank bereits einen neueren Build (der letzte Lauf in
+_import_runs, ohne solchen Lauf user_version) oder ist _sde.jsonl unlesbar,
+bricht der Import ab (überschreibbar mit --force).
+
+Finalize-Phase (nach dem Import; jeder Schritt per Flag oder in
+[import.finalize] der Konfigurationsdatei):
+  - ANALYZE, Statistiken für den Query Planner (--analyze, analyze)
+  - PRAGMA optimize (--optimize, optimize)
+  - WAL Checkpoint mit Truncate (--checkpoint, checkpoint), entfernt die große
+    -wal Datei nach dem Import
+  - ANALYZE, PRAGMA optimize und WAL Checkpoint sind standardmäßig an;
+    --finalize=false schaltet alle drei ab, z.B. --analyze=false einen einzelnen
+  - VACUUM (--vacuum, vacuum) oder VACUUM INTO (--vacuum-into, vacuum_into),
+    standardmäßig aus
+  - PRAGMA integrity_check (--integrity-check, integrity_check), standardmäßig aus
+
+Ohne Datenbankzugriff (SQLite wird nicht geöffnet):
+  - --dry-run: Discovery, Parser-Zuordnung, vollständiges Parsen und Validierung;
+    meldet pro Datei geplante Tabelle, Spalten, Zeilenzahl und übersprungene
+    Zeilen sowie Dateien ohne passenden Parser
+  - --parse-only: Nur die Parse-Phase des Imports (Durchsatz-Messung)
+
+Tabellenauswahl (--tables / --exclude-tables bzw. import.tables und
+import.exclude_tables in der Konfigurationsdatei):
+  - Glob-Patterns auf Tabellennamen, z.B. "map*" oder "inv[TG]*"
+  - Gruppen: inventory, dogma, universe, industry, character, cosmetics
+  - Übersprungene Tabellen werden in der Zusammenfassung aufgelistet
+
+Datei-Zuordnung: JSONL-Dateien ohne passenden Parser und Dateien, die nur über
+den Suffix-Fallback (invTypes_1 → invTypes) zugeordnet wurden, werden mit der
+verwendeten Regel geloggt und in der Zusammenfassung aufgelistet. Mit
+--fail-on-unknown-files bricht der Import in diesem Fall vor dem Öffnen der
+Datenbank ab (z.B. in CI, um neue SDE-Dateien zu bemerken).
+
+Einstellungen werden in dieser Reihenfolge aufgelöst (spätere gewinnen):
+Defaults < Konfigurationsdatei (--config) < Profil (--profile) <
+Environment Variables (ESDEDB_*) < explizit gesetzte Flags. --sde-dir, --db, --workers, --tables und
+--exclude-tables überschreiben import.sde_path, database.path,
+import.workers, import.tables und import.exclude_tables.
+
+Profile ([profiles.<name>] in der Konfigurationsdatei, z.B. "full", "web",
+"universe") überschreiben einzelne Schlüssel der Basis-Konfiguration wie
+database.path, import.tables oder SQLite-Pragmas. --profile wählt ein Profil;
+--all-profiles importiert alle Profile in einem Lauf: Das SDE wird einmal
+geparst (Vereinigung der Tabellen aller Profile) und jede Datei in alle
+Profil-Datenbanken eingefügt, deren Tabellenauswahl sie enthält. Alle Profile
+müssen dasselbe import.sde_path und verschiedene database.path verwenden.
+
+Fortschrittsausgabe (--progress):
+  - auto: Live-Anzeige auf einem Terminal (eine Zeile pro Worker, Insert-Zeile,
+    Gesamt-Balken mit Rows, Bytes und ETA), sonst wie "log"
+  - bar:  Live-Anzeige auch ohne erkanntes Terminal
+  - log:  Eine Textzeile pro Ereignis ohne Steuerzeichen (CI, systemd)
+  - json: NDJSON-Ereignisse auf stderr (start, scan, phase, progress,
+    file_failed, done) mit Phase, Datei, Rows, Rows/Sekunde, ETA und Fehlern;
+    "progress" wird alle --progress-interval geschrieben`,
+		Example: `  # Import mit Standard-Einstellungen bzw. Werten aus ./config.toml
   esdedb import
 
+  # Einstellungen aus einer anderen Konfigurationsdatei, Worker per Flag überschreiben
+  esdedb --config ./configs/production.toml import --workers 8
+
   # Import mit benutzerdefiniertem SDE-Verzeichnis und Datenbank-Pfad
   esdedb import --sde-dir ./sde-JSONL --db ./eve-sde.db --workers 4
 
@@ -55,37 +158,153 @@ Der Import zeigt einen Fortschrittsbalken mit Live-Metriken:
   # Fehlerhafte Dateien überspringen und Import fortsetzen
   esdedb import --sde-dir ./sde-JSONL --db ./eve-sde.db --skip-errors
 
+  # Indizes erst nach dem Laden aller Daten erstellen (schnellerer Bulk-Import)
+  esdedb import --sde-dir ./sde-JSONL --db ./eve-sde.db --defer-indexes
+
+  # Älteren SDE-Build über eine Datenbank mit neuerem Build importieren
+  esdedb import --sde-dir ./sde-JSONL --db ./eve-sde.db --force
+
+  # Datenbank nach dem Import kompaktieren
+  esdedb import --sde-dir ./sde-JSONL --db ./eve-sde.db --vacuum
+
+  # Kompaktierte Kopie für die Auslieferung schreiben
+  esdedb import --sde-dir ./sde-JSONL --db ./eve-sde.db --vacuum-into ./release/eve-sde.db
+
+  # Neuen SDE-Export validieren, ohne eine Datenbank anzulegen
+  esdedb import --sde-dir ./sde-JSONL --dry-run
+
+  # Parse-Durchsatz isoliert messen
+  esdedb import --sde-dir ./sde-JSONL --parse-only --workers -1
+
+  # Nur Universum- und Inventar-Tabellen importieren
+  esdedb import --sde-dir ./sde-JSONL --tables universe,inventory
+
+  # Alle Tabellen außer Kosmetik und Monden importieren
+  esdedb import --sde-dir ./sde-JSONL --exclude-tables cosmetics,mapMoons
+
+  # In CI abbrechen, wenn das SDE unbekannte Dateien enthält
+  esdedb import --sde-dir ./sde-JSONL --dry-run --fail-on-unknown-files
+
+  # Profil "web" aus der Konfigurationsdatei importieren
+  esdedb --profile web import
+
+  # Alle Profile (z.B. full, web, universe) mit einem Parse-Durchlauf bauen
+  esdedb import --all-profiles
+
+  # Fortschritt als JSON-Ereignisse für Wrapper-Skripte (alle 5 Sekunden)
+  esdedb import --sde-dir ./sde-JSONL --progress json --progress-interval 5s 2> progress.ndjson
+
   # Import mit Verbose Logging (Debug-Level)
   esdedb --verbose import --sde-dir ./sde-JSONL`,
 		RunE: runImportCmd,
 	}
 
 	// Flags
-	cmd.Flags().StringVarP(&sdeDir, "sde-dir", "s", "./sde-JSONL", "Pfad zum Verzeichnis mit SDE JSONL-Dateien")
-	cmd.Flags().StringVarP(&dbPath, "db", "d", "./eve-sde.db", "Pfad zur SQLite-Datenbank (wird erstellt falls nicht vorhanden)")
-	cmd.Flags().IntVarP(&workerCount, "workers", "w", 4, "Anzahl paralleler Worker-Threads (-1 = Automatisch basierend auf CPU-Kernen)")
+	defaults := config.DefaultConfig()
+	cmd.Flags().StringVarP(&sdeDir, "sde-dir", "s", defaults.Import.SDEPath, "Pfad zum Verzeichnis mit SDE JSONL-Dateien (überschreibt import.sde_path)")
+	cmd.Flags().StringVarP(&dbPath, "db", "d", defaults.Database.Path, "Pfad zur SQLite-Datenbank, wird erstellt falls nicht vorhanden (überschreibt database.path)")
+	cmd.Flags().IntVarP(&workerCount, "workers", "w", defaults.Import.Workers, "Anzahl paralleler Worker-Threads, 1-32 (0 oder -1 = Automatisch basierend auf CPU-Kernen; überschreibt import.workers)")
 	cmd.Flags().BoolVar(&skipErrors, "skip-errors", false, "Überspringt fehlerhafte Dateien statt Import abzubrechen")
+	cmd.Flags().IntVar(&chunkSizeMiB, "chunk-size", int(worker.DefaultChunkSize>>20), "Chunk-Größe in MiB für paralleles Parsen großer Dateien (0 = deaktiviert)")
+	cmd.Flags().DurationVar(&jobTimeout, "job-timeout", 0, "Maximale Parse-Dauer pro Datei/Chunk, z.B. 10m (0 = unbegrenzt)")
+	cmd.Flags().BoolVar(&deferIndexes, "defer-indexes", false, "Erstellt Sekundär-Indizes erst nach dem Laden aller Daten")
+	cmd.Flags().BoolVar(&forceImport, "force", false, "Erlaubt den Import eines älteren SDE-Builds in eine Datenbank mit neuerem Build")
+	cmd.Flags().BoolVar(&finalize, "finalize", defaults.Import.Finalize.Analyze && defaults.Import.Finalize.Optimize && defaults.Import.Finalize.Checkpoint, "Führt nach dem Import ANALYZE, PRAGMA optimize und WAL-Checkpoint aus, =false schaltet alle drei ab (überschreibt import.finalize.analyze, optimize und checkpoint)")
+	cmd.Flags().BoolVar(&finalizeAnalyze, "analyze", defaults.Import.Finalize.Analyze, "Führt nach dem Import ANALYZE aus (überschreibt import.finalize.analyze)")
+	cmd.Flags().BoolVar(&finalizeOptimize, "optimize", defaults.Import.Finalize.Optimize, "Führt nach dem Import PRAGMA optimize aus (überschreibt import.finalize.optimize)")
+	cmd.Flags().BoolVar(&finalizeCheckpoint, "checkpoint", defaults.Import.Finalize.Checkpoint, "Führt nach dem Import einen WAL-Checkpoint mit Truncate aus (überschreibt import.finalize.checkpoint)")
+	cmd.Flags().BoolVar(&vacuum, "vacuum", false, "Führt nach dem Import VACUUM aus, gibt freie Seiten frei (überschreibt import.finalize.vacuum)")
+	cmd.Flags().StringVar(&vacuumInto, "vacuum-into", "", "Schreibt nach dem Import eine kompaktierte Kopie in diese Datei (überschreibt import.finalize.vacuum_into)")
+	cmd.Flags().BoolVar(&integrityCheck, "integrity-check", false, "Führt nach dem Import PRAGMA integrity_check aus, Import schlägt fehl, wenn nicht ok (überschreibt import.finalize.integrity_check)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Parst und validiert alle Dateien und gibt einen Bericht aus, ohne die Datenbank zu öffnen")
+	cmd.Flags().BoolVar(&parseOnly, "parse-only", false, "Führt nur die Parse-Phase aus (Durchsatz-Messung, ohne Datenbank)")
+	cmd.MarkFlagsMutuallyExclusive("dry-run", "parse-only")
+	cmd.Flags().StringSliceVar(&includeTables, "tables", nil, "Importiert nur diese Tabellen (Glob-Patterns oder Gruppen: inventory, dogma, universe, industry, character, cosmetics; überschreibt import.tables)")
+	cmd.Flags().StringSliceVar(&excludeTables, "exclude-tables", nil, "Schließt diese Tabellen vom Import aus (Glob-Patterns oder Gruppen; überschreibt import.exclude_tables)")
+	cmd.Flags().BoolVar(&failOnUnknownFiles, "fail-on-unknown-files", false, "Bricht ab, wenn JSONL-Dateien ohne Parser oder nur per Fallback-Regel zugeordnet werden (für CI)")
+	cmd.Flags().BoolVar(&allProfiles, "all-profiles", false, "Importiert jedes Profil aus [profiles.<name>] in seine Datenbank; das SDE wird nur einmal geparst")
+	cmd.MarkFlagsMutuallyExclusive("all-profiles", "dry-run")
+	cmd.MarkFlagsMutuallyExclusive("all-profiles", "parse-only")
+	cmd.MarkFlagsMutuallyExclusive("all-profiles", "vacuum-into")
+	cmd.Flags().StringVar(&progressMode, "progress", string(cli.ProgressModeAuto), "Fortschrittsausgabe: auto, bar, log oder json (NDJSON auf stderr)")
+	cmd.Flags().DurationVar(&progressInterval, "progress-interval", 2*time.Second, "Abstand der JSON-Fortschrittsereignisse bei --progress=json (0 = pro Datei)")
 
 	return cmd
 }
 
+// importTarget ist eine Ziel-Datenbank des Imports. Ohne --all-profiles gibt
+// es genau ein Ziel, sonst eines pro Profil.
+type importTarget struct {
+	profile string // Profilname (leer = Basis-Konfiguration)
+	cfg     *config.Config
+	filter  *parser.TableFilter
+	// settings sind die Einstellungen aus [tables.<name>]
+	settings map[string]worker.TableSettings
+	db       *sqlx.DB
+
+	deferredIndexes []string
+	indexDuration   time.Duration
+	run             *database.ImportRun
+	finalizeSteps   []database.FinalizeStep
+}
+
+// logFields ergänzt fields um das Profil, sofern gesetzt.
+func (t *importTarget) logFields(fields ...logger.Field) []logger.Field {
+	if t.profile == "" {
+		return fields
+	}
+	return append([]logger.Field{{Key: "profile", Value: t.profile}}, fields...)
+}
+
+// omittedTables liefert die Parser-Tabellen, die der Tabellenfilter oder
+// tables.<name>.skip des Ziels vom Import ausschließen.
+func (t *importTarget) omittedTables() []string {
+	var omitted []string
+	for _, table := range registeredTables() {
+		if !t.filter.Match(table) || t.settings[table].Skip {
+			omitted = append(omitted, table)
+		}
+	}
+	return omitted
+}
+
+// errorf formatiert einen Fehler mit dem Profil als Präfix, sofern gesetzt.
+func (t *importTarget) errorf(format string, args ...interface{}) error {
+	err := fmt.Errorf(format, args...)
+	if t.profile == "" {
+		return err
+	}
+	return fmt.Errorf("profile %s: %w", t.profile, err)
+}
+
 func runImportCmd(cmd *cobra.Command, args []string) error {
 	log := logger.GetGlobalLogger()
 
-	// Validate inputs
-	if sdeDir == "" {
-		return fmt.Errorf("--sde-dir darf nicht leer sein")
+	if allProfiles && profileName != "" {
+		return fmt.Errorf("--profile und --all-profiles können nicht kombiniert werden")
 	}
-	if dbPath == "" {
-		return fmt.Errorf("--db darf nicht leer sein")
+
+	// Effektive Einstellungen: Flags > Environment > Profil > Konfigurationsdatei
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
 	}
+	sdeDir = cfg.Import.SDEPath
+	dbPath = cfg.Database.Path
+	workerCount = cfg.Import.Workers // 0 (Auto) wird von Validate() aufgelöst
 
-	// Auto-detect worker count
-	if workerCount == -1 {
-		workerCount = runtime.NumCPU()
+	mode, err := cli.ParseProgressMode(progressMode)
+	if err != nil {
+		return fmt.Errorf("--progress: %w", err)
 	}
-	if workerCount <= 0 {
-		workerCount = 1
+	if progressInterval < 0 {
+		return fmt.Errorf("--progress-interval darf nicht negativ sein")
+	}
+
+	// Ziel-Datenbanken (bei --all-profiles eine pro Profil)
+	targets, err := importTargets(cmd, cfg)
+	if err != nil {
+		return err
 	}
 
 	log.Info("Starting EVE SDE Import",
@@ -93,6 +312,10 @@ func runImportCmd(cmd *cobra.Command, args []string) error {
 		logger.Field{Key: "db_path", Value: dbPath},
 		logger.Field{Key: "workers", Value: workerCount},
 		logger.Field{Key: "skip_errors", Value: skipErrors},
+		logger.Field{Key: "defer_indexes", Value: deferIndexes},
+		logger.Field{Key: "chunk_size_mib", Value: chunkSizeMiB},
+		logger.Field{Key: "progress", Value: string(mode)},
+		logger.Field{Key: "targets", Value: len(targets)},
 	)
 
 	// Context mit Cancellation für Graceful Shutdown
@@ -110,19 +333,51 @@ func runImportCmd(cmd *cobra.Command, args []string) error {
 		cancel()
 	}()
 
-	// Open Database
-	db, err := database.NewDB(dbPath)
+	// Tabellenfilter und [tables.<name>] aus Flags bzw. Konfiguration (je Profil)
+	for _, t := range targets {
+		if t.filter, err = importTableFilter(t.cfg); err != nil {
+			return t.errorf("%w", err)
+		}
+		if t.settings, err = importTableSettings(t.cfg); err != nil {
+			return t.errorf("%w", err)
+		}
+	}
+
+	// Tabellenauswahl und -Einstellungen für Discovery und Orchestrator
+	selection, err := importSelection(targets)
 	if err != nil {
-		return fmt.Errorf("failed to open database: %w", err)
+		return err
 	}
-	defer func() { _ = db.Close() }()
 
-	// Run Migrations (Schema Creation)
-	log.Info("Applying database migrations...")
-	if err := database.ApplyMigrationsFromCLI(dbPath); err != nil {
-		return fmt.Errorf("failed to apply migrations: %w", err)
+	// Datei-Zuordnung prüfen (unbekannte Dateien, Fallback-Zuordnungen)
+	plan, err := discoverImportPlan(selection...)
+	if err != nil {
+		return err
+	}
+
+	// Dry-Run / Parse-Only: keine Datenbank öffnen
+	if dryRun || parseOnly {
+		return runDryRun(ctx, parseOnly, selection...)
+	}
+
+	// SDE-Build für den Downgrade-Schutz
+	sdeBuild, err := sdeBuildNumber(sdeDir, forceImport)
+	if err != nil {
+		return err
+	}
+
+	// Datenbanken öffnen, Downgrade-Schutz prüfen und Migrationen anwenden
+	for _, t := range targets {
+		if err := openImportTarget(ctx, t, sdeBuild); err != nil {
+			return err
+		}
+		defer func(t *importTarget) { _ = t.db.Close() }(t)
+	}
+	if len(targets) > 1 {
+		if selection, err = importSelection(targets); err != nil {
+			return err
+		}
 	}
-	log.Info("Database migrations applied successfully")
 
 	// Create Worker Pool
 	pool := worker.NewPool(workerCount)
@@ -134,8 +389,12 @@ func runImportCmd(cmd *cobra.Command, args []string) error {
 		logger.Field{Key: "parser_count", Value: len(parsers)},
 	)
 
-	// Create Orchestrator
-	orch := worker.NewOrchestrator(db, pool, parsers)
+	// Zeilenzahlen des letzten Imports als Hints für die Job-Priorisierung
+	// (ohne Vorlauf wird nach Dateigröße priorisiert)
+	var rowHints map[string]int64
+	if runs, err := database.ListImportRuns(ctx, targets[0].db); err == nil && len(runs) > 0 {
+		rowHints = runs[0].RowCounts
+	}
 
 	// Discover files first to set up progress bar
 	files, err := worker.DiscoverJSONLFiles(sdeDir)
@@ -147,76 +406,159 @@ func runImportCmd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no JSONL files found in %s", sdeDir)
 	}
 
-	// Create enhanced progress bar with live metrics
-	progressBar := cli.NewProgressBar(cli.ProgressBarConfig{
-		Total:       len(files),
-		Description: "Importing files",
-		Width:       40,
-		UpdateRate:  100 * time.Millisecond,
-		ShowSpinner: false, // Spinner für einzelne Dateien kann optional aktiviert werden
-	})
-
-	// Start Import in background
-	startTime := time.Now()
-	done := make(chan struct{})
-	var progress *worker.ProgressTracker
-	var importErr error
-
-	// Run import in goroutine
-	go func() {
-		progress, importErr = orch.ImportAll(ctx, sdeDir)
-		close(done)
-	}()
+	// Provenienz-Daten für _import_runs (SDE-Version, Tool-Version; die
+	// Checksummen liefert der Vorab-Scan)
+	importRun := newImportRun(cmd, sdeDir, sdeBuild)
+	checksums := checksumRecorder{}
+	for _, t := range targets {
+		run := *importRun
+		if len(targets) > 1 {
+			run.Flags = make(map[string]string, len(importRun.Flags)+1)
+			for k, v := range importRun.Flags {
+				run.Flags[k] = v
+			}
+			run.Flags["profile"] = t.profile
+		}
+		t.run = &run
+		checksums.runs = append(checksums.runs, t.run)
+	}
 
-	// Create context for progress bar
-	progressCtx, progressCancel := context.WithCancel(ctx)
-	defer progressCancel()
+	// Fortschrittsausgabe gemäß --progress (Live-Anzeige, Textzeilen oder JSON)
+	display, err := cli.NewImportDisplay(cli.ImportDisplayConfig{
+		Mode:        mode,
+		Workers:     workerCount,
+		Interval:    progressInterval,
+		RefreshRate: 100 * time.Millisecond,
+	})
+	if err != nil {
+		return err
+	}
 
-	// Start progress bar updates in goroutine
-	go func() {
-		<-done
-		progressCancel() // Signal progress bar to stop
-	}()
+	// Create Orchestrator (Live-Anzeige folgt den Import-Events)
+	opts := []worker.OrchestratorOption{
+		worker.WithChunkSize(int64(chunkSizeMiB) << 20),
+		worker.WithRowCountHints(rowHints),
+		worker.WithJobTimeout(jobTimeout),
+		worker.WithObserver(display),
+		worker.WithChecksums(),
+		worker.WithObserver(checksums),
+	}
+	orch := worker.NewOrchestrator(targets[0].db, pool, parsers, append(opts, selection...)...)
 
-	// Start progress bar (blocks until context is cancelled)
-	progressBar.Start(progressCtx, progress)
+	// Run import
+	startTime := time.Now()
+	progress, importErr := orch.ImportAll(ctx, sdeDir)
 
 	// Import finished
-	progressBar.Finish()
-	duration := time.Since(startTime)
+	display.Finish()
 
 	if importErr != nil {
-		if importErr == context.Canceled {
+		if errors.Is(importErr, context.Canceled) {
 			log.Warn("Import cancelled by user")
 			return nil
 		}
 		return fmt.Errorf("import failed: %w", importErr)
 	}
 
-	// Report Results
+	// Indizes, Import-Run, user_version und Finalize-Phase je Ziel
 	progressDetailed := progress.GetProgressDetailed()
+	failed := progressDetailed.FailedFiles
+	var finalizeErr error
+	for _, t := range targets {
+		err := finishImportTarget(ctx, t, startTime, sdeBuild, failed)
+		var finErr *finalizeError
+		if errors.As(err, &finErr) {
+			if finalizeErr == nil {
+				finalizeErr = err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	duration := time.Since(startTime)
+
+	// Report Results
 	parsed := progressDetailed.ParsedFiles
 	inserted := progressDetailed.InsertedFiles
-	failed := progressDetailed.FailedFiles
 	total := progressDetailed.TotalFiles
 
+	poolStats := pool.Stats()
+	for _, w := range poolStats.Workers {
+		log.Debug("Worker utilization",
+			logger.Field{Key: "worker", Value: w.ID},
+			logger.Field{Key: "jobs", Value: w.Jobs},
+			logger.Field{Key: "busy", Value: w.Busy},
+			logger.Field{Key: "utilization", Value: w.Utilization},
+		)
+	}
+
+	var indexCount int
+	var indexDuration time.Duration
+	for _, t := range targets {
+		indexCount += len(t.deferredIndexes)
+		indexDuration += t.indexDuration
+	}
+
 	log.Info("Import completed",
 		logger.Field{Key: "total_files", Value: total},
 		logger.Field{Key: "parsed_files", Value: int(parsed)},
 		logger.Field{Key: "inserted_files", Value: int(inserted)},
 		logger.Field{Key: "failed_files", Value: int(failed)},
 		logger.Field{Key: "inserted_rows", Value: progressDetailed.InsertedRows},
+		logger.Field{Key: "skipped_lines", Value: progressDetailed.SkippedLines},
+		logger.Field{Key: "index_duration", Value: indexDuration},
 		logger.Field{Key: "duration", Value: duration},
 		logger.Field{Key: "rows_per_second", Value: progressDetailed.RowsPerSecond},
+		logger.Field{Key: "worker_utilization", Value: poolStats.Utilization},
 	)
 
 	fmt.Printf("\n=== Import Summary ===\n")
 	fmt.Printf("Files:     %d/%d parsed (%d failed)\n", parsed, total, failed)
 	fmt.Printf("Rows:      %d inserted\n", progressDetailed.InsertedRows)
+	if progressDetailed.SkippedLines > 0 {
+		fmt.Printf("Lines:     %d malformed lines skipped (error_mode = \"skip\")\n", progressDetailed.SkippedLines)
+	}
+	if indexCount > 0 {
+		fmt.Printf("Indexes:   %d created in %v\n", indexCount, indexDuration)
+	}
 	fmt.Printf("Duration:  %v\n", duration)
 	fmt.Printf("Throughput: %.0f rows/sec\n", progressDetailed.RowsPerSecond)
+	displaySkippedTables(plan.SkippedTables())
+	displayUnmatchedFiles(plan.Unmatched)
+	displayFallbackMatches(plan.Fallbacks)
 	fmt.Printf("\n")
 
+	if len(targets) > 1 {
+		displayProfileStats(targets, orch.TargetStats())
+	}
+
+	displayWorkerStats(poolStats)
+
+	for _, t := range targets {
+		if len(t.finalizeSteps) == 0 {
+			continue
+		}
+		if t.profile != "" && len(targets) > 1 {
+			fmt.Printf("=== Finalize (%s) ===\n", t.profile)
+		} else {
+			fmt.Printf("=== Finalize ===\n")
+		}
+		for _, step := range t.finalizeSteps {
+			result := step.Result
+			if step.Err != nil {
+				result = fmt.Sprintf("FAILED: %v", step.Err)
+			}
+			fmt.Printf("%-16s %12v  %s\n", step.Name, step.Duration.Round(time.Microsecond), result)
+		}
+		fmt.Printf("\n")
+	}
+
+	if finalizeErr != nil {
+		return finalizeErr
+	}
+
 	if failed > 0 {
 		log.Warn("Some files failed to import",
 			logger.Field{Key: "failed_count", Value: int(failed)},
@@ -229,3 +571,378 @@ func runImportCmd(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// importTargets liefert die Ziel-Datenbanken des Imports.
+//
+// Ohne --all-profiles ist das die effektive Konfiguration cfg. Mit
+// --all-profiles wird jedes Profil der Konfigurationsdatei wie mit --profile
+// geladen; da das SDE nur einmal geparst wird, müssen alle Profile dasselbe
+// import.sde_path verwenden und unterschiedliche database.path (und
+// import.finalize.vacuum_into) haben.
+func importTargets(cmd *cobra.Command, cfg *config.Config) ([]*importTarget, error) {
+	if !allProfiles {
+		return []*importTarget{{profile: cfg.Profile, cfg: cfg}}, nil
+	}
+
+	if len(cfg.Profiles) == 0 {
+		return nil, fmt.Errorf("--all-profiles: no [profiles.<name>] sections in %s", configPath)
+	}
+	if cmd.Flags().Changed("db") {
+		return nil, fmt.Errorf("--db kann nicht mit --all-profiles kombiniert werden (database.path je Profil)")
+	}
+
+	var targets []*importTarget
+	paths := make(map[string]string)
+	for _, name := range cfg.Profiles {
+		profileCfg, _, err := loadProfileConfig(cmd, name)
+		if err != nil {
+			return nil, err
+		}
+		if profileCfg.Import.SDEPath != cfg.Import.SDEPath {
+			return nil, fmt.Errorf("profile %s: import.sde_path %q differs from %q (--all-profiles parses one SDE)",
+				name, profileCfg.Import.SDEPath, cfg.Import.SDEPath)
+		}
+		path := filepath.Clean(profileCfg.Database.Path)
+		if other, ok := paths[path]; ok {
+			return nil, fmt.Errorf("profiles %s and %s use the same database.path %s", other, name, profileCfg.Database.Path)
+		}
+		paths[path] = name
+		if into := profileCfg.Import.Finalize.VacuumInto; into != "" {
+			into = filepath.Clean(into)
+			if other, ok := paths[into]; ok {
+				return nil, fmt.Errorf("profile %s: import.finalize.vacuum_into %s is already used by profile %s",
+					name, profileCfg.Import.Finalize.VacuumInto, other)
+			}
+			paths[into] = name
+		}
+		targets = append(targets, &importTarget{profile: name, cfg: profileCfg})
+	}
+	return targets, nil
+}
+
+// workerTargets erstellt die Orchestrator-Ziele (Writer, Tabellenfilter und
+// Tabellen-Einstellungen) für targets. Die Writer werden erst nach dem Öffnen der Datenbanken
+// benötigt; für die Discovery genügen die Filter.
+func workerTargets(targets []*importTarget) []worker.ImportTarget {
+	out := make([]worker.ImportTarget, len(targets))
+	for i, t := range targets {
+		out[i] = worker.ImportTarget{Name: t.profile, Tables: t.filter, Settings: t.settings}
+		if t.db != nil {
+			out[i].Writer = worker.NewDBWriter(t.db)
+		}
+	}
+	return out
+}
+
+// openImportTarget öffnet die Datenbank eines Ziels mit den SQLite-Optionen
+// aus [database], prüft den Downgrade-Schutz und wendet die Migrationen an.
+func openImportTarget(ctx context.Context, t *importTarget, sdeBuild int64) error {
+	log := logger.GetGlobalLogger()
+	path := t.cfg.Database.Path
+
+	// Open Database (SQLite-Optionen aus [database]: journal_mode, synchronous, ...)
+	dbOptions := databaseOptions(t.cfg.Database)
+	if dbOptions.ReadOnly || dbOptions.Immutable {
+		return t.errorf("database.read_only and database.immutable cannot be used for import")
+	}
+	db, err := database.NewDBWithOptions(path, dbOptions)
+	if err != nil {
+		return t.errorf("failed to open database: %w", err)
+	}
+	t.db = db
+
+	// Downgrade-Schutz: SDE-Build mit dem Build der Ziel-Datenbank vergleichen
+	if err := checkSDEDowngrade(ctx, db, sdeBuild, forceImport); err != nil {
+		return t.errorf("%w", err)
+	}
+
+	// Run Migrations (Schema Creation)
+	// Bei --defer-indexes werden Sekundär-Indizes erst nach dem Import erstellt
+	log.Info("Applying database migrations...", t.logFields(logger.Field{Key: "db_path", Value: path})...)
+	if deferIndexes {
+		t.deferredIndexes, err = database.ApplySchemaFromCLIWithOptions(path, dbOptions)
+	} else {
+		err = database.ApplyMigrationsFromCLIWithOptions(path, dbOptions)
+	}
+	if err != nil {
+		return t.errorf("failed to apply migrations: %w", err)
+	}
+	log.Info("Database migrations applied successfully",
+		t.logFields(logger.Field{Key: "deferred_indexes", Value: len(t.deferredIndexes)})...,
+	)
+	return nil
+}
+
+// finalizeError kennzeichnet Fehler der Finalize-Phase, nach denen die
+// Zusammenfassung noch ausgegeben wird.
+type finalizeError struct{ err error }
+
+func (e *finalizeError) Error() string { return e.err.Error() }
+func (e *finalizeError) Unwrap() error { return e.err }
+
+// finishImportTarget schließt den Import eines Ziels ab: verzögerte Indizes,
+// Eintrag in _import_runs, SDE-Build in user_version und Finalize-Phase.
+// Sind Dateien fehlgeschlagen (failedFiles > 0) oder schließen Tabellenfilter,
+// tables.<name>.skip bzw. das Profil Parser-Tabellen aus, bleibt user_version
+// unverändert, da die Datenbank den Build nur teilweise enthält.
+// Fehler der Finalize-Phase werden als *finalizeError geliefert.
+func finishImportTarget(ctx context.Context, t *importTarget, startTime time.Time, sdeBuild, failedFiles int64) error {
+	log := logger.GetGlobalLogger()
+
+	// Deferred Index Build (nach dem Laden aller Daten)
+	if len(t.deferredIndexes) > 0 {
+		log.Info("Creating deferred indexes...",
+			t.logFields(logger.Field{Key: "index_count", Value: len(t.deferredIndexes)})...,
+		)
+		indexStart := time.Now()
+		if err := database.CreateIndexes(ctx, t.db, t.deferredIndexes); err != nil {
+			return t.errorf("failed to create indexes: %w", err)
+		}
+		t.indexDuration = time.Since(indexStart)
+		log.Info("Deferred indexes created",
+			t.logFields(
+				logger.Field{Key: "index_count", Value: len(t.deferredIndexes)},
+				logger.Field{Key: "duration", Value: t.indexDuration},
+			)...,
+		)
+	}
+
+	// Import-Run in _import_runs protokollieren (vor der Finalize-Phase,
+	// damit der Eintrag auch in einer VACUUM INTO Kopie enthalten ist)
+	var err error
+	t.run.StartedAt = startTime
+	t.run.FinishedAt = time.Now()
+	if t.run.RowCounts, err = database.CountTableRows(ctx, t.db); err != nil {
+		return t.errorf("failed to count table rows: %w", err)
+	}
+	if err := database.RecordImportRun(ctx, t.db, t.run); err != nil {
+		return t.errorf("failed to record import run: %w", err)
+	}
+	log.Info("Import run recorded",
+		t.logFields(
+			logger.Field{Key: "run_id", Value: t.run.ID},
+			logger.Field{Key: "sde_version", Value: t.run.SDEVersion},
+			logger.Field{Key: "sde_build", Value: t.run.SDEBuildNumber},
+		)...,
+	)
+
+	// SDE-Build in user_version ablegen (ohne Metadaten-Tabelle abfragbar);
+	// ein unvollständiger Import darf den Downgrade-Schutz nicht anheben
+	omitted := t.omittedTables()
+	if sdeBuild > 0 && failedFiles > 0 {
+		log.Warn("Skipping user_version because files failed to import",
+			t.logFields(
+				logger.Field{Key: "sde_build", Value: sdeBuild},
+				logger.Field{Key: "failed_count", Value: int(failedFiles)},
+			)...,
+		)
+	} else if sdeBuild > 0 && len(omitted) > 0 {
+		log.Warn("Skipping user_version because tables were left out of the import",
+			t.logFields(
+				logger.Field{Key: "sde_build", Value: sdeBuild},
+				logger.Field{Key: "omitted_tables", Value: len(omitted)},
+			)...,
+		)
+	} else if sdeBuild > 0 {
+		if err := database.SetUserVersion(ctx, t.db, sdeBuild); err != nil {
+			return t.errorf("failed to store SDE build in user_version: %w", err)
+		}
+	}
+
+	// Finalize-Phase: nur die in [import.finalize] bzw. per Flag
+	// angeforderten Schritte
+	opts := finalizeOptions(t.cfg.Import.Finalize)
+	if opts == (database.FinalizeOptions{}) {
+		return nil
+	}
+
+	log.Info("Running finalize phase...", t.logFields()...)
+	t.finalizeSteps, err = database.Finalize(ctx, t.db, opts)
+	for _, step := range t.finalizeSteps {
+		log.Info("Finalize step completed",
+			t.logFields(
+				logger.Field{Key: "step", Value: step.Name},
+				logger.Field{Key: "duration", Value: step.Duration},
+				logger.Field{Key: "result", Value: step.Result},
+			)...,
+		)
+	}
+	if err != nil {
+		return &finalizeError{t.errorf("finalize phase failed: %w", err)}
+	}
+	return nil
+}
+
+// displayProfileStats zeigt die Insert-Statistik je Profil (--all-profiles).
+func displayProfileStats(targets []*importTarget, stats []worker.TargetStats) {
+	fmt.Printf("=== Profiles ===\n")
+	for i, t := range targets {
+		var s worker.TargetStats
+		if i < len(stats) {
+			s = stats[i]
+		}
+		fmt.Printf("%-12s %-32s %4d files %10d rows", t.profile, t.cfg.Database.Path, s.InsertedFiles, s.InsertedRows)
+		if s.FailedFiles > 0 {
+			fmt.Printf("  (%d failed)", s.FailedFiles)
+		}
+		fmt.Printf("\n")
+	}
+	fmt.Printf("\n")
+}
+
+// importTableFilter erstellt den Tabellenfilter für den Import aus
+// import.tables bzw. import.exclude_tables der effektiven Konfiguration
+// (explizit gesetzte --tables / --exclude-tables haben dort bereits Vorrang).
+// Patterns, die keine registrierte Tabelle treffen, werden als Warnung
+// gemeldet (z.B. Tippfehler).
+func importTableFilter(cfg *config.Config) (*parser.TableFilter, error) {
+	filter, err := parser.NewTableFilter(cfg.Import.Tables, cfg.Import.ExcludeTables)
+	if err != nil {
+		return nil, fmt.Errorf("invalid table filter: %w", err)
+	}
+	if filter.IsEmpty() {
+		return nil, nil
+	}
+
+	for _, pattern := range filter.Unmatched(registeredTables()) {
+		cli.Warning("Warning: table pattern %q matches no known table", pattern)
+	}
+
+	return filter, nil
+}
+
+// registeredTables liefert die Tabellennamen aller registrierten Parser
+// (sortiert), z.B. für config.Config.CheckTableNames.
+func registeredTables() []string {
+	var tables []string
+	for _, p := range parser.RegisterParsers() {
+		tables = append(tables, p.TableName())
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// importTableSettings wandelt die [tables.<name>] Abschnitte der effektiven
+// Konfiguration in Orchestrator-Einstellungen um. Unbekannte Tabellen sind
+// ein Fehler. error_mode = "fail_fast" entspricht dem Standardverhalten
+// (ParseFile) und wird daher nicht gesetzt.
+func importTableSettings(cfg *config.Config) (map[string]worker.TableSettings, error) {
+	if issues := cfg.CheckTableNames(registeredTables()); len(issues) > 0 {
+		return nil, &config.ValidationError{Issues: issues}
+	}
+	settings := make(map[string]worker.TableSettings, len(cfg.Tables))
+	for table, tc := range cfg.Tables {
+		s := worker.TableSettings{BatchSize: tc.BatchSize, MaxErrors: tc.MaxErrors, Skip: tc.Skip}
+		if tc.ErrorMode != "" {
+			mode, err := parser.ParseErrorMode(tc.ErrorMode)
+			if err != nil {
+				return nil, fmt.Errorf("invalid tables.%s: %w", table, err)
+			}
+			if mode == parser.ErrorModeSkip {
+				s.ErrorMode = &mode
+			}
+		}
+		conflict, err := database.ParseConflictStrategy(tc.OnConflict)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tables.%s: %w", table, err)
+		}
+		s.OnConflict = conflict
+		settings[table] = s
+	}
+	return settings, nil
+}
+
+// importSelection liefert die Orchestrator-Optionen für Tabellenauswahl und
+// Tabellen-Einstellungen: Filter und Einstellungen des einzigen Ziels bzw.
+// ein Ziel pro Profil (geparst wird die Vereinigung aller Profile).
+func importSelection(targets []*importTarget) ([]worker.OrchestratorOption, error) {
+	if len(targets) == 1 {
+		return []worker.OrchestratorOption{
+			worker.WithTableFilter(targets[0].filter),
+			worker.WithTableSettings(targets[0].settings),
+		}, nil
+	}
+
+	parse, err := profileParseSettings(targets)
+	if err != nil {
+		return nil, err
+	}
+	return []worker.OrchestratorOption{
+		worker.WithTargets(workerTargets(targets)...),
+		worker.WithTableSettings(parse),
+	}, nil
+}
+
+// profileParseSettings ermittelt die Parse-Einstellungen (error_mode,
+// max_errors) bei --all-profiles. Da das SDE nur einmal geparst wird, müssen
+// alle Profile, die eine Tabelle importieren, dafür dieselben Werte verwenden.
+func profileParseSettings(targets []*importTarget) (map[string]worker.TableSettings, error) {
+	seen := make(map[string]bool)
+	var tables []string
+	for _, t := range targets {
+		for table := range t.settings {
+			if !seen[table] {
+				seen[table] = true
+				tables = append(tables, table)
+			}
+		}
+	}
+	sort.Strings(tables)
+
+	parse := make(map[string]worker.TableSettings, len(tables))
+	for _, table := range tables {
+		var first *importTarget
+		for _, t := range targets {
+			s := t.settings[table]
+			if s.Skip || !t.filter.Match(table) {
+				continue
+			}
+			if first == nil {
+				first = t
+				parse[table] = worker.TableSettings{ErrorMode: s.ErrorMode, MaxErrors: s.MaxErrors}
+				continue
+			}
+			if !sameErrorHandling(parse[table], s) {
+				return nil, fmt.Errorf("profiles %s and %s use different error_mode/max_errors for table %s (the SDE is parsed only once)",
+					first.profile, t.profile, table)
+			}
+		}
+	}
+	return parse, nil
+}
+
+// sameErrorHandling prüft, ob a und b mit demselben Fehler-Modus parsen.
+func sameErrorHandling(a, b worker.TableSettings) bool {
+	if (a.ErrorMode == nil) != (b.ErrorMode == nil) {
+		return false
+	}
+	if a.ErrorMode != nil && *a.ErrorMode != *b.ErrorMode {
+		return false
+	}
+	return a.MaxErrors == b.MaxErrors
+}
+
+// displaySkippedTables listet die vom Tabellenfilter ausgeschlossenen Tabellen auf.
+func displaySkippedTables(tables []string) {
+	if len(tables) == 0 {
+		return
+	}
+	fmt.Printf("Skipped:   %d tables (%s)\n", len(tables), strings.Join(tables, ", "))
+}
+
+// displayWorkerStats zeigt die Auslastung je Worker der Parse-Phase an.
+//
+// Dient zum Tuning von --workers: Liegt die durchschnittliche Auslastung
+// deutlich unter 100%, bringen zusätzliche Worker keinen Gewinn.
+func displayWorkerStats(stats worker.PoolStats) {
+	if len(stats.Workers) == 0 {
+		return
+	}
+
+	fmt.Printf("=== Workers (avg utilization %.0f%%) ===\n", stats.Utilization*100)
+	for _, w := range stats.Workers {
+		fmt.Printf("worker %-3d %5d jobs %12v  %5.1f%%\n",
+			w.ID, w.Jobs, w.Busy.Round(time.Millisecond), w.Utilization*100)
+	}
+	fmt.Printf("\n")
+}
@@ -9,6 +9,7 @@ import (
 	"syscall"
 
 	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/cli"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/config"
 	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/logger"
 	"github.com/spf13/cobra"
 )
@@ -20,26 +21,20 @@ var (
 )
 
 var (
-	configPath string
-	verbose    bool
-	noColor    bool
+	configPath  string
+	profileName string
+	verbose     bool
+	noColor     bool
 )
 
 func main() {
 	// Check if we're running a completion command - if so, skip logging to avoid polluting the completion script
 	isCompletionCmd := len(os.Args) >= 2 && os.Args[1] == "completion"
 
-	// Initialize logger
-	logLevel := "info"
-	logFormat := "json"
-
-	log := logger.NewLogger(logLevel, logFormat)
-	logger.SetGlobalLogger(log)
-
-	// Log application start (skip for completion commands)
-	if !isCompletionCmd {
-		logger.LogAppStart(version, commit)
-	}
+	// Initialize logger with defaults; PersistentPreRun reconfigures it from
+	// the effective config (logging.level/format) once the flags are parsed
+	defaults := config.DefaultConfig()
+	logger.SetGlobalLogger(logger.NewLogger(defaults.Logging.Level, defaults.Logging.Format))
 
 	// Setup signal handler for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
@@ -65,7 +60,8 @@ in eine SQLite-Datenbank. Der Import erfolgt in zwei Phasen:
 Verfügbare Befehle:
   import   - Importiert SDE JSONL-Dateien in SQLite-Datenbank
   validate - Validiert eine TOML-Konfigurationsdatei
-  stats    - Zeigt Datenbankstatistiken an`,
+  stats    - Zeigt Datenbankstatistiken an
+  export   - Exportiert die Datenbank (CSV, PostgreSQL-Dump)`,
 		Example: `  # Import mit Standard-Einstellungen
   esdedb import
 
@@ -79,11 +75,14 @@ Verfügbare Befehle:
   esdedb --verbose import --sde-dir ./sde-JSONL`,
 		Version: fmt.Sprintf("%s (commit: %s)", version, commit),
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			// Adjust log level based on verbose flag
-			if verbose {
-				log := logger.NewLogger("debug", logFormat)
-				logger.SetGlobalLogger(log)
+			// Logging aus der effektiven Konfiguration (--verbose setzt debug)
+			configureLogging(cmd)
+
+			// Log application start (skip for completion commands)
+			if !isCompletionCmd {
+				logger.LogAppStart(version, commit)
 			}
+
 			// Set color mode based on --no-color flag
 			if noColor {
 				cli.SetColorMode(cli.ColorNever)
@@ -94,8 +93,9 @@ Verfügbare Befehle:
 	}
 
 	// Persistent Flags (global für alle Commands)
-	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "./config.toml", "Pfad zur TOML-Konfigurationsdatei")
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Aktiviert Verbose Logging (Debug Level) für detaillierte Ausgaben")
+	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "./config.toml", "Pfad zur TOML-Konfigurationsdatei (Defaults < Datei < Profil < ESDEDB_* Environment < Flags)")
+	rootCmd.PersistentFlags().StringVarP(&profileName, "profile", "p", "", "Profil aus [profiles.<name>] der Konfigurationsdatei anwenden")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Aktiviert Verbose Logging (Debug Level) für detaillierte Ausgaben (überschreibt logging.level)")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Deaktiviert farbige Konsolenausgabe (nützlich für Logs/CI)")
 
 	// Subcommands
@@ -103,6 +103,7 @@ Verfügbare Befehle:
 	rootCmd.AddCommand(newValidateCmd())
 	rootCmd.AddCommand(newVersionCmd())
 	rootCmd.AddCommand(newStatsCmd())
+	rootCmd.AddCommand(newExportCmd())
 	rootCmd.AddCommand(newConfigCmd())
 
 	if err := rootCmd.Execute(); err != nil {
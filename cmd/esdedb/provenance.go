@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/logger"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/worker"
+	"github.com/jmoiron/sqlx"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// newImportRun erstellt einen ImportRun-Eintrag mit allen Provenienz-Daten,
+// die bereits vor dem Import feststehen (SDE-Version und -Build, Tool-Version,
+// Host, Flags). sdeBuild stammt aus sdeBuildNumber. Die Datei-Checksummen
+// ergänzt der Vorab-Scan des Imports (siehe checksumRecorder).
+func newImportRun(cmd *cobra.Command, sdeDir string, sdeBuild int64) *database.ImportRun {
+	run := &database.ImportRun{
+		SDEBuildNumber: sdeBuild,
+		ToolVersion:    version,
+		ToolCommit:     commit,
+		Flags:          collectFlags(cmd),
+	}
+
+	if hostname, err := os.Hostname(); err == nil {
+		run.Hostname = hostname
+	}
+
+	// SDE-Version aus _sde.jsonl (optional - fehlt bei Teil-Exports). Eine
+	// unlesbare Datei bricht den Import nicht ab, Version und Release-Datum
+	// bleiben dann leer.
+	metaPath := filepath.Join(sdeDir, parser.SDEMetadataFile)
+	if _, err := os.Stat(metaPath); err == nil {
+		meta, err := parser.ReadSDEMetadata(metaPath)
+		if err != nil {
+			logger.GetGlobalLogger().Warn("SDE metadata not available",
+				logger.Field{Key: "file", Value: metaPath},
+				logger.Field{Key: "error", Value: err.Error()},
+			)
+			meta = &parser.SDEMetadata{}
+		}
+		if meta.Version != nil {
+			run.SDEVersion = *meta.Version
+		}
+		if meta.ReleaseDate != nil {
+			run.SDEReleaseDate = *meta.ReleaseDate
+		}
+	}
+
+	return run
+}
+
+// sdeBuildNumber liest die Build-Nummer des SDE-Exports aus _sde.jsonl.
+//
+// Fehlt die Datei, wird 0 (unbekannt) zurückgegeben; die Downgrade-Prüfung
+// entfällt dann. Ist sie unlesbar oder enthält keine Build-Nummer, ist das ein
+// Fehler, da der Downgrade-Schutz sonst unbemerkt ausfällt; mit force wird
+// nur gewarnt und 0 zurückgegeben.
+func sdeBuildNumber(sdeDir string, force bool) (int64, error) {
+	metaPath := filepath.Join(sdeDir, parser.SDEMetadataFile)
+	if _, err := os.Stat(metaPath); err != nil {
+		return 0, nil
+	}
+	build, err := parser.ReadSDEBuildNumber(metaPath)
+	if err != nil {
+		if !force {
+			return 0, fmt.Errorf("SDE build number not available (use --force to import without downgrade protection): %w", err)
+		}
+		logger.GetGlobalLogger().Warn("SDE build number not available, importing without downgrade protection (--force)",
+			logger.Field{Key: "file", Value: metaPath},
+			logger.Field{Key: "error", Value: err.Error()},
+		)
+		return 0, nil
+	}
+	return build, nil
+}
+
+// checkSDEDowngrade verhindert, dass eine Datenbank mit einem älteren SDE-Build
+// überschrieben wird als dem, den sie enthält. Maßgeblich ist der Build des
+// letzten Laufs in _import_runs, damit auch teilweise Importe
+// (fehlgeschlagene Dateien, Tabellenfilter), die user_version nicht setzen,
+// geschützt sind und nach einem erzwungenen Downgrade derselbe Build wieder
+// ohne --force importiert werden kann. Ohne Lauf mit bekanntem Build gilt
+// PRAGMA user_version.
+//
+// Ist einer der beiden Builds unbekannt (0), findet keine Prüfung statt.
+// Mit force wird der Downgrade nur protokolliert.
+func checkSDEDowngrade(ctx context.Context, db *sqlx.DB, incoming int64, force bool) error {
+	current, err := database.LatestImportedBuildNumber(ctx, db)
+	if err != nil {
+		return err
+	}
+	if current == 0 {
+		if current, err = database.GetUserVersion(ctx, db); err != nil {
+			return err
+		}
+	}
+	if current == 0 || incoming == 0 || incoming >= current {
+		return nil
+	}
+	if !force {
+		return fmt.Errorf("database contains SDE build %d, refusing to import older build %d (use --force to override)", current, incoming)
+	}
+	logger.GetGlobalLogger().Warn("Importing older SDE build (--force)",
+		logger.Field{Key: "current_build", Value: current},
+		logger.Field{Key: "incoming_build", Value: incoming},
+	)
+	return nil
+}
+
+// collectFlags sammelt die beim Aufruf gesetzten Flags des Commands (inkl.
+// globaler Flags) mit ihren Werten. Flags mit Standardwert werden nicht
+// aufgezeichnet, damit der Import-Run nur die tatsächlich gewählten Optionen
+// enthält.
+func collectFlags(cmd *cobra.Command) map[string]string {
+	flags := make(map[string]string)
+	visit := func(f *pflag.Flag) {
+		if f.Changed {
+			flags[f.Name] = f.Value.String()
+		}
+	}
+	cmd.InheritedFlags().VisitAll(visit)
+	cmd.Flags().VisitAll(visit)
+	return flags
+}
+
+// checksumRecorder übernimmt die im Vorab-Scan berechneten SHA-256-Checksummen
+// (siehe worker.WithChecksums) in die Import-Runs aller Ziele, damit die
+// SDE-Dateien dafür nicht ein weiteres Mal gelesen werden.
+type checksumRecorder struct {
+	worker.NopObserver
+	runs []*database.ImportRun
+}
+
+// OnScanComplete implementiert worker.ImportObserver.
+func (r checksumRecorder) OnScanComplete(scan worker.ScanResult) {
+	checksums := make(map[string]string, len(scan.Files))
+	for file, fs := range scan.Files {
+		if fs.SHA256 != "" {
+			checksums[filepath.Base(file)] = fs.SHA256
+		}
+	}
+	for _, run := range r.runs {
+		run.FileChecksums = checksums
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/config"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/worker"
+	"github.com/spf13/cobra"
+)
+
+// TestNewImportRun_InvalidMetadata testet, dass eine ungültige _sde.jsonl den
+// Import nicht verhindert (Version und Release-Datum bleiben leer)
+func TestNewImportRun_InvalidMetadata(t *testing.T) {
+	sdeDir := t.TempDir()
+	metaPath := filepath.Join(sdeDir, parser.SDEMetadataFile)
+	content := `{"_key": "sde", "version": 20250101, "releaseDate": "2025-01-01", "buildNumber": 3100000}` + "\n"
+	if err := os.WriteFile(metaPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write metadata: %v", err)
+	}
+
+	run := newImportRun(&cobra.Command{Use: "import"}, sdeDir, 3100000)
+	if run.SDEVersion != "" || run.SDEReleaseDate != "" {
+		t.Errorf("expected empty version and release date, got %q / %q", run.SDEVersion, run.SDEReleaseDate)
+	}
+	if run.SDEBuildNumber != 3100000 {
+		t.Errorf("expected build number 3100000, got %d", run.SDEBuildNumber)
+	}
+}
+
+// TestSDEBuildNumber testet, dass eine unlesbare _sde.jsonl den Import ohne
+// --force abbricht, eine fehlende Datei aber nicht
+func TestSDEBuildNumber(t *testing.T) {
+	sdeDir := t.TempDir()
+	if build, err := sdeBuildNumber(sdeDir, false); err != nil || build != 0 {
+		t.Errorf("expected build 0 without _sde.jsonl, got %d (%v)", build, err)
+	}
+
+	metaPath := filepath.Join(sdeDir, parser.SDEMetadataFile)
+	if err := os.WriteFile(metaPath, []byte(`{"_key": "sde", "buildNumber": 3100000}`+"\n"), 0644); err != nil {
+		t.Fatalf("failed to write metadata: %v", err)
+	}
+	if build, err := sdeBuildNumber(sdeDir, false); err != nil || build != 3100000 {
+		t.Errorf("expected build 3100000, got %d (%v)", build, err)
+	}
+
+	if err := os.WriteFile(metaPath, []byte("{broken\n"), 0644); err != nil {
+		t.Fatalf("failed to write metadata: %v", err)
+	}
+	if _, err := sdeBuildNumber(sdeDir, false); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("expected error mentioning --force, got %v", err)
+	}
+	if build, err := sdeBuildNumber(sdeDir, true); err != nil || build != 0 {
+		t.Errorf("expected build 0 with --force, got %d (%v)", build, err)
+	}
+}
+
+// TestCheckSDEDowngrade_ImportRuns testet, dass auch ein in _import_runs
+// protokollierter Build ohne user_version (teilweiser Import) schützt
+func TestCheckSDEDowngrade_ImportRuns(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer func() { _ = database.Close(db) }()
+
+	run := &database.ImportRun{SDEBuildNumber: 3200000, StartedAt: time.Now(), FinishedAt: time.Now()}
+	if err := database.RecordImportRun(ctx, db, run); err != nil {
+		t.Fatalf("RecordImportRun failed: %v", err)
+	}
+
+	if err := checkSDEDowngrade(ctx, db, 3100000, false); err == nil || !strings.Contains(err.Error(), "3200000") {
+		t.Errorf("expected downgrade from recorded build 3200000 to be refused, got %v", err)
+	}
+	if err := checkSDEDowngrade(ctx, db, 3100000, true); err != nil {
+		t.Errorf("expected forced downgrade to pass, got %v", err)
+	}
+	if err := checkSDEDowngrade(ctx, db, 3200000, false); err != nil {
+		t.Errorf("expected same build to pass, got %v", err)
+	}
+
+	// After a forced downgrade the database holds the older build
+	run = &database.ImportRun{SDEBuildNumber: 3100000, StartedAt: time.Now(), FinishedAt: time.Now()}
+	if err := database.RecordImportRun(ctx, db, run); err != nil {
+		t.Fatalf("RecordImportRun failed: %v", err)
+	}
+	if err := checkSDEDowngrade(ctx, db, 3100000, false); err != nil {
+		t.Errorf("expected re-import of the downgraded build to pass, got %v", err)
+	}
+	if err := checkSDEDowngrade(ctx, db, 3000000, false); err == nil {
+		t.Error("expected downgrade below the latest run to be refused")
+	}
+}
+
+// TestFinishImportTarget_UserVersion testet, dass user_version nur nach einem
+// fehlerfreien Import gesetzt wird, der Lauf aber immer protokolliert wird
+func TestFinishImportTarget_UserVersion(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name        string
+		failedFiles int64
+		expected    int64
+	}{
+		{"no failures", 0, 3100000},
+		{"failed files", 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := database.NewDB(filepath.Join(t.TempDir(), "test.db"))
+			if err != nil {
+				t.Fatalf("failed to create database: %v", err)
+			}
+			defer func() { _ = database.Close(db) }()
+			if err := database.EnsureImportRunsTable(ctx, db); err != nil {
+				t.Fatalf("failed to create import runs table: %v", err)
+			}
+
+			cfg := config.DefaultConfig()
+			target := &importTarget{cfg: &cfg, db: db, run: &database.ImportRun{SDEBuildNumber: 3100000}}
+			if err := finishImportTarget(ctx, target, time.Now(), 3100000, tt.failedFiles); err != nil {
+				t.Fatalf("finishImportTarget failed: %v", err)
+			}
+
+			version, err := database.GetUserVersion(ctx, db)
+			if err != nil {
+				t.Fatalf("GetUserVersion failed: %v", err)
+			}
+			if version != tt.expected {
+				t.Errorf("expected user_version %d, got %d", tt.expected, version)
+			}
+			runs, err := database.ListImportRuns(ctx, db)
+			if err != nil {
+				t.Fatalf("ListImportRuns failed: %v", err)
+			}
+			if len(runs) != 1 {
+				t.Errorf("expected 1 recorded import run, got %d", len(runs))
+			}
+		})
+	}
+}
+
+// TestChecksumRecorder testet, dass die Checksummen des Vorab-Scans in die
+// Import-Runs aller Ziele übernommen werden (Schlüssel: Dateiname)
+func TestChecksumRecorder(t *testing.T) {
+	runs := []*database.ImportRun{{}, {}}
+	recorder := checksumRecorder{runs: runs}
+	recorder.OnScanComplete(worker.ScanResult{Files: map[string]worker.FileScan{
+		filepath.Join("sde", "invTypes.jsonl"): {Lines: 3, SHA256: "9f86d081"},
+		filepath.Join("sde", "broken.jsonl"):   {},
+	}})
+
+	for i, run := range runs {
+		if len(run.FileChecksums) != 1 || run.FileChecksums["invTypes.jsonl"] != "9f86d081" {
+			t.Errorf("run %d: expected checksum for invTypes.jsonl only, got %v", i, run.FileChecksums)
+		}
+	}
+}
+
+// TestCollectFlags testet, dass nur beim Aufruf gesetzte Flags (inkl.
+// globaler Flags) aufgezeichnet werden
+func TestCollectFlags(t *testing.T) {
+	root := &cobra.Command{Use: "esdedb"}
+	root.PersistentFlags().String("log-level", "info", "")
+	cmd := &cobra.Command{Use: "import", Run: func(*cobra.Command, []string) {}}
+	cmd.Flags().Int("workers", 4, "")
+	cmd.Flags().String("db", "./eve-sde.db", "")
+	root.AddCommand(cmd)
+
+	root.SetArgs([]string{"import", "--workers", "8", "--log-level", "debug"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+
+	flags := collectFlags(cmd)
+	want := map[string]string{"workers": "8", "log-level": "debug"}
+	if !reflect.DeepEqual(flags, want) {
+		t.Errorf("expected %v, got %v", want, flags)
+	}
+}
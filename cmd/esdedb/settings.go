@@ -0,0 +1,318 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/config"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// flagBinding ordnet ein CLI Flag dem Config-Schlüssel zu, den es überschreibt.
+type flagBinding struct {
+	Flag  string                                           // Flag-Name (ohne --)
+	Key   string                                           // Config-Schlüssel, z.B. "database.path"
+	Apply func(cfg *config.Config, f *pflag.FlagSet) error // Überträgt den Flag-Wert in die Config
+	// Cmd beschränkt die Bindung auf den Command mit diesem Namen (z.B.
+	// "csv"); leer = alle Commands ohne eigene Bindung für das Flag
+	Cmd string
+	// AllowEmpty erlaubt einen explizit leeren Wert (z.B. --null "")
+	AllowEmpty bool
+	// IgnoreFalse: ein explizites =false überschreibt nichts (z.B.
+	// --verbose=false lässt logging.level unverändert)
+	IgnoreFalse bool
+}
+
+// flagBindings listet alle Flags, die Config-Werte überschreiben.
+//
+// Flags mit gleichem Namen in verschiedenen Commands (z.B. --db bei import
+// und stats) teilen sich eine Bindung, sofern ein Command keine eigene
+// Bindung (Cmd) hat – z.B. wirkt --tables bei export csv auf
+// export.csv.tables statt import.tables.
+var flagBindings = []flagBinding{
+	{Flag: "db", Key: "database.path", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Database.Path, err = f.GetString("db")
+		return err
+	}},
+	{Flag: "sde-dir", Key: "import.sde_path", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Import.SDEPath, err = f.GetString("sde-dir")
+		return err
+	}},
+	{Flag: "workers", Key: "import.workers", Apply: func(cfg *config.Config, f *pflag.FlagSet) error {
+		workers, err := f.GetInt("workers")
+		if err != nil {
+			return err
+		}
+		// -1 ist die CLI-Schreibweise für Auto (in der Config 0)
+		if workers == -1 {
+			workers = 0
+		}
+		cfg.Import.Workers = workers
+		return nil
+	}},
+	{Flag: "tables", Key: "import.tables", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Import.Tables, err = f.GetStringSlice("tables")
+		return err
+	}},
+	{Flag: "exclude-tables", Key: "import.exclude_tables", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Import.ExcludeTables, err = f.GetStringSlice("exclude-tables")
+		return err
+	}},
+	// --finalize setzt analyze, optimize und checkpoint gemeinsam; die
+	// Einzel-Flags stehen danach und überschreiben es
+	{Flag: "finalize", Key: "import.finalize.analyze", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Import.Finalize.Analyze, err = f.GetBool("finalize")
+		return err
+	}},
+	{Flag: "finalize", Key: "import.finalize.optimize", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Import.Finalize.Optimize, err = f.GetBool("finalize")
+		return err
+	}},
+	{Flag: "finalize", Key: "import.finalize.checkpoint", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Import.Finalize.Checkpoint, err = f.GetBool("finalize")
+		return err
+	}},
+	{Flag: "analyze", Key: "import.finalize.analyze", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Import.Finalize.Analyze, err = f.GetBool("analyze")
+		return err
+	}},
+	{Flag: "optimize", Key: "import.finalize.optimize", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Import.Finalize.Optimize, err = f.GetBool("optimize")
+		return err
+	}},
+	{Flag: "checkpoint", Key: "import.finalize.checkpoint", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Import.Finalize.Checkpoint, err = f.GetBool("checkpoint")
+		return err
+	}},
+	{Flag: "vacuum", Key: "import.finalize.vacuum", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Import.Finalize.Vacuum, err = f.GetBool("vacuum")
+		return err
+	}},
+	{Flag: "vacuum-into", Key: "import.finalize.vacuum_into", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Import.Finalize.VacuumInto, err = f.GetString("vacuum-into")
+		return err
+	}},
+	{Flag: "integrity-check", Key: "import.finalize.integrity_check", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Import.Finalize.IntegrityCheck, err = f.GetBool("integrity-check")
+		return err
+	}},
+	{Flag: "verbose", Key: "logging.level", IgnoreFalse: true, Apply: func(cfg *config.Config, f *pflag.FlagSet) error {
+		if v, err := f.GetBool("verbose"); err != nil || !v {
+			return err
+		}
+		cfg.Logging.Level = "debug"
+		return nil
+	}},
+
+	// export csv
+	{Flag: "out", Key: "export.csv.path", Cmd: "csv", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Export.CSV.Path, err = f.GetString("out")
+		return err
+	}},
+	{Flag: "delimiter", Key: "export.csv.delimiter", Cmd: "csv", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Export.CSV.Delimiter, err = f.GetString("delimiter")
+		return err
+	}},
+	{Flag: "no-header", Key: "export.csv.header", Cmd: "csv", Apply: func(cfg *config.Config, f *pflag.FlagSet) error {
+		noHeader, err := f.GetBool("no-header")
+		cfg.Export.CSV.Header = !noHeader
+		return err
+	}},
+	{Flag: "null", Key: "export.csv.null", Cmd: "csv", AllowEmpty: true, Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Export.CSV.Null, err = f.GetString("null")
+		return err
+	}},
+	{Flag: "gzip", Key: "export.csv.gzip", Cmd: "csv", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Export.CSV.Gzip, err = f.GetBool("gzip")
+		return err
+	}},
+	{Flag: "decimal-comma", Key: "export.csv.decimal_comma", Cmd: "csv", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Export.CSV.DecimalComma, err = f.GetBool("decimal-comma")
+		return err
+	}},
+	{Flag: "tables", Key: "export.csv.tables", Cmd: "csv", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Export.CSV.Tables, err = f.GetStringSlice("tables")
+		return err
+	}},
+	{Flag: "exclude-tables", Key: "export.csv.exclude_tables", Cmd: "csv", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Export.CSV.ExcludeTables, err = f.GetStringSlice("exclude-tables")
+		return err
+	}},
+
+	// export postgres
+	{Flag: "out", Key: "export.postgres.path", Cmd: "postgres", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Export.Postgres.Path, err = f.GetString("out")
+		return err
+	}},
+	{Flag: "schema", Key: "export.postgres.schema", Cmd: "postgres", AllowEmpty: true, Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Export.Postgres.Schema, err = f.GetString("schema")
+		return err
+	}},
+	{Flag: "drop-tables", Key: "export.postgres.drop_tables", Cmd: "postgres", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Export.Postgres.DropTables, err = f.GetBool("drop-tables")
+		return err
+	}},
+	{Flag: "tables", Key: "export.postgres.tables", Cmd: "postgres", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Export.Postgres.Tables, err = f.GetStringSlice("tables")
+		return err
+	}},
+	{Flag: "exclude-tables", Key: "export.postgres.exclude_tables", Cmd: "postgres", Apply: func(cfg *config.Config, f *pflag.FlagSet) (err error) {
+		cfg.Export.Postgres.ExcludeTables, err = f.GetStringSlice("exclude-tables")
+		return err
+	}},
+}
+
+// bindingsFor liefert die für cmd gültigen Flag-Bindungen: eigene (Cmd)
+// vor allgemeinen Bindungen desselben Flags.
+func bindingsFor(cmd *cobra.Command) []flagBinding {
+	own := map[string]bool{}
+	for _, b := range flagBindings {
+		if b.Cmd == cmd.Name() {
+			own[b.Flag] = true
+		}
+	}
+	var bindings []flagBinding
+	for _, b := range flagBindings {
+		if b.Cmd == cmd.Name() || (b.Cmd == "" && !own[b.Flag]) {
+			bindings = append(bindings, b)
+		}
+	}
+	return bindings
+}
+
+// loadConfig lädt die effektive Konfiguration für cmd.
+//
+// Alle Commands lösen Einstellungen über diese eine Pipeline auf:
+// Defaults < Konfigurationsdatei (--config) < Profil (--profile) <
+// Environment Variables < explizit gesetzte Flags. Nicht gesetzte Flags überschreiben nichts, auch
+// wenn ihr Default von der Config abweicht; explizit leer gesetzte
+// String-Flags sind ein Fehler. Eine explizit per --config
+// angegebene, aber fehlende Datei ist ein Fehler; die Standard-Datei darf
+// fehlen.
+func loadConfig(cmd *cobra.Command) (*config.Config, error) {
+	cfg, _, err := loadConfigWithSources(cmd)
+	return cfg, err
+}
+
+// loadConfigWithSources arbeitet wie loadConfig und liefert zusätzlich die
+// Quelle jedes Config-Werts (Default, Datei, Profil, Environment Variable, Flag).
+//
+// Hat configureLogging die Konfiguration für cmd bereits geladen, wird
+// dieses Ergebnis (auch ein Fehler) geliefert statt erneut zu laden.
+func loadConfigWithSources(cmd *cobra.Command) (*config.Config, config.Sources, error) {
+	if ctx := cmd.Context(); ctx != nil {
+		if loaded, ok := ctx.Value(loadedConfigKey{}).(*loadedConfig); ok {
+			return loaded.cfg, loaded.sources, loaded.err
+		}
+	}
+	return loadProfileConfig(cmd, profileName)
+}
+
+// loadedConfigKey ist der Context-Schlüssel der von configureLogging
+// geladenen Konfiguration
+type loadedConfigKey struct{}
+
+// loadedConfig ist das Ergebnis von loadProfileConfig für einen Command
+type loadedConfig struct {
+	cfg     *config.Config
+	sources config.Sources
+	err     error
+}
+
+// loadProfileConfig lädt die effektive Konfiguration für cmd mit dem Profil
+// profile (leer = Basis-Konfiguration), z.B. für import --all-profiles.
+func loadProfileConfig(cmd *cobra.Command, profile string) (*config.Config, config.Sources, error) {
+	flags := cmd.Flags()
+	bindings := bindingsFor(cmd)
+	for _, b := range bindings {
+		f := flags.Lookup(b.Flag)
+		if f != nil && f.Changed && !b.AllowEmpty && f.Value.Type() == "string" && f.Value.String() == "" {
+			return nil, nil, fmt.Errorf("--%s darf nicht leer sein", b.Flag)
+		}
+	}
+	if flags.Changed("config") {
+		if _, err := os.Stat(configPath); err != nil {
+			return nil, nil, fmt.Errorf("config file %s: %w", configPath, err)
+		}
+	}
+
+	cfg, sources, err := config.LoadWithSources(configPath, profile, func(cfg *config.Config, sources config.Sources) error {
+		for _, b := range bindings {
+			f := flags.Lookup(b.Flag)
+			if f == nil || !f.Changed {
+				continue
+			}
+			if b.IgnoreFalse && f.Value.String() == "false" {
+				continue
+			}
+			if err := b.Apply(cfg, flags); err != nil {
+				return fmt.Errorf("--%s: %w", b.Flag, err)
+			}
+			sources[b.Key] = config.Source{Kind: config.SourceFlag, Name: "--" + b.Flag}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return cfg, sources, nil
+}
+
+// configureLogging lädt die effektive Konfiguration für cmd einmal und
+// richtet den globalen Logger nach logging.level und logging.format ein.
+//
+// Das Ergebnis wird im Context von cmd hinterlegt; loadConfig liefert es
+// dem Command, ohne die Konfiguration erneut aufzulösen. Lässt sich die
+// Konfiguration nicht laden, bleiben die Defaults (bzw. debug bei --verbose)
+// aktiv und loadConfig liefert den Fehler, damit ihn der Command meldet;
+// validate und config init laufen so auch mit ungültiger Datei.
+func configureLogging(cmd *cobra.Command) {
+	cfg, sources, err := loadProfileConfig(cmd, profileName)
+
+	logging := config.DefaultConfig().Logging
+	if err == nil {
+		logging = cfg.Logging
+	} else if verbose {
+		logging.Level = "debug"
+	}
+	logger.SetGlobalLogger(logger.NewLogger(logging.Level, logging.Format))
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cmd.SetContext(context.WithValue(ctx, loadedConfigKey{}, &loadedConfig{cfg: cfg, sources: sources, err: err}))
+}
+
+// databaseOptions wandelt [database] der effektiven Konfiguration in die
+// Optionen für database.NewDBWithOptions um.
+func databaseOptions(d config.DatabaseConfig) database.Options {
+	return database.Options{
+		JournalMode: d.JournalMode,
+		Synchronous: d.Synchronous,
+		CacheSizeMB: d.CacheSizeMB,
+		MmapSizeMB:  d.MmapSizeMB,
+		BusyTimeout: time.Duration(d.BusyTimeoutMS) * time.Millisecond,
+		PageSize:    d.PageSize,
+		ReadOnly:    d.ReadOnly,
+		Immutable:   d.Immutable,
+	}
+}
+
+// finalizeOptions wandelt [import.finalize] der effektiven Konfiguration in
+// die Schritte für database.Finalize um.
+func finalizeOptions(f config.FinalizeConfig) database.FinalizeOptions {
+	return database.FinalizeOptions{
+		Analyze:        f.Analyze,
+		Optimize:       f.Optimize,
+		Vacuum:         f.Vacuum,
+		VacuumInto:     f.VacuumInto,
+		IntegrityCheck: f.IntegrityCheck,
+		Checkpoint:     f.Checkpoint,
+	}
+}
@@ -0,0 +1,397 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/config"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/logger"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/worker"
+)
+
+// writeSettingsConfig writes a config file and points configPath at it
+func writeSettingsConfig(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	oldPath := configPath
+	configPath = path
+	t.Cleanup(func() { configPath = oldPath })
+}
+
+const settingsTestConfig = `
+[database]
+path = "./file.db"
+
+[import]
+sde_path = "./file-sde"
+workers = 6
+tables = ["inventory"]
+
+[logging]
+level = "warn"
+format = "json"
+`
+
+// TestLoadConfig_Precedence tests flags > env > file > defaults
+func TestLoadConfig_Precedence(t *testing.T) {
+	writeSettingsConfig(t, settingsTestConfig)
+
+	// File only (flag defaults must not override file values)
+	cmd := newImportCmd()
+	if err := cmd.ParseFlags(nil); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Database.Path != "./file.db" || cfg.Import.SDEPath != "./file-sde" || cfg.Import.Workers != 6 {
+		t.Errorf("expected file values, got %+v", cfg)
+	}
+	if len(cfg.Import.Tables) != 1 || cfg.Logging.Level != "warn" || cfg.Logging.Format != "json" {
+		t.Errorf("expected file tables and logging, got %+v", cfg)
+	}
+
+	// Env overrides file
+	t.Setenv("ESDEDB_DATABASE_PATH", "./env.db")
+	t.Setenv("ESDEDB_IMPORT_WORKER_COUNT", "3")
+	cfg, err = loadConfig(cmd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Database.Path != "./env.db" || cfg.Import.Workers != 3 || cfg.Import.SDEPath != "./file-sde" {
+		t.Errorf("expected env to override file, got %+v", cfg)
+	}
+
+	// Explicit flags override env and file
+	cmd = newImportCmd()
+	if err := cmd.ParseFlags([]string{"--db", "./flag.db", "--workers", "2", "--tables", "dogma"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	cfg, err = loadConfig(cmd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Database.Path != "./flag.db" || cfg.Import.Workers != 2 {
+		t.Errorf("expected flags to override env, got %+v", cfg)
+	}
+	if len(cfg.Import.Tables) != 1 || cfg.Import.Tables[0] != "dogma" {
+		t.Errorf("expected --tables to override file, got %v", cfg.Import.Tables)
+	}
+	if cfg.Import.SDEPath != "./file-sde" {
+		t.Errorf("expected unset --sde-dir to keep file value, got %s", cfg.Import.SDEPath)
+	}
+}
+
+// TestLoadConfig_BoolFlags tests that explicit =false flags override true config values
+func TestLoadConfig_BoolFlags(t *testing.T) {
+	writeSettingsConfig(t, `
+[logging]
+level = "warn"
+
+[export.csv]
+gzip = true
+decimal_comma = true
+header = false
+`)
+
+	cmd := newExportCSVCmd()
+	if err := cmd.ParseFlags([]string{"--gzip=false", "--decimal-comma=false", "--no-header=false"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Export.CSV.Gzip || cfg.Export.CSV.DecimalComma || !cfg.Export.CSV.Header {
+		t.Errorf("expected flags to override [export.csv], got %+v", cfg.Export.CSV)
+	}
+
+	// --drop-tables=false overrides drop_tables = true
+	writeSettingsConfig(t, "[export.postgres]\ndrop_tables = true\n")
+	cmd = newExportPostgresCmd()
+	if err := cmd.ParseFlags([]string{"--drop-tables=false"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	if cfg, err = loadConfig(cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Export.Postgres.DropTables {
+		t.Error("expected --drop-tables=false to override [export.postgres]")
+	}
+
+	// [import.finalize] from the file, --vacuum=false overrides vacuum = true
+	writeSettingsConfig(t, "[import.finalize]\nanalyze = false\ncheckpoint = false\nvacuum = true\nvacuum_into = \"./release.db\"\n")
+	cmd = newImportCmd()
+	if err := cmd.ParseFlags([]string{"--vacuum=false", "--integrity-check"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	if cfg, err = loadConfig(cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := config.FinalizeConfig{Optimize: true, VacuumInto: "./release.db", IntegrityCheck: true}
+	if cfg.Import.Finalize != want {
+		t.Errorf("expected finalize %+v, got %+v", want, cfg.Import.Finalize)
+	}
+	if opts := finalizeOptions(cfg.Import.Finalize); opts.Analyze || !opts.Optimize || opts.Checkpoint || opts.Vacuum {
+		t.Errorf("unexpected finalize options %+v", opts)
+	}
+
+	// Without file and flags analyze, optimize and checkpoint are on
+	writeSettingsConfig(t, "")
+	cmd = newImportCmd()
+	if err := cmd.ParseFlags(nil); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	if cfg, err = loadConfig(cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = config.FinalizeConfig{Analyze: true, Optimize: true, Checkpoint: true}
+	if cfg.Import.Finalize != want {
+		t.Errorf("expected default finalize %+v, got %+v", want, cfg.Import.Finalize)
+	}
+
+	// --finalize=false disables analyze, optimize and checkpoint; --checkpoint
+	// overrides it for a single step
+	cmd = newImportCmd()
+	if err := cmd.ParseFlags([]string{"--finalize=false", "--checkpoint"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	cfg, sources, err := loadConfigWithSources(cmd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = config.FinalizeConfig{Checkpoint: true}
+	if cfg.Import.Finalize != want {
+		t.Errorf("expected finalize %+v, got %+v", want, cfg.Import.Finalize)
+	}
+	if src := sources.Get("import.finalize.analyze"); src.Name != "--finalize" {
+		t.Errorf("expected import.finalize.analyze from --finalize, got %+v", src)
+	}
+	if src := sources.Get("import.finalize.checkpoint"); src.Name != "--checkpoint" {
+		t.Errorf("expected import.finalize.checkpoint from --checkpoint, got %+v", src)
+	}
+
+	// --verbose=false keeps logging.level from the file
+	writeSettingsConfig(t, "[logging]\nlevel = \"warn\"\n")
+	cmd = newExportCSVCmd()
+	cmd.Flags().Bool("verbose", false, "")
+	if err := cmd.ParseFlags([]string{"--verbose=false"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	cfg, sources, err = loadConfigWithSources(cmd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Logging.Level != "warn" || sources.Get("logging.level").Kind == config.SourceFlag {
+		t.Errorf("expected --verbose=false to keep file level, got %s (%v)", cfg.Logging.Level, sources.Get("logging.level"))
+	}
+}
+
+// TestConfigureLogging_LoadsOnce tests that commands reuse the config loaded for logging
+func TestConfigureLogging_LoadsOnce(t *testing.T) {
+	t.Cleanup(func() {
+		defaults := config.DefaultConfig().Logging
+		logger.SetGlobalLogger(logger.NewLogger(defaults.Level, defaults.Format))
+	})
+	writeSettingsConfig(t, settingsTestConfig)
+
+	cmd := newStatsCmd()
+	if err := cmd.ParseFlags(nil); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	configureLogging(cmd)
+
+	// Later changes to the file are not seen: the config is resolved once
+	if err := os.WriteFile(configPath, []byte("[database]\npath = \"./changed.db\"\n"), 0644); err != nil {
+		t.Fatalf("failed to rewrite config: %v", err)
+	}
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Database.Path != "./file.db" {
+		t.Errorf("expected config loaded by configureLogging, got %s", cfg.Database.Path)
+	}
+
+	// A broken config keeps default logging and is reported by loadConfig
+	writeSettingsConfig(t, "[logging\n")
+	cmd = newStatsCmd()
+	if err := cmd.ParseFlags(nil); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	configureLogging(cmd)
+	if _, err := loadConfig(cmd); err == nil {
+		t.Error("expected the load error of configureLogging")
+	}
+}
+
+// TestLoadConfig_Workers tests the -1 auto shorthand and validation of flag values
+func TestLoadConfig_Workers(t *testing.T) {
+	writeSettingsConfig(t, "")
+
+	cmd := newImportCmd()
+	if err := cmd.ParseFlags([]string{"--workers", "-1"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Import.Workers != runtime.NumCPU() {
+		t.Errorf("expected auto workers %d, got %d", runtime.NumCPU(), cfg.Import.Workers)
+	}
+
+	cmd = newImportCmd()
+	if err := cmd.ParseFlags([]string{"--workers", "64"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	if _, err := loadConfig(cmd); err == nil || !strings.Contains(err.Error(), "import.workers") {
+		t.Errorf("expected workers validation error, got %v", err)
+	}
+}
+
+// TestLoadConfig_MissingExplicitConfig tests that an explicit --config must exist
+func TestLoadConfig_MissingExplicitConfig(t *testing.T) {
+	oldPath := configPath
+	defer func() { configPath = oldPath }()
+
+	cmd := newStatsCmd()
+	cmd.Flags().StringVar(&configPath, "config", "./config.toml", "")
+	if err := cmd.ParseFlags([]string{"--config", filepath.Join(t.TempDir(), "missing.toml")}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	if _, err := loadConfig(cmd); err == nil || !strings.Contains(err.Error(), "missing.toml") {
+		t.Errorf("expected error for missing config file, got %v", err)
+	}
+
+	// The default config file may be missing
+	cmd = newStatsCmd()
+	configPath = filepath.Join(t.TempDir(), "config.toml")
+	if err := cmd.ParseFlags(nil); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Database.Path != "./eve_sde.db" {
+		t.Errorf("expected default database path, got %s", cfg.Database.Path)
+	}
+}
+
+// TestImportTableSettings_UnknownTable testet, dass import unbekannte
+// Tabellen in [tables.<name>] ablehnt
+func TestImportTableSettings_UnknownTable(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Tables = map[string]config.TableConfig{"noSuchTable": {Skip: true}}
+	if _, err := importTableSettings(&cfg); err == nil || !strings.Contains(err.Error(), "tables.noSuchTable: unknown table") {
+		t.Errorf("expected unknown table error, got %v", err)
+	}
+}
+
+// TestImportTableSettings tests the conversion of [tables.<name>] and the
+// consistency check of parse settings across profiles
+func TestImportTableSettings(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Tables = map[string]config.TableConfig{
+		"mapMoons": {ErrorMode: "skip", MaxErrors: 3, BatchSize: 200},
+		"invTypes": {ErrorMode: "fail_fast", OnConflict: "ignore"},
+	}
+	settings, err := importTableSettings(&cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	moons := settings["mapMoons"]
+	if moons.ErrorMode == nil || *moons.ErrorMode != parser.ErrorModeSkip || moons.MaxErrors != 3 || moons.BatchSize != 200 {
+		t.Errorf("unexpected mapMoons settings: %+v", moons)
+	}
+	if types := settings["invTypes"]; types.ErrorMode != nil || types.OnConflict != database.ConflictIgnore {
+		t.Errorf("expected fail_fast as default parse mode and ignore conflicts, got %+v", types)
+	}
+
+	full := &importTarget{profile: "full", settings: settings}
+	slim := &importTarget{profile: "slim", settings: map[string]worker.TableSettings{"mapMoons": {Skip: true}}}
+	parse, err := profileParseSettings([]*importTarget{full, slim})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parse["mapMoons"].ErrorMode == nil || parse["mapMoons"].BatchSize != 0 {
+		t.Errorf("expected only parse settings for mapMoons, got %+v", parse["mapMoons"])
+	}
+
+	// slim imports mapMoons fail-fast while full skips malformed lines
+	slim.settings = map[string]worker.TableSettings{}
+	if _, err := profileParseSettings([]*importTarget{full, slim}); err == nil || !strings.Contains(err.Error(), "mapMoons") {
+		t.Errorf("expected conflicting error_mode error, got %v", err)
+	}
+}
+
+// TestImportTargets_VacuumInto tests that --all-profiles rejects profiles
+// writing their compacted copy to the same file
+func TestImportTargets_VacuumInto(t *testing.T) {
+	writeSettingsConfig(t, `
+[profiles.full.database]
+path = "./full.db"
+
+[profiles.full.import.finalize]
+vacuum_into = "./release/eve.db"
+
+[profiles.slim.database]
+path = "./slim.db"
+
+[profiles.slim.import.finalize]
+vacuum_into = "release/eve.db"
+`)
+	t.Cleanup(func() { allProfiles = false })
+
+	cmd := newImportCmd()
+	if err := cmd.ParseFlags([]string{"--all-profiles"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := importTargets(cmd, cfg); err == nil || !strings.Contains(err.Error(), "import.finalize.vacuum_into") {
+		t.Errorf("expected duplicate vacuum_into error, got %v", err)
+	}
+}
+
+// TestDatabaseOptions tests the conversion of [database] into database.Options
+func TestDatabaseOptions(t *testing.T) {
+	if opts := databaseOptions(config.DefaultConfig().Database); opts != database.DefaultOptions() {
+		t.Errorf("default config must match database.DefaultOptions: %+v", opts)
+	}
+
+	opts := databaseOptions(config.DatabaseConfig{
+		JournalMode:   "DELETE",
+		Synchronous:   "FULL",
+		CacheSizeMB:   32,
+		MmapSizeMB:    256,
+		BusyTimeoutMS: 10000,
+		PageSize:      8192,
+		ReadOnly:      true,
+	})
+	want := database.Options{
+		JournalMode: "DELETE",
+		Synchronous: "FULL",
+		CacheSizeMB: 32,
+		MmapSizeMB:  256,
+		BusyTimeout: 10 * time.Second,
+		PageSize:    8192,
+		ReadOnly:    true,
+	}
+	if opts != want {
+		t.Errorf("expected %+v, got %+v", want, opts)
+	}
+}
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"sort"
+	"time"
 
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/config"
 	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
 	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/logger"
 	"github.com/jmoiron/sqlx"
@@ -23,9 +27,11 @@ type TableStats struct {
 
 // DatabaseStats enthält Gesamtstatistiken der Datenbank
 type DatabaseStats struct {
-	Tables    []TableStats
-	DBSize    int64
-	TotalRows int64
+	Tables     []TableStats
+	DBSize     int64
+	TotalRows  int64
+	SDEBuild   int64                // SDE-Build-Nummer aus PRAGMA user_version (0 = unbekannt)
+	ImportRuns []database.ImportRun // Protokollierte Import-Läufe (neueste zuerst)
 }
 
 func newStatsCmd() *cobra.Command {
@@ -38,6 +44,9 @@ Folgende Informationen werden angezeigt:
   - Liste aller Tabellen in der Datenbank
   - Anzahl der Zeilen pro Tabelle
   - Gesamtgröße der Datenbankdatei
+  - SDE-Build-Nummer (PRAGMA user_version)
+  - Provenienz des letzten Imports (SDE-Version, Tool-Version, Host,
+    Flags, Datei-Checksummen, Laufzeit) aus der Tabelle _import_runs
 
 Die Statistiken helfen bei der Überwachung des Datenbank-Inhalts
 und können zur Diagnose von Import-Problemen verwendet werden.`,
@@ -53,7 +62,7 @@ und können zur Diagnose von Import-Problemen verwendet werden.`,
 	}
 
 	// Flags
-	cmd.Flags().StringVarP(&statsDBPath, "db", "d", "./eve-sde.db", "Pfad zur SQLite-Datenbank")
+	cmd.Flags().StringVarP(&statsDBPath, "db", "d", config.DefaultConfig().Database.Path, "Pfad zur SQLite-Datenbank (überschreibt database.path)")
 
 	return cmd
 }
@@ -61,10 +70,12 @@ und können zur Diagnose von Import-Problemen verwendet werden.`,
 func runStatsCmd(cmd *cobra.Command, args []string) error {
 	log := logger.GetGlobalLogger()
 
-	// Validate inputs
-	if statsDBPath == "" {
-		return fmt.Errorf("--db darf nicht leer sein")
+	// Effektiver Datenbank-Pfad: --db > ESDEDB_DATABASE_PATH > database.path
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
 	}
+	statsDBPath = cfg.Database.Path
 
 	// Check if database file exists
 	if _, err := os.Stat(statsDBPath); os.IsNotExist(err) {
@@ -75,8 +86,10 @@ func runStatsCmd(cmd *cobra.Command, args []string) error {
 		logger.Field{Key: "db_path", Value: statsDBPath},
 	)
 
-	// Open Database (read-only)
-	db, err := database.NewDB(statsDBPath)
+	// Open Database (read-only, übrige SQLite-Optionen aus [database])
+	dbOptions := databaseOptions(cfg.Database)
+	dbOptions.ReadOnly = true
+	db, err := database.NewDBWithOptions(statsDBPath, dbOptions)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -148,6 +161,18 @@ func collectStats(db *sqlx.DB) (*DatabaseStats, error) {
 		stats.TotalRows += count
 	}
 
+	// Import-Provenienz (leer bei Datenbanken ohne _import_runs)
+	runs, err := database.ListImportRuns(context.Background(), db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import runs: %w", err)
+	}
+	stats.ImportRuns = runs
+
+	// SDE-Build-Nummer (vom Import in user_version abgelegt)
+	if stats.SDEBuild, err = database.GetUserVersion(context.Background(), db); err != nil {
+		return nil, err
+	}
+
 	return stats, nil
 }
 
@@ -155,6 +180,9 @@ func displayStats(stats *DatabaseStats, dbPath string) {
 	fmt.Printf("\n=== Database Statistics ===\n")
 	fmt.Printf("Database: %s\n", dbPath)
 	fmt.Printf("Size:     %s\n", formatBytes(stats.DBSize))
+	if stats.SDEBuild > 0 {
+		fmt.Printf("SDE Build: %d\n", stats.SDEBuild)
+	}
 	fmt.Printf("\n")
 
 	if len(stats.Tables) == 0 {
@@ -172,6 +200,66 @@ func displayStats(stats *DatabaseStats, dbPath string) {
 		fmt.Printf("%-30s %15d\n", table.Name, table.RowCount)
 	}
 	fmt.Printf("\n")
+
+	displayImportRuns(stats.ImportRuns)
+}
+
+// displayImportRuns zeigt die Provenienz des letzten Import-Laufs an.
+func displayImportRuns(runs []database.ImportRun) {
+	if len(runs) == 0 {
+		return
+	}
+
+	run := runs[0]
+	fmt.Printf("=== Last Import Run (#%d of %d) ===\n", run.ID, len(runs))
+	fmt.Printf("SDE Version:  %s\n", valueOrUnknown(run.SDEVersion))
+	fmt.Printf("SDE Release:  %s\n", valueOrUnknown(run.SDEReleaseDate))
+	if run.SDEBuildNumber > 0 {
+		fmt.Printf("SDE Build:    %d\n", run.SDEBuildNumber)
+	}
+	fmt.Printf("Tool Version: %s (commit: %s)\n", valueOrUnknown(run.ToolVersion), valueOrUnknown(run.ToolCommit))
+	fmt.Printf("Hostname:     %s\n", valueOrUnknown(run.Hostname))
+	fmt.Printf("Started:      %s\n", run.StartedAt.Format(time.RFC3339))
+	fmt.Printf("Finished:     %s (%v)\n", run.FinishedAt.Format(time.RFC3339), run.FinishedAt.Sub(run.StartedAt).Round(time.Millisecond))
+
+	var totalRows int64
+	for _, count := range run.RowCounts {
+		totalRows += count
+	}
+	fmt.Printf("Rows:         %d in %d tables\n", totalRows, len(run.RowCounts))
+
+	if len(run.Flags) > 0 {
+		fmt.Printf("\nFlags:\n")
+		for _, name := range sortedKeys(run.Flags) {
+			fmt.Printf("  --%s=%s\n", name, run.Flags[name])
+		}
+	}
+
+	if len(run.FileChecksums) > 0 {
+		fmt.Printf("\nFile Checksums (SHA-256):\n")
+		for _, file := range sortedKeys(run.FileChecksums) {
+			fmt.Printf("  %-36s %s\n", file, run.FileChecksums[file])
+		}
+	}
+	fmt.Printf("\n")
+}
+
+// sortedKeys liefert die Schlüssel einer String-Map in sortierter Reihenfolge.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// valueOrUnknown ersetzt leere Werte durch "unknown".
+func valueOrUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
 }
 
 func formatBytes(bytes int64) string {
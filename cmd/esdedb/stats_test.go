@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
 	_ "github.com/mattn/go-sqlite3"
@@ -250,6 +252,43 @@ func TestCollectStats(t *testing.T) {
 	}
 }
 
+func TestCollectStats_ImportRuns(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "provenance.db")
+
+	db, err := database.NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	run := &database.ImportRun{
+		SDEVersion:  "3064089",
+		ToolVersion: "1.2.0",
+		StartedAt:   time.Now().Add(-time.Minute),
+		FinishedAt:  time.Now(),
+	}
+	if err := database.RecordImportRun(context.Background(), db, run); err != nil {
+		t.Fatalf("failed to record import run: %v", err)
+	}
+
+	oldPath := statsDBPath
+	statsDBPath = dbPath
+	defer func() { statsDBPath = oldPath }()
+
+	stats, err := collectStats(db)
+	if err != nil {
+		t.Fatalf("collectStats failed: %v", err)
+	}
+
+	if len(stats.ImportRuns) != 1 {
+		t.Fatalf("expected 1 import run, got %d", len(stats.ImportRuns))
+	}
+	if stats.ImportRuns[0].SDEVersion != "3064089" {
+		t.Errorf("expected SDE version 3064089, got %s", stats.ImportRuns[0].SDEVersion)
+	}
+}
+
 func TestFormatBytes(t *testing.T) {
 	tests := []struct {
 		bytes    int64
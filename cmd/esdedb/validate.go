@@ -16,12 +16,19 @@ func newValidateCmd() *cobra.Command {
 		Long: `Validate command prüft eine TOML-Konfigurationsdatei auf Gültigkeit.
 
 Folgende Aspekte werden validiert:
-  - TOML-Syntax (Datei muss gültiges TOML sein)
+  - TOML-Syntax und Typen (Datei muss gültiges TOML sein)
   - Erforderliche Felder (database.path, import.sde_path)
-  - Wertebereichsprüfungen (workers: 1-32, language: en/de/fr/ja/ru/zh/es/ko)
-  - Logik-Konsistenz (z.B. gültige Pfade, sinnvolle Werte)
+  - Wertebereichsprüfungen (workers: 0-32, language: en/de/fr/ja/ru/zh/es/ko)
+  - Logik-Konsistenz (z.B. Tabellenfilter, [tables.<name>], [export.csv])
+  - Unbekannte Schlüssel (Warnung, da sie beim Laden ignoriert werden)
 
-Bei erfolgreicher Validierung wird eine Zusammenfassung der Konfiguration angezeigt.`,
+Es werden alle Probleme gemeldet, nicht nur das erste – jeweils mit
+Datei, Zeile und Spalte des Schlüssels (bzw. der Environment Variable, aus
+der der Wert stammt). Mit --profile wird zusätzlich das Profil geprüft.
+
+Bei erfolgreicher Validierung wird eine Zusammenfassung der Konfiguration angezeigt.
+
+Ein JSON Schema für Editoren erzeugt "esdedb config schema".`,
 		Example: `  # Konfigurationsdatei validieren (Standard: ./config.toml)
   esdedb validate
 
@@ -46,14 +53,46 @@ func runValidateCmd(cmd *cobra.Command, args []string) error {
 		logger.Field{Key: "config_path", Value: configPath},
 	)
 
-	// Load and validate config
-	cfg, err := config.Load(configPath)
+	// Laden und alle Probleme sammeln
+	cfg, issues, err := config.Diagnose(configPath, profileName, registeredTables())
 	if err != nil {
+		cli.Error("Configuration validation failed: %v", err)
+		return err
+	}
+
+	var errs, warnings []config.Issue
+	for _, issue := range issues {
+		if issue.Severity == config.SeverityError {
+			errs = append(errs, issue)
+		} else {
+			warnings = append(warnings, issue)
+		}
+	}
+	for _, issue := range issues {
+		severity := cli.YellowText(string(issue.Severity))
+		if issue.Severity == config.SeverityError {
+			severity = cli.RedText(string(issue.Severity))
+		}
+		fmt.Printf("%s: %s: %s\n", issue.Location(configPath), severity, issue.Message)
+	}
+	if len(issues) > 0 {
+		fmt.Println()
+	}
+
+	if len(errs) > 0 {
+		err := &config.ValidationError{Issues: errs}
 		log.Error("Configuration validation failed",
 			logger.Field{Key: "config_path", Value: configPath},
+			logger.Field{Key: "errors", Value: len(errs)},
+			logger.Field{Key: "warnings", Value: len(warnings)},
 			logger.Field{Key: "error", Value: err.Error()},
 		)
-		cli.Error("Configuration validation failed: %v", err)
+		cli.Error("Configuration validation failed: %d error(s), %d warning(s)", len(errs), len(warnings))
+		return err
+	}
+
+	// Validate löst import.workers = 0 (Auto) auf
+	if err := cfg.Validate(); err != nil {
 		return err
 	}
 
@@ -64,9 +103,14 @@ func runValidateCmd(cmd *cobra.Command, args []string) error {
 		logger.Field{Key: "sde_path", Value: cfg.Import.SDEPath},
 		logger.Field{Key: "workers", Value: cfg.Import.Workers},
 		logger.Field{Key: "language", Value: cfg.Import.Language},
+		logger.Field{Key: "warnings", Value: len(warnings)},
 	)
 
-	cli.Success("Configuration is valid")
+	if len(warnings) > 0 {
+		cli.Warning("Configuration is valid with %d warning(s)", len(warnings))
+	} else {
+		cli.Success("Configuration is valid")
+	}
 	fmt.Printf("\nConfiguration Summary:\n")
 	fmt.Printf("  Database Path: %s\n", cfg.Database.Path)
 	fmt.Printf("  SDE Path:      %s\n", cfg.Import.SDEPath)
@@ -74,6 +118,9 @@ func runValidateCmd(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Language:      %s\n", cfg.Import.Language)
 	fmt.Printf("  Log Level:     %s\n", cfg.Logging.Level)
 	fmt.Printf("  Log Format:    %s\n", cfg.Logging.Format)
+	if cfg.Profile != "" {
+		fmt.Printf("  Profile:       %s\n", cfg.Profile)
+	}
 
 	return nil
 }
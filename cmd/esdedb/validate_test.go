@@ -1,10 +1,13 @@
 package main
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -165,6 +168,21 @@ enabled = false
 	}
 }
 
+// TestValidateCmd_UnknownTable testet, dass validate unbekannte Tabellen in
+// [tables.<name>] meldet
+func TestValidateCmd_UnknownTable(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "unknown-table.toml")
+	if err := os.WriteFile(configFile, []byte("[tables.noSuchTable]\nskip = true\n"), 0644); err != nil {
+		t.Fatalf("failed to create test config: %v", err)
+	}
+
+	configPath = configFile
+	cmd := newValidateCmd()
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "tables.noSuchTable: unknown table") {
+		t.Errorf("expected unknown table error, got %v", err)
+	}
+}
+
 func TestValidateCmd_NonExistentFile_UsesDefaults(t *testing.T) {
 	// Point to a non-existent file
 	configPath = "/tmp/does-not-exist-validate-test.toml"
@@ -283,3 +301,55 @@ enabled = false
 		t.Errorf("integration test failed: %v", err)
 	}
 }
+
+// TestValidateCmd_ReportsAllIssues testet, dass alle Fehler gemeldet werden
+func TestValidateCmd_ReportsAllIssues(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	content := `[database]
+path = ""
+
+[import]
+sde_path = "./sde"
+workers = 64
+language = "xx"
+unknown = true
+`
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test config: %v", err)
+	}
+
+	configPath = configFile
+	err := newValidateCmd().Execute()
+	var verr *config.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if len(verr.Issues) != 3 {
+		t.Errorf("expected 3 errors, got %+v", verr.Issues)
+	}
+	for i, key := range []string{"database.path", "import.workers", "import.language"} {
+		if i < len(verr.Issues) && verr.Issues[i].Key != key {
+			t.Errorf("issue %d: expected key %s, got %s", i, key, verr.Issues[i].Key)
+		}
+	}
+}
+
+// TestValidateCmd_WarningsOnly testet, dass unbekannte Schlüssel nur warnen
+func TestValidateCmd_WarningsOnly(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	content := `[database]
+path = "./test.db"
+journal = "WAL"
+
+[import]
+sde_path = "./sde"
+`
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test config: %v", err)
+	}
+
+	configPath = configFile
+	if err := newValidateCmd().Execute(); err != nil {
+		t.Errorf("expected warnings only, got error: %v", err)
+	}
+}
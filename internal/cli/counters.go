@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/worker"
+)
+
+// importCounters sammelt die Gesamt-Zähler eines Imports aus Observer-Events
+// und berechnet daraus Fortschritt, Durchsatz und ETA.
+//
+// Parse- und Insert-Fortschritt werden getrennt gezählt: in der Parse-Phase
+// steigen die geparsten Zeilen (aus OnWorkerActivity) und Bytes (aus
+// OnFileParsed), in der Insert-Phase die eingefügten. Durchsatz und ETA
+// beziehen sich auf die aktuelle Phase.
+//
+// Wird von ProgressBar, LiveDisplay und JSONProgress gemeinsam verwendet. Thread-Safe.
+type importCounters struct {
+	mu             sync.Mutex
+	started        time.Time
+	phase          worker.Phase
+	phaseStarted   time.Time
+	totalFiles     int64
+	doneFiles      int64
+	failedFiles    int64
+	insertedRows   int64
+	parsedRows     int64
+	parsedBytes    int64
+	totalRows      int64
+	totalBytes     int64
+	processedBytes int64
+	fileBytes      map[string]int64 // Dateigröße je Datei aus dem Vorab-Scan
+	jobRows        map[string]int   // Zuletzt gemeldete Zeilen je Parse-Job (Datei#Chunk)
+}
+
+// start setzt alle Zähler für einen neuen Import zurück.
+func (c *importCounters) start(files int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.started = time.Now()
+	c.phase, c.phaseStarted = 0, c.started
+	c.totalFiles = int64(files)
+	c.doneFiles, c.failedFiles, c.insertedRows = 0, 0, 0
+	c.parsedRows, c.parsedBytes = 0, 0
+	c.totalRows, c.totalBytes, c.processedBytes = 0, 0, 0
+	c.fileBytes = nil
+	c.jobRows = nil
+}
+
+// phaseChange merkt sich die neue Phase; Durchsatz und ETA werden ab jetzt
+// auf die Laufzeit dieser Phase bezogen.
+func (c *importCounters) phaseChange(phase worker.Phase) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.phase = phase
+	c.phaseStarted = time.Now()
+}
+
+// currentPhase gibt die aktuelle Phase zurück (0 vor der ersten Phase).
+func (c *importCounters) currentPhase() worker.Phase {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.phase
+}
+
+// activity verbucht den Zwischenstand eines Parse-Jobs. Gemeldet wird die
+// bisherige Zeilenzahl des Jobs; ein erneuter Versuch (Retry) desselben Jobs
+// ersetzt daher den vorherigen Stand, statt ihn zu addieren.
+func (c *importCounters) activity(a worker.WorkerActivity) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.jobRows == nil {
+		c.jobRows = make(map[string]int)
+	}
+	key := fmt.Sprintf("%s#%d", a.File, a.Chunk)
+	c.parsedRows += int64(a.Rows - c.jobRows[key])
+	c.jobRows[key] = a.Rows
+}
+
+// fileParsed verbucht die Größe einer vollständig geparsten Datei.
+func (c *importCounters) fileParsed(file string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.parsedBytes += c.fileBytes[file]
+}
+
+// scan übernimmt Zeilen- und Byte-Gesamtwerte aus dem Vorab-Scan.
+func (c *importCounters) scan(scan worker.ScanResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.totalRows = scan.TotalLines
+	c.totalBytes = scan.TotalBytes
+	c.fileBytes = make(map[string]int64, len(scan.Files))
+	for file, fs := range scan.Files {
+		c.fileBytes[file] = fs.Bytes
+	}
+}
+
+// fileDone verbucht eine abgeschlossene Datei und gibt den neuen Stand zurück.
+func (c *importCounters) fileDone(file string, rows int64, failed bool) worker.Progress {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.doneFiles++
+	c.insertedRows += rows
+	c.processedBytes += c.fileBytes[file]
+	if failed {
+		c.failedFiles++
+	}
+	return c.snapshotLocked()
+}
+
+// snapshot gibt den aktuellen Stand zurück.
+func (c *importCounters) snapshot() worker.Progress {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.snapshotLocked()
+}
+
+// snapshotLocked berechnet Durchsatz und ETA aus den Zählern.
+//
+// Durchsatz und ETA beziehen sich auf die aktuelle Phase: in der Parse-Phase
+// auf die geparsten, sonst auf die eingefügten Zeilen, jeweils ab Beginn der
+// Phase. Die ETA wird aus den Zeilen berechnet, wenn der Vorab-Scan
+// Gesamtwerte geliefert hat, sonst aus den Bytes bzw. zuletzt aus den Dateien.
+// Muss mit gehaltenem c.mu aufgerufen werden.
+func (c *importCounters) snapshotLocked() worker.Progress {
+	progress := worker.Progress{
+		ParsedFiles:    c.doneFiles,
+		InsertedFiles:  c.doneFiles - c.failedFiles,
+		FailedFiles:    c.failedFiles,
+		TotalFiles:     c.totalFiles,
+		TotalRows:      c.totalRows,
+		InsertedRows:   c.insertedRows,
+		ParsedRows:     c.parsedRows,
+		TotalBytes:     c.totalBytes,
+		ProcessedBytes: c.processedBytes,
+		ParsedBytes:    c.parsedBytes,
+		ElapsedTime:    time.Since(c.started),
+	}
+	if c.totalFiles > 0 {
+		progress.PercentFiles = float64(c.doneFiles) / float64(c.totalFiles) * 100
+	}
+	if c.totalRows > 0 {
+		progress.PercentRows = float64(c.insertedRows) / float64(c.totalRows) * 100
+	}
+	if c.totalBytes > 0 {
+		progress.PercentBytes = float64(c.processedBytes) / float64(c.totalBytes) * 100
+	}
+
+	// Zähler der aktuellen Phase
+	rows, bytes, files := c.insertedRows, c.processedBytes, c.doneFiles
+	if c.phase == worker.PhaseParse {
+		rows, bytes, files = c.parsedRows, c.parsedBytes, 0
+	}
+	elapsed := time.Since(c.phaseStarted)
+	if elapsed > 0 {
+		progress.RowsPerSecond = float64(rows) / elapsed.Seconds()
+	}
+
+	switch {
+	case c.totalRows > rows && rows > 0:
+		progress.ETA = remaining(elapsed, rows, c.totalRows)
+	case c.totalBytes > bytes && bytes > 0:
+		progress.ETA = remaining(elapsed, bytes, c.totalBytes)
+	case files > 0 && c.totalFiles > files:
+		progress.ETA = remaining(elapsed, files, c.totalFiles)
+	}
+	return progress
+}
+
+// phaseDone liefert die Zeilen und Bytes, die eine Anzeige in phase als
+// erledigt ausweist: in der Parse-Phase die geparsten, sonst die
+// eingefügten Zeilen bzw. die Bytes abgeschlossener Dateien.
+func phaseDone(progress worker.Progress, phase worker.Phase) (rows, bytes int64) {
+	if phase == worker.PhaseParse {
+		return progress.ParsedRows, progress.ParsedBytes
+	}
+	return progress.InsertedRows, progress.ProcessedBytes
+}
+
+// remaining schätzt die Restdauer bei linearem Fortschritt von done auf total.
+func remaining(elapsed time.Duration, done, total int64) time.Duration {
+	return time.Duration(float64(elapsed) * float64(total-done) / float64(done))
+}
@@ -0,0 +1,252 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/worker"
+)
+
+// Event-Typen der JSON-Fortschrittsausgabe
+const (
+	EventStart      = "start"       // Import gestartet (files_total gesetzt)
+	EventScan       = "scan"        // Vorab-Scan abgeschlossen (rows_total/bytes_total gesetzt)
+	EventPhase      = "phase"       // Phasenwechsel
+	EventProgress   = "progress"    // Periodischer Fortschritt
+	EventFileFailed = "file_failed" // Datei fehlgeschlagen (error gesetzt)
+	EventDone       = "done"        // Import beendet (error gesetzt bei Fehler/Abbruch)
+)
+
+// ProgressEvent ist ein Fortschritts-Ereignis der JSON-Ausgabe.
+//
+// Jedes Ereignis wird als eine JSON-Zeile (NDJSON) geschrieben und enthält
+// immer den vollständigen Gesamtstand, damit Konsumenten einzelne Zeilen
+// unabhängig voneinander auswerten können.
+type ProgressEvent struct {
+	Time           time.Time `json:"time"`
+	Event          string    `json:"event"`
+	Phase          string    `json:"phase,omitempty"`
+	File           string    `json:"file,omitempty"`
+	FilesDone      int64     `json:"files_done"`
+	FilesFailed    int64     `json:"files_failed"`
+	FilesTotal     int64     `json:"files_total"`
+	Rows           int64     `json:"rows"`
+	RowsParsed     int64     `json:"rows_parsed"`
+	RowsTotal      int64     `json:"rows_total,omitempty"`
+	Bytes          int64     `json:"bytes,omitempty"`
+	BytesParsed    int64     `json:"bytes_parsed,omitempty"`
+	BytesTotal     int64     `json:"bytes_total,omitempty"`
+	RowsPerSecond  float64   `json:"rows_per_sec"`
+	ETASeconds     float64   `json:"eta_seconds,omitempty"`
+	ElapsedSeconds float64   `json:"elapsed_seconds"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// JSONProgress schreibt den Import-Fortschritt als NDJSON-Ereignisse.
+//
+// JSONProgress implementiert worker.ImportObserver und ist für nicht
+// interaktive Umgebungen (CI, systemd, Wrapper-Skripte, Dashboards) gedacht.
+// Start, Scan, Phasenwechsel, fehlgeschlagene Dateien und das Ende werden
+// sofort gemeldet; dazwischen wird alle Interval ein "progress"-Ereignis mit
+// der aktuellen Datei geschrieben. Bei Interval <= 0 wird stattdessen nach
+// jeder abgeschlossenen Datei ein "progress"-Ereignis geschrieben.
+//
+// Beispiel:
+//
+//	jp := cli.NewJSONProgress(cli.JSONProgressConfig{Interval: 2 * time.Second})
+//	orch := worker.NewOrchestrator(db, pool, parsers, worker.WithObserver(jp))
+//	_, err := orch.ImportAll(ctx, sdeDir)
+//	jp.Finish()
+type JSONProgress struct {
+	worker.NopObserver
+
+	interval time.Duration
+	counters importCounters
+
+	mu      sync.Mutex // schützt Zustand und Encoder
+	encoder *json.Encoder
+	phase   worker.Phase
+	file    string // zuletzt aktive Datei
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+var _ worker.ImportObserver = (*JSONProgress)(nil)
+
+// JSONProgressConfig enthält Konfigurationsoptionen für JSONProgress.
+type JSONProgressConfig struct {
+	// Interval ist der Abstand der "progress"-Ereignisse (<= 0: pro Datei)
+	Interval time.Duration
+	// Output definiert, wohin die Ereignisse geschrieben werden (default: os.Stderr)
+	Output io.Writer
+}
+
+// NewJSONProgress erstellt eine neue JSONProgress.
+func NewJSONProgress(config JSONProgressConfig) *JSONProgress {
+	if config.Output == nil {
+		config.Output = os.Stderr
+	}
+	return &JSONProgress{
+		interval: config.Interval,
+		encoder:  json.NewEncoder(config.Output),
+	}
+}
+
+// OnImportStart setzt die Zähler zurück, meldet den Start und startet die
+// periodischen Ereignisse.
+func (j *JSONProgress) OnImportStart(sdeDir string, files []string) {
+	j.counters.start(len(files))
+
+	j.mu.Lock()
+	j.phase, j.file = 0, ""
+	j.mu.Unlock()
+
+	j.emit(EventStart, "", nil)
+	if j.interval > 0 {
+		j.startTicker()
+	}
+}
+
+// OnScanComplete übernimmt Zeilen- und Byte-Gesamtwerte aus dem Vorab-Scan.
+func (j *JSONProgress) OnScanComplete(scan worker.ScanResult) {
+	j.counters.scan(scan)
+	j.emit(EventScan, "", nil)
+}
+
+// OnPhaseChange meldet den Phasenwechsel.
+func (j *JSONProgress) OnPhaseChange(phase worker.Phase) {
+	j.counters.phaseChange(phase)
+
+	j.mu.Lock()
+	j.phase = phase
+	j.mu.Unlock()
+
+	j.emit(EventPhase, "", nil)
+}
+
+// OnWorkerActivity verbucht die geparsten Zeilen und merkt sich die zuletzt
+// gestartete Datei der Parse-Phase.
+func (j *JSONProgress) OnWorkerActivity(activity worker.WorkerActivity) {
+	j.counters.activity(activity)
+	if activity.Done {
+		return
+	}
+	j.setFile(activity.File)
+}
+
+// OnFileParsed verbucht die Größe der geparsten Datei.
+func (j *JSONProgress) OnFileParsed(file string, rows int, duration time.Duration) {
+	j.counters.fileParsed(file)
+}
+
+// OnInsertProgress merkt sich die aktuell eingefügte Datei.
+func (j *JSONProgress) OnInsertProgress(progress worker.InsertProgress) {
+	j.setFile(progress.File)
+}
+
+// OnFileInserted verbucht die eingefügte Datei.
+func (j *JSONProgress) OnFileInserted(file string, rows int, duration time.Duration) {
+	j.counters.fileDone(file, int64(rows), false)
+	if j.interval <= 0 {
+		j.emit(EventProgress, file, nil)
+	}
+}
+
+// OnFileFailed verbucht die fehlgeschlagene Datei und meldet den Fehler.
+func (j *JSONProgress) OnFileFailed(file string, err error) {
+	j.counters.fileDone(file, 0, true)
+	j.emit(EventFileFailed, file, err)
+}
+
+// OnImportDone stoppt die periodischen Ereignisse und meldet das Ende.
+func (j *JSONProgress) OnImportDone(progress worker.Progress, err error) {
+	j.Finish()
+	j.emit(EventDone, "", err)
+}
+
+// Finish stoppt die periodischen Ereignisse. Mehrfache Aufrufe sind erlaubt.
+func (j *JSONProgress) Finish() {
+	j.mu.Lock()
+	stop, done := j.stop, j.done
+	j.stop, j.done = nil, nil
+	j.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+// startTicker startet die periodischen "progress"-Ereignisse.
+func (j *JSONProgress) startTicker() {
+	j.Finish()
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	j.mu.Lock()
+	j.stop, j.done = stop, done
+	j.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				j.emit(EventProgress, "", nil)
+			}
+		}
+	}()
+}
+
+// setFile merkt sich die aktuelle Datei.
+func (j *JSONProgress) setFile(file string) {
+	j.mu.Lock()
+	j.file = file
+	j.mu.Unlock()
+}
+
+// emit schreibt ein Ereignis mit dem aktuellen Gesamtstand.
+// Ist file leer, wird die zuletzt aktive Datei verwendet.
+func (j *JSONProgress) emit(event, file string, err error) {
+	progress := j.counters.snapshot()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if file == "" {
+		file = j.file
+	}
+	e := ProgressEvent{
+		Time:           time.Now().UTC(),
+		Event:          event,
+		File:           file,
+		FilesDone:      progress.ParsedFiles,
+		FilesFailed:    progress.FailedFiles,
+		FilesTotal:     progress.TotalFiles,
+		Rows:           progress.InsertedRows,
+		RowsParsed:     progress.ParsedRows,
+		RowsTotal:      progress.TotalRows,
+		Bytes:          progress.ProcessedBytes,
+		BytesParsed:    progress.ParsedBytes,
+		BytesTotal:     progress.TotalBytes,
+		RowsPerSecond:  progress.RowsPerSecond,
+		ETASeconds:     progress.ETA.Seconds(),
+		ElapsedSeconds: progress.ElapsedTime.Seconds(),
+	}
+	if j.phase != 0 {
+		e.Phase = j.phase.String()
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	_ = j.encoder.Encode(e)
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/worker"
+)
+
+// decodeEvents liest alle NDJSON-Ereignisse aus dem Buffer
+func decodeEvents(t *testing.T, out string) []ProgressEvent {
+	t.Helper()
+	var events []ProgressEvent
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var e ProgressEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", line, err)
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+// TestJSONProgress_Events testet die Ereignisse bei Ausgabe pro Datei
+func TestJSONProgress_Events(t *testing.T) {
+	var buf bytes.Buffer
+	jp := NewJSONProgress(JSONProgressConfig{Output: &buf})
+
+	jp.OnImportStart("/sde", []string{"a.jsonl", "b.jsonl"})
+	jp.OnScanComplete(worker.ScanResult{TotalLines: 30, TotalBytes: 300})
+	jp.OnPhaseChange(worker.PhaseInsert)
+	jp.OnInsertProgress(worker.InsertProgress{File: "/sde/a.jsonl", Table: "a", Rows: 20, TotalRows: 20, Batch: 1, Batches: 1})
+	jp.OnFileInserted("/sde/a.jsonl", 20, time.Millisecond)
+	jp.OnFileFailed("/sde/b.jsonl", errors.New("boom"))
+	jp.OnImportDone(worker.Progress{}, nil)
+
+	events := decodeEvents(t, buf.String())
+	want := []string{EventStart, EventScan, EventPhase, EventProgress, EventFileFailed, EventDone}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d: %s", len(want), len(events), buf.String())
+	}
+	for i, e := range events {
+		if e.Event != want[i] {
+			t.Errorf("event %d: expected %q, got %q", i, want[i], e.Event)
+		}
+	}
+
+	if events[0].FilesTotal != 2 || events[1].RowsTotal != 30 || events[1].BytesTotal != 300 {
+		t.Errorf("unexpected totals: %+v %+v", events[0], events[1])
+	}
+	if events[2].Phase != "insert" {
+		t.Errorf("expected phase insert, got %q", events[2].Phase)
+	}
+	if p := events[3]; p.File != "/sde/a.jsonl" || p.Rows != 20 || p.FilesDone != 1 {
+		t.Errorf("unexpected progress event: %+v", p)
+	}
+	if f := events[4]; f.File != "/sde/b.jsonl" || f.Error != "boom" || f.FilesFailed != 1 {
+		t.Errorf("unexpected file_failed event: %+v", f)
+	}
+	if d := events[5]; d.FilesDone != 2 || d.Error != "" {
+		t.Errorf("unexpected done event: %+v", d)
+	}
+}
+
+// TestJSONProgress_Interval testet die periodischen "progress"-Ereignisse
+func TestJSONProgress_Interval(t *testing.T) {
+	var buf bytes.Buffer
+	sw := newSyncedWriter(&buf)
+	jp := NewJSONProgress(JSONProgressConfig{Output: sw, Interval: 10 * time.Millisecond})
+
+	jp.OnImportStart("/sde", []string{"a.jsonl"})
+	jp.OnPhaseChange(worker.PhaseParse)
+	jp.OnWorkerActivity(worker.WorkerActivity{WorkerID: 0, File: "/sde/a.jsonl", Chunk: -1, Started: time.Now()})
+	time.Sleep(50 * time.Millisecond)
+	jp.OnFileInserted("/sde/a.jsonl", 5, time.Millisecond)
+	jp.OnImportDone(worker.Progress{}, errors.New("cancelled"))
+	jp.Finish() // idempotent
+
+	sw.mu.Lock()
+	events := decodeEvents(t, buf.String())
+	sw.mu.Unlock()
+
+	progress := 0
+	for _, e := range events {
+		if e.Event == EventProgress {
+			progress++
+			if e.File != "/sde/a.jsonl" || e.Phase != "parse" {
+				t.Errorf("unexpected progress event: %+v", e)
+			}
+		}
+	}
+	if progress == 0 {
+		t.Error("expected periodic progress events")
+	}
+	last := events[len(events)-1]
+	if last.Event != EventDone || last.Error != "cancelled" {
+		t.Errorf("expected done event with error, got %+v", last)
+	}
+}
+
+// TestParseProgressMode testet das Parsen der Modus-Namen
+func TestParseProgressMode(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want ProgressMode
+	}{
+		{"auto", ProgressModeAuto},
+		{"BAR", ProgressModeBar},
+		{" log ", ProgressModeLog},
+		{"json", ProgressModeJSON},
+	} {
+		got, err := ParseProgressMode(tc.in)
+		if err != nil || got != tc.want {
+			t.Errorf("ParseProgressMode(%q) = %q, %v; want %q", tc.in, got, err, tc.want)
+		}
+	}
+
+	if _, err := ParseProgressMode("fancy"); err == nil || !strings.Contains(err.Error(), "auto, bar, log, json") {
+		t.Errorf("expected error listing valid modes, got %v", err)
+	}
+}
+
+// TestNewImportDisplay testet die Auswahl der Ausgabe je Modus
+func TestNewImportDisplay(t *testing.T) {
+	var buf bytes.Buffer
+
+	display, err := NewImportDisplay(ImportDisplayConfig{Mode: ProgressModeBar, Output: &buf})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if live, ok := display.(*LiveDisplay); !ok || !live.interactive {
+		t.Errorf("expected interactive LiveDisplay for bar, got %T", display)
+	}
+
+	display, _ = NewImportDisplay(ImportDisplayConfig{Mode: ProgressModeLog, Output: &buf})
+	if live, ok := display.(*LiveDisplay); !ok || live.interactive {
+		t.Errorf("expected plain LiveDisplay for log, got %T", display)
+	}
+
+	display, _ = NewImportDisplay(ImportDisplayConfig{Mode: ProgressModeAuto, Output: &buf})
+	if live, ok := display.(*LiveDisplay); !ok || live.interactive {
+		t.Errorf("expected plain LiveDisplay for auto without terminal, got %T", display)
+	}
+
+	display, _ = NewImportDisplay(ImportDisplayConfig{Mode: ProgressModeJSON, JSONOutput: &buf})
+	if _, ok := display.(*JSONProgress); !ok {
+		t.Errorf("expected JSONProgress for json, got %T", display)
+	}
+
+	if _, err := NewImportDisplay(ImportDisplayConfig{Mode: "fancy"}); err == nil {
+		t.Error("expected error for invalid mode")
+	}
+}
@@ -0,0 +1,382 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/worker"
+	"github.com/mattn/go-isatty"
+)
+
+// ANSI-Steuersequenzen für die mehrzeilige Anzeige
+const (
+	ansiCursorUp = "\033[%dA"
+	ansiClearEOL = "\033[2K"
+)
+
+// LiveDisplay zeigt den Import als mehrzeilige Live-Anzeige an.
+//
+// LiveDisplay implementiert worker.ImportObserver und zeigt:
+//   - eine Zeile pro Worker mit Datei, bisher geparsten Zeilen und Laufzeit
+//   - eine Insert-Zeile mit Tabelle und Batch-Fortschritt
+//   - einen Gesamt-Fortschrittsbalken mit Zeilen, Bytes und ETA
+//
+// Auf einem Terminal wird die Anzeige alle RefreshRate an derselben Stelle
+// neu gezeichnet. Ist die Ausgabe kein Terminal (Datei, Pipe, CI-Log), wird
+// stattdessen pro Ereignis (Phase, Datei geparst/eingefügt/fehlgeschlagen)
+// eine einfache Textzeile ohne Steuerzeichen geschrieben.
+//
+// Beispiel:
+//
+//	live := cli.NewLiveDisplay(cli.LiveDisplayConfig{Workers: 8})
+//	orch := worker.NewOrchestrator(db, pool, parsers, worker.WithObserver(live))
+//	_, err := orch.ImportAll(ctx, sdeDir)
+//	live.Finish()
+type LiveDisplay struct {
+	worker.NopObserver
+
+	output      io.Writer
+	interactive bool
+	refreshRate time.Duration
+	barWidth    int
+
+	counters importCounters
+
+	mu       sync.Mutex // schützt die Anzeige-Zustände und das Schreiben
+	phase    worker.Phase
+	workers  map[int]worker.WorkerActivity
+	nworkers int
+	insert   *worker.InsertProgress
+	rendered int // Anzahl zuletzt gezeichneter Zeilen (Terminal-Modus)
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+var _ worker.ImportObserver = (*LiveDisplay)(nil)
+
+// LiveDisplayConfig enthält Konfigurationsoptionen für die LiveDisplay.
+type LiveDisplayConfig struct {
+	// Workers ist die Anzahl der Worker-Zeilen (0 = nur aktive Worker anzeigen)
+	Workers int
+	// RefreshRate ist das Neuzeichnen-Intervall im Terminal-Modus (default: 200ms)
+	RefreshRate time.Duration
+	// BarWidth ist die Breite des Gesamt-Balkens (default: 30)
+	BarWidth int
+	// Output definiert, wohin die Anzeige geschrieben wird (default: os.Stdout)
+	Output io.Writer
+	// Plain erzwingt die zeilenweise Ausgabe auch auf einem Terminal
+	Plain bool
+	// Interactive erzwingt den Terminal-Modus auch ohne erkanntes Terminal
+	// (wird von Plain überstimmt)
+	Interactive bool
+}
+
+// NewLiveDisplay erstellt eine neue LiveDisplay.
+//
+// Der Terminal-Modus wird verwendet, wenn Output ein Terminal ist (oder
+// Interactive gesetzt ist) und Plain nicht gesetzt ist; sonst wird
+// zeilenweise ausgegeben.
+func NewLiveDisplay(config LiveDisplayConfig) *LiveDisplay {
+	if config.RefreshRate == 0 {
+		config.RefreshRate = 200 * time.Millisecond
+	}
+	if config.BarWidth == 0 {
+		config.BarWidth = 30
+	}
+	if config.Output == nil {
+		config.Output = os.Stdout
+	}
+
+	return &LiveDisplay{
+		output:      config.Output,
+		interactive: !config.Plain && (config.Interactive || isTerminal(config.Output)),
+		refreshRate: config.RefreshRate,
+		barWidth:    config.BarWidth,
+		workers:     make(map[int]worker.WorkerActivity),
+		nworkers:    config.Workers,
+	}
+}
+
+// isTerminal prüft, ob w ein Terminal ist.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// OnImportStart setzt die Zähler zurück und startet im Terminal-Modus das
+// periodische Neuzeichnen.
+func (d *LiveDisplay) OnImportStart(sdeDir string, files []string) {
+	d.counters.start(len(files))
+
+	d.mu.Lock()
+	d.workers = make(map[int]worker.WorkerActivity)
+	d.insert = nil
+	d.rendered = 0
+	d.mu.Unlock()
+
+	if !d.interactive {
+		d.printf("Importing %d files from %s\n", len(files), sdeDir)
+		return
+	}
+	d.startRefresh()
+}
+
+// OnScanComplete übernimmt Zeilen- und Byte-Gesamtwerte aus dem Vorab-Scan.
+func (d *LiveDisplay) OnScanComplete(scan worker.ScanResult) {
+	d.counters.scan(scan)
+	if !d.interactive {
+		d.printf("Scanned %s rows, %s in %v\n",
+			formatCount(scan.TotalLines), formatBytes(scan.TotalBytes), scan.Duration.Round(time.Millisecond))
+	}
+}
+
+// OnPhaseChange merkt sich die aktuelle Phase.
+func (d *LiveDisplay) OnPhaseChange(phase worker.Phase) {
+	d.counters.phaseChange(phase)
+
+	d.mu.Lock()
+	d.phase = phase
+	if phase != worker.PhaseParse {
+		d.workers = make(map[int]worker.WorkerActivity)
+	}
+	d.mu.Unlock()
+
+	if !d.interactive {
+		d.printf("Phase: %s\n", phase)
+	}
+}
+
+// OnWorkerActivity verbucht die geparsten Zeilen und aktualisiert die
+// Zeile des Workers.
+func (d *LiveDisplay) OnWorkerActivity(activity worker.WorkerActivity) {
+	d.counters.activity(activity)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if activity.Done {
+		delete(d.workers, activity.WorkerID)
+		return
+	}
+	d.workers[activity.WorkerID] = activity
+}
+
+// OnFileParsed verbucht die geparste Datei und meldet sie im Zeilen-Modus.
+func (d *LiveDisplay) OnFileParsed(file string, rows int, duration time.Duration) {
+	d.counters.fileParsed(file)
+	if !d.interactive {
+		d.printf("parsed   %-36s %10d rows %10v\n", filepath.Base(file), rows, duration.Round(time.Millisecond))
+	}
+}
+
+// OnInsertProgress aktualisiert die Insert-Zeile.
+func (d *LiveDisplay) OnInsertProgress(progress worker.InsertProgress) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.insert = &progress
+}
+
+// OnFileInserted verbucht die eingefügte Datei.
+func (d *LiveDisplay) OnFileInserted(file string, rows int, duration time.Duration) {
+	progress := d.counters.fileDone(file, int64(rows), false)
+	d.clearInsert()
+	if !d.interactive {
+		d.printf("inserted %-36s %10d rows %10v  [%d/%d files]\n",
+			filepath.Base(file), rows, duration.Round(time.Millisecond), progress.ParsedFiles, progress.TotalFiles)
+	}
+}
+
+// OnFileFailed verbucht die fehlgeschlagene Datei.
+func (d *LiveDisplay) OnFileFailed(file string, err error) {
+	progress := d.counters.fileDone(file, 0, true)
+	d.clearInsert()
+	if !d.interactive {
+		d.printf("FAILED   %-36s %v  [%d/%d files]\n", filepath.Base(file), err, progress.ParsedFiles, progress.TotalFiles)
+	}
+}
+
+// OnImportDone beendet die Anzeige.
+func (d *LiveDisplay) OnImportDone(progress worker.Progress, err error) {
+	d.Finish()
+}
+
+// Finish stoppt das Neuzeichnen und zeichnet den finalen Stand.
+// Mehrfache Aufrufe sind erlaubt.
+func (d *LiveDisplay) Finish() {
+	d.mu.Lock()
+	stop, done := d.stop, d.done
+	d.stop, d.done = nil, nil
+	d.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+	d.redraw()
+}
+
+// startRefresh startet das periodische Neuzeichnen (Terminal-Modus).
+func (d *LiveDisplay) startRefresh() {
+	d.Finish()
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	d.mu.Lock()
+	d.stop, d.done = stop, done
+	d.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(d.refreshRate)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				d.redraw()
+			}
+		}
+	}()
+}
+
+// redraw zeichnet alle Zeilen an derselben Stelle neu.
+func (d *LiveDisplay) redraw() {
+	lines := d.lines()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var sb strings.Builder
+	if d.rendered > 0 {
+		fmt.Fprintf(&sb, ansiCursorUp, d.rendered)
+	}
+	for _, line := range lines {
+		sb.WriteString("\r" + ansiClearEOL + line + "\n")
+	}
+	// Übrige Zeilen einer längeren vorherigen Anzeige löschen
+	for i := len(lines); i < d.rendered; i++ {
+		sb.WriteString("\r" + ansiClearEOL + "\n")
+	}
+	if extra := d.rendered - len(lines); extra > 0 {
+		fmt.Fprintf(&sb, ansiCursorUp, extra)
+	}
+	d.rendered = len(lines)
+
+	_, _ = io.WriteString(d.output, sb.String())
+}
+
+// lines erzeugt die Zeilen der Anzeige (ohne Steuerzeichen).
+func (d *LiveDisplay) lines() []string {
+	progress := d.counters.snapshot()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var lines []string
+	now := time.Now()
+
+	// Worker-Zeilen
+	ids := make([]int, 0, len(d.workers))
+	for id := range d.workers {
+		ids = append(ids, id)
+	}
+	for id := 0; id < d.nworkers; id++ {
+		if _, ok := d.workers[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	if d.phase == worker.PhaseParse {
+		for _, id := range ids {
+			a, ok := d.workers[id]
+			if !ok {
+				lines = append(lines, fmt.Sprintf("worker %-3d idle", id))
+				continue
+			}
+			name := filepath.Base(a.File)
+			if a.Chunk >= 0 {
+				name = fmt.Sprintf("%s #%d", name, a.Chunk)
+			}
+			lines = append(lines, fmt.Sprintf("worker %-3d %-36s %10s rows %8s",
+				id, name, formatCount(int64(a.Rows)), formatDuration(now.Sub(a.Started))))
+		}
+	}
+
+	// Insert-Zeile
+	if d.phase == worker.PhaseInsert {
+		if d.insert != nil {
+			lines = append(lines, fmt.Sprintf("insert     %-36s batch %d/%d (%s/%s rows)",
+				d.insert.Table, d.insert.Batch, d.insert.Batches,
+				formatCount(int64(d.insert.Rows)), formatCount(int64(d.insert.TotalRows))))
+		} else {
+			lines = append(lines, "insert     waiting")
+		}
+	}
+
+	lines = append(lines, d.overallLine(progress))
+	return lines
+}
+
+// overallLine erzeugt die Zeile mit dem Gesamt-Balken. Zeilen, Bytes und
+// Balken zeigen in der Parse-Phase den Parse-, sonst den Insert-Fortschritt.
+// Muss mit gehaltenem d.mu aufgerufen werden.
+func (d *LiveDisplay) overallLine(progress worker.Progress) string {
+	rows, bytes := phaseDone(progress, d.phase)
+	percent := progress.PercentFiles
+	if progress.TotalBytes > 0 {
+		percent = float64(bytes) / float64(progress.TotalBytes) * 100
+	}
+	filled := int(percent / 100 * float64(d.barWidth))
+	if filled > d.barWidth {
+		filled = d.barWidth
+	}
+	bar := strings.Repeat("=", filled)
+	if filled < d.barWidth {
+		bar += ">" + strings.Repeat(" ", d.barWidth-filled-1)
+	}
+
+	line := fmt.Sprintf("[%s] %d/%d files", bar, progress.ParsedFiles, progress.TotalFiles)
+	if progress.FailedFiles > 0 {
+		line += fmt.Sprintf(" (%d failed)", progress.FailedFiles)
+	}
+	if progress.TotalRows > 0 {
+		line += fmt.Sprintf("  %s/%s rows", formatCount(rows), formatCount(progress.TotalRows))
+		if d.phase == worker.PhaseParse {
+			line += " parsed"
+		}
+	}
+	if progress.TotalBytes > 0 {
+		line += fmt.Sprintf("  %s/%s", formatBytes(bytes), formatBytes(progress.TotalBytes))
+	}
+	if progress.ETA > 0 {
+		line += "  ETA: " + formatDuration(progress.ETA)
+	}
+	return line
+}
+
+// clearInsert entfernt die Insert-Zeile nach Abschluss einer Datei.
+func (d *LiveDisplay) clearInsert() {
+	d.mu.Lock()
+	d.insert = nil
+	d.mu.Unlock()
+}
+
+// printf schreibt eine Zeile im Zeilen-Modus.
+func (d *LiveDisplay) printf(format string, args ...interface{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, _ = fmt.Fprintf(d.output, format, args...)
+}
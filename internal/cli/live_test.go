@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/worker"
+)
+
+// TestNewLiveDisplay_Defaults testet Default-Werte und den Zeilen-Modus bei Nicht-Terminals
+func TestNewLiveDisplay_Defaults(t *testing.T) {
+	d := NewLiveDisplay(LiveDisplayConfig{Output: &bytes.Buffer{}})
+
+	if d.refreshRate != 200*time.Millisecond {
+		t.Errorf("expected default refresh rate 200ms, got %v", d.refreshRate)
+	}
+	if d.barWidth != 30 {
+		t.Errorf("expected default bar width 30, got %d", d.barWidth)
+	}
+	if d.interactive {
+		t.Error("expected plain mode for non-terminal output")
+	}
+}
+
+// TestLiveDisplay_Lines testet die Worker-, Insert- und Gesamt-Zeilen
+func TestLiveDisplay_Lines(t *testing.T) {
+	d := NewLiveDisplay(LiveDisplayConfig{Output: &bytes.Buffer{}, Workers: 2, BarWidth: 10})
+	d.interactive = true // Rendering ohne Terminal testen
+
+	d.counters.start(2)
+	d.counters.scan(worker.ScanResult{TotalLines: 2000, TotalBytes: 4096})
+	d.OnPhaseChange(worker.PhaseParse)
+	d.OnWorkerActivity(worker.WorkerActivity{WorkerID: 1, File: "/sde/invTypes.jsonl", Chunk: -1, Rows: 1500, Started: time.Now()})
+
+	lines := d.lines()
+	if len(lines) != 3 {
+		t.Fatalf("expected 2 worker lines and 1 overall line, got %q", lines)
+	}
+	if !strings.Contains(lines[0], "worker 0") || !strings.Contains(lines[0], "idle") {
+		t.Errorf("expected idle worker 0, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "invTypes.jsonl") || !strings.Contains(lines[1], "1.5k rows") {
+		t.Errorf("expected active worker 1, got %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "0/2 files") || !strings.Contains(lines[2], "1.5k/2.0k rows parsed") {
+		t.Errorf("unexpected overall line %q", lines[2])
+	}
+
+	d.OnWorkerActivity(worker.WorkerActivity{WorkerID: 1, Done: true})
+	d.OnPhaseChange(worker.PhaseInsert)
+	d.OnInsertProgress(worker.InsertProgress{File: "/sde/invTypes.jsonl", Table: "invTypes", Rows: 1000, TotalRows: 1500, Batch: 1, Batches: 2})
+
+	lines = d.lines()
+	if len(lines) != 2 {
+		t.Fatalf("expected insert line and overall line, got %q", lines)
+	}
+	if !strings.Contains(lines[0], "invTypes") || !strings.Contains(lines[0], "batch 1/2") {
+		t.Errorf("unexpected insert line %q", lines[0])
+	}
+
+	d.OnFileInserted("/sde/invTypes.jsonl", 1500, time.Second)
+	lines = d.lines()
+	if !strings.Contains(lines[0], "waiting") {
+		t.Errorf("expected insert line to be reset, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "1/2 files") {
+		t.Errorf("expected 1/2 files, got %q", lines[1])
+	}
+}
+
+// TestLiveDisplay_Plain testet die zeilenweise Ausgabe ohne Steuerzeichen
+func TestLiveDisplay_Plain(t *testing.T) {
+	var buf bytes.Buffer
+	d := NewLiveDisplay(LiveDisplayConfig{Output: &buf})
+
+	d.OnImportStart("/sde", []string{"a.jsonl", "b.jsonl"})
+	d.OnPhaseChange(worker.PhaseParse)
+	d.OnWorkerActivity(worker.WorkerActivity{WorkerID: 0, File: "/sde/a.jsonl", Chunk: -1, Started: time.Now()})
+	d.OnFileParsed("/sde/a.jsonl", 10, time.Millisecond)
+	d.OnPhaseChange(worker.PhaseInsert)
+	d.OnFileInserted("/sde/a.jsonl", 10, time.Millisecond)
+	d.OnFileFailed("/sde/b.jsonl", errors.New("boom"))
+	d.OnImportDone(worker.Progress{}, nil)
+
+	out := buf.String()
+	if strings.Contains(out, "\033[") {
+		t.Errorf("expected no ANSI escape codes in plain mode, got %q", out)
+	}
+	for _, want := range []string{"Importing 2 files", "Phase: parse", "parsed   a.jsonl", "inserted a.jsonl", "[1/2 files]", "FAILED   b.jsonl", "boom"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// TestLiveDisplay_Redraw testet das Neuzeichnen an derselben Stelle
+func TestLiveDisplay_Redraw(t *testing.T) {
+	var buf bytes.Buffer
+	sw := newSyncedWriter(&buf)
+	d := NewLiveDisplay(LiveDisplayConfig{Output: sw, Workers: 1, RefreshRate: 10 * time.Millisecond})
+	d.interactive = true
+
+	d.OnImportStart("/sde", []string{"a.jsonl"})
+	d.OnPhaseChange(worker.PhaseParse)
+	time.Sleep(30 * time.Millisecond)
+	d.OnImportDone(worker.Progress{}, nil)
+	d.Finish() // idempotent
+
+	sw.mu.Lock()
+	out := buf.String()
+	sw.mu.Unlock()
+	if !strings.Contains(out, "\033[2A") {
+		t.Errorf("expected cursor-up sequence for 2 lines, got %q", out)
+	}
+	if !strings.Contains(out, "worker 0") {
+		t.Errorf("expected worker line, got %q", out)
+	}
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/worker"
+)
+
+// ProgressMode bestimmt, wie der Import-Fortschritt ausgegeben wird.
+type ProgressMode string
+
+const (
+	// ProgressModeAuto verwendet die Live-Anzeige auf einem Terminal und
+	// sonst die zeilenweise Ausgabe.
+	ProgressModeAuto ProgressMode = "auto"
+	// ProgressModeBar erzwingt die mehrzeilige Live-Anzeige.
+	ProgressModeBar ProgressMode = "bar"
+	// ProgressModeLog schreibt eine Textzeile pro Ereignis (ohne Steuerzeichen).
+	ProgressModeLog ProgressMode = "log"
+	// ProgressModeJSON schreibt NDJSON-Ereignisse (siehe ProgressEvent).
+	ProgressModeJSON ProgressMode = "json"
+)
+
+// ProgressModes gibt alle gültigen Fortschritts-Modi zurück.
+func ProgressModes() []ProgressMode {
+	return []ProgressMode{ProgressModeAuto, ProgressModeBar, ProgressModeLog, ProgressModeJSON}
+}
+
+// ParseProgressMode wandelt einen Modus-Namen (case-insensitive) in einen
+// ProgressMode um.
+func ParseProgressMode(s string) (ProgressMode, error) {
+	mode := ProgressMode(strings.ToLower(strings.TrimSpace(s)))
+	for _, m := range ProgressModes() {
+		if mode == m {
+			return mode, nil
+		}
+	}
+
+	names := make([]string, 0, len(ProgressModes()))
+	for _, m := range ProgressModes() {
+		names = append(names, string(m))
+	}
+	return "", fmt.Errorf("invalid progress mode %q (valid: %s)", s, strings.Join(names, ", "))
+}
+
+// ImportDisplay ist eine Fortschrittsausgabe, die den Import-Events folgt.
+type ImportDisplay interface {
+	worker.ImportObserver
+	// Finish beendet die Ausgabe (mehrfache Aufrufe sind erlaubt).
+	Finish()
+}
+
+// ImportDisplayConfig enthält die Optionen für NewImportDisplay.
+type ImportDisplayConfig struct {
+	// Mode ist der Fortschritts-Modus (default: ProgressModeAuto)
+	Mode ProgressMode
+	// Workers ist die Anzahl der Worker-Zeilen der Live-Anzeige
+	Workers int
+	// Interval ist der Abstand der JSON-"progress"-Ereignisse (<= 0: pro Datei)
+	Interval time.Duration
+	// RefreshRate ist das Neuzeichnen-Intervall der Live-Anzeige (default: 200ms)
+	RefreshRate time.Duration
+	// Output ist das Ziel der Live-/Log-Ausgabe (default: os.Stdout)
+	Output io.Writer
+	// JSONOutput ist das Ziel der JSON-Ereignisse (default: os.Stderr)
+	JSONOutput io.Writer
+}
+
+// NewImportDisplay erstellt die Fortschrittsausgabe für den Modus.
+//
+// Beispiel:
+//
+//	display, err := cli.NewImportDisplay(cli.ImportDisplayConfig{Mode: cli.ProgressModeJSON, Interval: 2 * time.Second})
+//	orch := worker.NewOrchestrator(db, pool, parsers, worker.WithObserver(display))
+//	_, err = orch.ImportAll(ctx, sdeDir)
+//	display.Finish()
+func NewImportDisplay(config ImportDisplayConfig) (ImportDisplay, error) {
+	switch config.Mode {
+	case ProgressModeAuto, "":
+		return NewLiveDisplay(LiveDisplayConfig{Workers: config.Workers, RefreshRate: config.RefreshRate, Output: config.Output}), nil
+	case ProgressModeBar:
+		return NewLiveDisplay(LiveDisplayConfig{Workers: config.Workers, RefreshRate: config.RefreshRate, Output: config.Output, Interactive: true}), nil
+	case ProgressModeLog:
+		return NewLiveDisplay(LiveDisplayConfig{Workers: config.Workers, Output: config.Output, Plain: true}), nil
+	case ProgressModeJSON:
+		return NewJSONProgress(JSONProgressConfig{Interval: config.Interval, Output: config.JSONOutput}), nil
+	default:
+		return nil, fmt.Errorf("invalid progress mode %q", config.Mode)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -15,19 +16,38 @@ import (
 
 // ProgressBar stellt eine erweiterte Progress Bar für Import-Operationen bereit.
 //
+// ProgressBar implementiert worker.ImportObserver und wird per
+// worker.WithObserver am Orchestrator registriert; die Anzeige folgt dann
+// direkt den Import-Events. Alternativ kann ein ProgressTracker mit Start()
+// gepollt werden.
+//
 // Features:
 //   - Live-Update der Zeilen/Sekunde
-//   - ETA-Anzeige (Estimated Time to Arrival)
+//   - Zeilen und Bytes neben den Dateien (Gesamtwerte aus dem Vorab-Scan)
+//   - ETA-Anzeige (Estimated Time to Arrival, zeilenbasiert wenn bekannt)
 //   - Spinner für einzelne Dateien
-//   - Thread-Safe Updates über Channels
+//   - Thread-Safe Updates
+//
+// Beispiel:
+//
+//	pb := cli.NewProgressBar(cli.ProgressBarConfig{Description: "Importing files"})
+//	orch := worker.NewOrchestrator(db, pool, parsers, worker.WithObserver(pb))
+//	_, err := orch.ImportAll(ctx, sdeDir)
+//	pb.Finish()
 type ProgressBar struct {
+	worker.NopObserver
+
 	bar        *progressbar.ProgressBar
 	tracker    *worker.ProgressTracker
 	updateRate time.Duration
 	spinner    *Spinner
 	output     io.Writer
+
+	counters importCounters // Zähler aus den Observer-Events
 }
 
+var _ worker.ImportObserver = (*ProgressBar)(nil)
+
 // ProgressBarConfig enthält Konfigurationsoptionen für die ProgressBar.
 type ProgressBarConfig struct {
 	// Total ist die Gesamtzahl der zu verarbeitenden Elemente
@@ -90,6 +110,7 @@ func NewProgressBar(config ProgressBarConfig) *ProgressBar {
 		updateRate: config.UpdateRate,
 		output:     config.Output,
 	}
+	pb.counters.totalFiles = int64(config.Total)
 
 	// Spinner erstellen, wenn aktiviert
 	if config.ShowSpinner {
@@ -143,9 +164,85 @@ func (pb *ProgressBar) Start(ctx context.Context, tracker *worker.ProgressTracke
 	}
 }
 
-// updateDescription aktualisiert die Beschreibung mit Live-Metriken.
+// OnImportStart setzt die Gesamtzahl auf die tatsächlich importierten Dateien.
+func (pb *ProgressBar) OnImportStart(sdeDir string, files []string) {
+	pb.counters.start(len(files))
+	pb.bar.ChangeMax(len(files))
+}
+
+// OnScanComplete übernimmt Zeilen- und Byte-Gesamtwerte aus dem Vorab-Scan.
+func (pb *ProgressBar) OnScanComplete(scan worker.ScanResult) {
+	pb.counters.scan(scan)
+}
+
+// OnPhaseChange zeigt die aktuelle Import-Phase in der Beschreibung an.
+func (pb *ProgressBar) OnPhaseChange(phase worker.Phase) {
+	pb.counters.phaseChange(phase)
+	switch phase {
+	case worker.PhaseParse:
+		pb.bar.Describe("[cyan]Parsing[reset]")
+	case worker.PhasePostProcess:
+		pb.bar.Describe("[cyan]Post-processing[reset]")
+	}
+}
+
+// OnWorkerActivity verbucht die bisher geparsten Zeilen und aktualisiert die
+// Beschreibung, damit Zeilen, Durchsatz und ETA schon beim Parsen steigen.
+func (pb *ProgressBar) OnWorkerActivity(activity worker.WorkerActivity) {
+	pb.counters.activity(activity)
+	pb.updateDescription(pb.counters.snapshot())
+}
+
+// OnFileParsed verbucht die geparste Datei und zeigt den Spinner (falls
+// aktiviert) für sie an.
+func (pb *ProgressBar) OnFileParsed(file string, rows int, duration time.Duration) {
+	pb.counters.fileParsed(file)
+	pb.updateDescription(pb.counters.snapshot())
+	pb.StartSpinner(fmt.Sprintf("%s parsed (%d rows)", filepath.Base(file), rows))
+}
+
+// OnFileInserted schreitet die Anzeige um eine Datei fort.
+func (pb *ProgressBar) OnFileInserted(file string, rows int, duration time.Duration) {
+	pb.fileDone(file, int64(rows), false)
+}
+
+// OnFileFailed schreitet die Anzeige um eine (fehlgeschlagene) Datei fort.
+func (pb *ProgressBar) OnFileFailed(file string, err error) {
+	pb.fileDone(file, 0, true)
+}
+
+// OnImportDone stoppt einen laufenden Spinner.
+func (pb *ProgressBar) OnImportDone(progress worker.Progress, err error) {
+	pb.StopSpinner()
+}
+
+// fileDone verbucht eine abgeschlossene Datei und aktualisiert die Anzeige.
+func (pb *ProgressBar) fileDone(file string, rows int64, failed bool) {
+	progress := pb.counters.fileDone(file, rows, failed)
+
+	pb.StopSpinner()
+	_ = pb.bar.Add(1)
+	pb.updateDescription(progress)
+}
+
+// updateDescription aktualisiert die Beschreibung mit Live-Metriken der
+// aktuellen Phase (geparste bzw. eingefügte Zeilen).
 func (pb *ProgressBar) updateDescription(progress worker.Progress) {
-	desc := fmt.Sprintf("[cyan]Importing[reset] [yellow]%.0f rows/s[reset]", progress.RowsPerSecond)
+	phase := pb.counters.currentPhase()
+	label := "Importing"
+	if phase == worker.PhaseParse {
+		label = "Parsing"
+	}
+	desc := fmt.Sprintf("[cyan]%s[reset] [yellow]%.0f rows/s[reset]", label, progress.RowsPerSecond)
+
+	// Zeilen und Bytes, sobald Gesamtwerte aus dem Vorab-Scan vorliegen
+	rows, bytes := phaseDone(progress, phase)
+	if progress.TotalRows > 0 {
+		desc += fmt.Sprintf(" rows %s/%s", formatCount(rows), formatCount(progress.TotalRows))
+	}
+	if progress.TotalBytes > 0 {
+		desc += fmt.Sprintf(" %s/%s", formatBytes(bytes), formatBytes(progress.TotalBytes))
+	}
 
 	// ETA hinzufügen, wenn verfügbar
 	if progress.ETA > 0 {
@@ -203,6 +300,32 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
 }
 
+// formatCount formatiert eine Anzahl kompakt (z.B. 1.2M, 350.0k).
+func formatCount(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+// formatBytes formatiert eine Byte-Anzahl in eine lesbare Einheit (KiB, MiB, GiB).
+func formatBytes(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
 // Spinner repräsentiert einen rotierenden Spinner für Datei-Verarbeitung.
 type Spinner struct {
 	mu      sync.Mutex
@@ -223,9 +346,14 @@ func NewSpinner(output io.Writer) *Spinner {
 }
 
 // Start startet den Spinner mit einer Datei-Beschreibung.
+//
+// Thread-Safe: Ein laufender Spinner wird zuerst gestoppt. Da Stop ohne Lock
+// läuft, kann ein paralleler Start den Spinner inzwischen erneut gestartet
+// haben; active wird deshalb nach jedem Stop erneut geprüft, damit kein
+// Render-Goroutine ohne Stop-Kanal zurückbleibt.
 func (s *Spinner) Start(message string) {
 	s.mu.Lock()
-	if s.active {
+	for s.active {
 		s.mu.Unlock()
 		s.Stop()
 		s.mu.Lock()
@@ -268,7 +396,7 @@ func (s *Spinner) Stop() {
 	}
 	done := s.done // Capture before closing
 	s.mu.Unlock()
-	
+
 	close(done) // Close outside mutex
 
 	// Zeile löschen
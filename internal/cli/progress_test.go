@@ -398,3 +398,181 @@ func BenchmarkSpinner_Render(b *testing.B) {
 		spinner.current = (spinner.current + 1) % len(spinner.frames)
 	}
 }
+
+// TestProgressBar_Observer testet die ProgressBar als worker.ImportObserver
+func TestProgressBar_Observer(t *testing.T) {
+	var buf bytes.Buffer
+	pb := NewProgressBar(ProgressBarConfig{
+		Total:  10, // wird durch OnImportStart ersetzt
+		Output: newSyncedWriter(&buf),
+	})
+
+	pb.OnImportStart("/sde", []string{"a.jsonl", "b.jsonl", "c.jsonl"})
+	pb.OnPhaseChange(worker.PhaseParse)
+	pb.OnFileParsed("a.jsonl", 100, time.Millisecond)
+	pb.OnPhaseChange(worker.PhaseInsert)
+	pb.OnFileInserted("a.jsonl", 100, time.Millisecond)
+	pb.OnFileFailed("b.jsonl", context.Canceled)
+
+	progress := pb.counters.snapshot()
+
+	if progress.TotalFiles != 3 {
+		t.Errorf("expected 3 total files, got %d", progress.TotalFiles)
+	}
+	if progress.ParsedFiles != 2 || progress.FailedFiles != 1 || progress.InsertedFiles != 1 {
+		t.Errorf("expected 2 done / 1 failed / 1 inserted, got %d / %d / %d",
+			progress.ParsedFiles, progress.FailedFiles, progress.InsertedFiles)
+	}
+	if progress.InsertedRows != 100 {
+		t.Errorf("expected 100 inserted rows, got %d", progress.InsertedRows)
+	}
+	if state := pb.bar.State(); state.CurrentNum != 2 || state.Max != 3 {
+		t.Errorf("expected bar at 2/3, got %d/%d", state.CurrentNum, state.Max)
+	}
+
+	pb.OnImportDone(progress, nil)
+	pb.Finish()
+}
+
+// TestProgressBar_ScanTotals tests row/byte progress from the pre-scan
+func TestProgressBar_ScanTotals(t *testing.T) {
+	var buf bytes.Buffer
+	pb := NewProgressBar(ProgressBarConfig{Output: newSyncedWriter(&buf)})
+
+	pb.OnImportStart("/sde", []string{"a.jsonl", "b.jsonl"})
+	pb.OnScanComplete(worker.ScanResult{
+		Files: map[string]worker.FileScan{
+			"a.jsonl": {Lines: 100, Bytes: 1000},
+			"b.jsonl": {Lines: 300, Bytes: 3000},
+		},
+		TotalLines: 400,
+		TotalBytes: 4000,
+	})
+	pb.OnPhaseChange(worker.PhaseInsert)
+	pb.OnFileInserted("a.jsonl", 100, time.Millisecond)
+
+	progress := pb.counters.snapshot()
+
+	if progress.TotalRows != 400 || progress.PercentRows != 25 {
+		t.Errorf("expected 25%% of 400 rows, got %.1f%% of %d", progress.PercentRows, progress.TotalRows)
+	}
+	if progress.ProcessedBytes != 1000 || progress.PercentBytes != 25 {
+		t.Errorf("expected 1000 bytes (25%%), got %d (%.1f%%)", progress.ProcessedBytes, progress.PercentBytes)
+	}
+	if progress.ETA <= 0 {
+		t.Errorf("expected row-based ETA, got %v", progress.ETA)
+	}
+
+	pb.OnImportDone(progress, nil)
+	pb.Finish()
+}
+
+// TestProgressBar_ParallelOnFileParsed testet parallele OnFileParsed-Aufrufe
+// aus Worker-Goroutines (mit -race): nach OnImportDone darf kein Spinner
+// mehr laufen und weiter in die Ausgabe zeichnen
+func TestProgressBar_ParallelOnFileParsed(t *testing.T) {
+	var buf bytes.Buffer
+	out := newSyncedWriter(&buf)
+	pb := NewProgressBar(ProgressBarConfig{Output: out, ShowSpinner: true})
+
+	pb.OnImportStart("/sde", []string{"a.jsonl"})
+	pb.OnPhaseChange(worker.PhaseParse)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 16; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				pb.OnFileParsed("a.jsonl", i, time.Millisecond)
+			}
+		}()
+	}
+	wg.Wait()
+	pb.OnImportDone(worker.Progress{}, nil)
+
+	// Ein abgebrochener Render-Tick darf noch schreiben, danach ist Ruhe
+	time.Sleep(200 * time.Millisecond)
+	out.mu.Lock()
+	before := buf.Len()
+	out.mu.Unlock()
+	time.Sleep(300 * time.Millisecond)
+	out.mu.Lock()
+	after := buf.Len()
+	out.mu.Unlock()
+
+	if after != before {
+		t.Errorf("spinner still drawing after OnImportDone (%d → %d bytes)", before, after)
+	}
+	pb.spinner.mu.Lock()
+	active := pb.spinner.active
+	pb.spinner.mu.Unlock()
+	if active {
+		t.Error("expected spinner to be stopped")
+	}
+}
+
+// TestImportCounters_ParsePhase tests that rows, bytes and ETA already
+// advance while parsing, before any file has been inserted
+func TestImportCounters_ParsePhase(t *testing.T) {
+	var c importCounters
+	c.start(2)
+	c.scan(worker.ScanResult{
+		Files: map[string]worker.FileScan{
+			"a.jsonl": {Lines: 100, Bytes: 1000},
+			"b.jsonl": {Lines: 300, Bytes: 3000},
+		},
+		TotalLines: 400,
+		TotalBytes: 4000,
+	})
+	c.phaseChange(worker.PhaseParse)
+
+	// Two chunks of b.jsonl and a retry of chunk 1 (replaces, not adds)
+	c.activity(worker.WorkerActivity{File: "b.jsonl", Chunk: 0, Rows: 50})
+	c.activity(worker.WorkerActivity{File: "b.jsonl", Chunk: 1, Rows: 80})
+	c.activity(worker.WorkerActivity{File: "b.jsonl", Chunk: 1, Rows: 20})
+	c.activity(worker.WorkerActivity{File: "a.jsonl", Chunk: -1, Rows: 100, Done: true})
+	c.fileParsed("a.jsonl")
+	time.Sleep(time.Millisecond)
+
+	progress := c.snapshot()
+	if progress.ParsedRows != 170 || progress.ParsedBytes != 1000 {
+		t.Errorf("expected 170 parsed rows and 1000 parsed bytes, got %d / %d", progress.ParsedRows, progress.ParsedBytes)
+	}
+	if progress.InsertedRows != 0 {
+		t.Errorf("expected no inserted rows while parsing, got %d", progress.InsertedRows)
+	}
+	if progress.RowsPerSecond <= 0 || progress.ETA <= 0 {
+		t.Errorf("expected parse rate and ETA, got %.0f rows/s, ETA %v", progress.RowsPerSecond, progress.ETA)
+	}
+	if rows, bytes := phaseDone(progress, worker.PhaseParse); rows != 170 || bytes != 1000 {
+		t.Errorf("phaseDone(parse) = %d, %d", rows, bytes)
+	}
+
+	// Insert phase: rate and ETA switch to inserted rows
+	c.phaseChange(worker.PhaseInsert)
+	progress = c.snapshot()
+	if progress.ETA != 0 || progress.RowsPerSecond != 0 {
+		t.Errorf("expected no insert ETA before the first insert, got %v (%.0f rows/s)", progress.ETA, progress.RowsPerSecond)
+	}
+	progress = c.fileDone("a.jsonl", 100, false)
+	if rows, bytes := phaseDone(progress, worker.PhaseInsert); rows != 100 || bytes != 1000 {
+		t.Errorf("phaseDone(insert) = %d, %d", rows, bytes)
+	}
+}
+
+// TestFormatCountAndBytes tests the compact number formatting
+func TestFormatCountAndBytes(t *testing.T) {
+	counts := map[int64]string{999: "999", 1500: "1.5k", 2_500_000: "2.5M"}
+	for n, want := range counts {
+		if got := formatCount(n); got != want {
+			t.Errorf("formatCount(%d) = %q, want %q", n, got, want)
+		}
+	}
+	sizes := map[int64]string{512: "512 B", 2048: "2.0 KiB", 5 << 20: "5.0 MiB"}
+	for n, want := range sizes {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
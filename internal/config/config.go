@@ -6,10 +6,11 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
 	"runtime"
+	"sort"
 	"strconv"
-
-	"github.com/BurntSushi/toml"
+	"strings"
 )
 
 // Config ist die Haupt-Konfigurationsstruktur
@@ -19,13 +20,64 @@ type Config struct {
 	Import   ImportConfig   `toml:"import"`
 	Logging  LoggingConfig  `toml:"logging"`
 	Update   UpdateConfig   `toml:"update"`
+	Export   ExportConfig   `toml:"export"`
+	// Tables enthält Import-Einstellungen je Tabelle ([tables.<name>])
+	Tables map[string]TableConfig `toml:"tables"`
+
+	// Profile ist das angewendete Profil (leer = Basis-Konfiguration)
+	Profile string `toml:"-"`
+	// Profiles listet alle in der Konfigurationsdatei definierten Profile
+	Profiles []string `toml:"-"`
 }
 
 // DatabaseConfig konfiguriert SQLite-spezifische Parameter
 type DatabaseConfig struct {
 	Path        string `toml:"path"`
-	JournalMode string `toml:"journal_mode"`
-	CacheSizeMB int    `toml:"cache_size_mb"`
+	JournalMode string `toml:"journal_mode"`  // WAL, DELETE, TRUNCATE, PERSIST, MEMORY, OFF
+	Synchronous string `toml:"synchronous"`   // OFF, NORMAL, FULL, EXTRA
+	CacheSizeMB int    `toml:"cache_size_mb"` // Page Cache in MB (0 = Default 64)
+	MmapSizeMB  int    `toml:"mmap_size_mb"`  // Memory-Mapped I/O in MiB (0 = deaktiviert)
+	// BusyTimeoutMS ist die Wartezeit bei gesperrter Datenbank in Millisekunden
+	BusyTimeoutMS int `toml:"busy_timeout_ms"`
+	// PageSize ist die Seitengröße in Bytes (Zweierpotenz 512-65536, 0 = SQLite-Default);
+	// wirkt nur bei neuen Datenbanken bzw. nach VACUUM
+	PageSize int `toml:"page_size"`
+	// ReadOnly öffnet die Datenbank schreibgeschützt (mode=ro)
+	ReadOnly bool `toml:"read_only"`
+	// Immutable öffnet die Datenbank als unveränderlich (immutable=1, impliziert read_only)
+	Immutable bool `toml:"immutable"`
+}
+
+// validJournalModes sind die unterstützten Werte für database.journal_mode
+// (ohne Beachtung der Groß-/Kleinschreibung)
+var validJournalModes = []string{"WAL", "DELETE", "TRUNCATE", "PERSIST", "MEMORY", "OFF"}
+
+// validSynchronousLevels sind die unterstützten Werte für
+// database.synchronous (ohne Beachtung der Groß-/Kleinschreibung)
+var validSynchronousLevels = []string{"OFF", "NORMAL", "FULL", "EXTRA"}
+
+// checkFields prüft die SQLite-Optionen je Schlüssel
+func (d DatabaseConfig) checkFields() []fieldError {
+	var errs []fieldError
+	if d.JournalMode != "" && !containsFold(validJournalModes, d.JournalMode) {
+		errs = append(errs, fieldError{"journal_mode", fmt.Errorf("invalid journal_mode %q (must be: %s)", d.JournalMode, strings.Join(validJournalModes, ", "))})
+	}
+	if d.Synchronous != "" && !containsFold(validSynchronousLevels, d.Synchronous) {
+		errs = append(errs, fieldError{"synchronous", fmt.Errorf("invalid synchronous %q (must be: %s)", d.Synchronous, strings.Join(validSynchronousLevels, ", "))})
+	}
+	if d.CacheSizeMB < 0 {
+		errs = append(errs, fieldError{"cache_size_mb", fmt.Errorf("invalid cache_size_mb %d (must be >= 0)", d.CacheSizeMB)})
+	}
+	if d.MmapSizeMB < 0 {
+		errs = append(errs, fieldError{"mmap_size_mb", fmt.Errorf("invalid mmap_size_mb %d (must be >= 0)", d.MmapSizeMB)})
+	}
+	if d.BusyTimeoutMS < 0 {
+		errs = append(errs, fieldError{"busy_timeout_ms", fmt.Errorf("invalid busy_timeout_ms %d (must be >= 0)", d.BusyTimeoutMS)})
+	}
+	if d.PageSize != 0 && (d.PageSize < 512 || d.PageSize > 65536 || d.PageSize&(d.PageSize-1) != 0) {
+		errs = append(errs, fieldError{"page_size", fmt.Errorf("invalid page_size %d (must be a power of two between 512 and 65536)", d.PageSize)})
+	}
+	return errs
 }
 
 // ImportConfig konfiguriert den JSONL-Import
@@ -33,6 +85,70 @@ type ImportConfig struct {
 	SDEPath  string `toml:"sde_path"`
 	Language string `toml:"language"`
 	Workers  int    `toml:"workers"`
+	// Tables beschränkt den Import auf Tabellen (Glob-Patterns oder Gruppen
+	// wie "universe"); leer = alle Tabellen
+	Tables []string `toml:"tables"`
+	// ExcludeTables schließt Tabellen (Glob-Patterns oder Gruppen) vom Import aus
+	ExcludeTables []string `toml:"exclude_tables"`
+	// Finalize wählt die Schritte der Finalize-Phase nach dem Import
+	Finalize FinalizeConfig `toml:"finalize"`
+}
+
+// FinalizeConfig konfiguriert die Finalize-Phase nach dem Import
+// ([import.finalize]). Die günstigen Schritte (Analyze, Optimize, Checkpoint)
+// sind standardmäßig an, VACUUM, VACUUM INTO und IntegrityCheck müssen
+// explizit angefordert werden.
+type FinalizeConfig struct {
+	Analyze        bool   `toml:"analyze"`         // ANALYZE (Statistiken für den Query Planner)
+	Optimize       bool   `toml:"optimize"`        // PRAGMA optimize
+	Checkpoint     bool   `toml:"checkpoint"`      // WAL-Checkpoint mit Truncate
+	Vacuum         bool   `toml:"vacuum"`          // VACUUM (gibt freie Seiten frei)
+	VacuumInto     string `toml:"vacuum_into"`     // Kompaktierte Kopie in diese Datei (leer = keine)
+	IntegrityCheck bool   `toml:"integrity_check"` // PRAGMA integrity_check (Import schlägt fehl, wenn nicht ok)
+}
+
+// TableConfig konfiguriert den Import einer einzelnen Tabelle ([tables.<name>])
+type TableConfig struct {
+	BatchSize int    `toml:"batch_size"` // Zeilen pro INSERT-Statement (0 = Default 1000)
+	ErrorMode string `toml:"error_mode"` // fail_fast (Default) oder skip (fehlerhafte Zeilen überspringen)
+	// MaxErrors bricht bei error_mode = "skip" nach so vielen fehlerhaften Zeilen ab (0 = unbegrenzt)
+	MaxErrors  int    `toml:"max_errors"`
+	OnConflict string `toml:"on_conflict"` // abort (Default), ignore oder replace
+	Skip       bool   `toml:"skip"`        // Tabelle nicht importieren
+}
+
+// validErrorModes sind die unterstützten Werte für tables.<name>.error_mode
+// (ohne Beachtung der Groß-/Kleinschreibung)
+var validErrorModes = []string{"fail_fast", "skip"}
+
+// validConflictStrategies sind die unterstützten Werte für
+// tables.<name>.on_conflict (ohne Beachtung der Groß-/Kleinschreibung, leer = abort)
+var validConflictStrategies = []string{"abort", "ignore", "replace"}
+
+// Validate prüft die Einstellungen einer Tabelle
+func (t TableConfig) Validate() error {
+	return firstError(t.checkFields())
+}
+
+// checkFields prüft die Einstellungen einer Tabelle je Schlüssel
+func (t TableConfig) checkFields() []fieldError {
+	var errs []fieldError
+	if t.BatchSize < 0 {
+		errs = append(errs, fieldError{"batch_size", fmt.Errorf("batch_size must be >= 0 (got %d)", t.BatchSize)})
+	}
+	if t.ErrorMode != "" && !containsFold(validErrorModes, t.ErrorMode) {
+		errs = append(errs, fieldError{"error_mode", fmt.Errorf("invalid error mode %q (must be: %s)", t.ErrorMode, strings.Join(validErrorModes, ", "))})
+	}
+	if t.MaxErrors < 0 {
+		errs = append(errs, fieldError{"max_errors", fmt.Errorf("max_errors must be >= 0 (got %d)", t.MaxErrors)})
+	}
+	if t.MaxErrors > 0 && !strings.EqualFold(t.ErrorMode, "skip") {
+		errs = append(errs, fieldError{"max_errors", fmt.Errorf("max_errors requires error_mode = \"skip\"")})
+	}
+	if t.OnConflict != "" && !containsFold(validConflictStrategies, t.OnConflict) {
+		errs = append(errs, fieldError{"on_conflict", fmt.Errorf("invalid conflict strategy %q (must be: %s)", t.OnConflict, strings.Join(validConflictStrategies, ", "))})
+	}
+	return errs
 }
 
 // LoggingConfig konfiguriert Logging-Verhalten
@@ -47,29 +163,23 @@ type UpdateConfig struct {
 	CheckURL string `toml:"check_url"`
 }
 
-// Load lädt Config mit Prioritäts-Kaskade: TOML < Env < Flags
+// Load lädt Config mit Prioritäts-Kaskade: Defaults < TOML < Env
 func Load(configPath string) (*Config, error) {
-	// 1. Default Config
-	cfg := DefaultConfig()
-
-	// 2. TOML laden (falls vorhanden)
-	if _, err := os.Stat(configPath); err == nil {
-		if _, err := toml.DecodeFile(configPath, &cfg); err != nil {
-			return nil, fmt.Errorf("failed to parse config file: %w", err)
-		}
-	}
-
-	// 3. Environment Variables überschreiben
-	applyEnvVars(&cfg)
-
-	// 4. CLI Flags (wird extern in main.go überschrieben)
+	return LoadWithOverrides(configPath, nil)
+}
 
-	// 5. Validierung
-	if err := cfg.Validate(); err != nil {
-		return nil, err
+// LoadWithOverrides lädt Config mit Prioritäts-Kaskade: Defaults < TOML < Env < Overrides.
+//
+// override wird nach den Environment Variables und vor der Validierung
+// aufgerufen, z.B. um explizit gesetzte CLI Flags zu übernehmen. Damit werden
+// auch Flag-Werte wie alle anderen Quellen validiert. override darf nil sein.
+func LoadWithOverrides(configPath string, override func(*Config) error) (*Config, error) {
+	var wrapped func(*Config, Sources) error
+	if override != nil {
+		wrapped = func(cfg *Config, _ Sources) error { return override(cfg) }
 	}
-
-	return &cfg, nil
+	cfg, _, err := LoadWithSources(configPath, "", wrapped)
+	return cfg, err
 }
 
 // DefaultConfig liefert sinnvolle Defaults
@@ -77,14 +187,17 @@ func DefaultConfig() Config {
 	return Config{
 		Version: "1.0.0",
 		Database: DatabaseConfig{
-			Path:        "./eve_sde.db",
-			JournalMode: "WAL",
-			CacheSizeMB: 64,
+			Path:          "./eve_sde.db",
+			JournalMode:   "WAL",
+			Synchronous:   "NORMAL",
+			CacheSizeMB:   64,
+			BusyTimeoutMS: 5000,
 		},
 		Import: ImportConfig{
 			SDEPath:  "./sde-JSONL",
 			Language: "en",
 			Workers:  0, // 0 = Auto (runtime.NumCPU())
+			Finalize: FinalizeConfig{Analyze: true, Optimize: true, Checkpoint: true},
 		},
 		Logging: LoggingConfig{
 			Level:  "info",
@@ -93,67 +206,196 @@ func DefaultConfig() Config {
 		Update: UpdateConfig{
 			Enabled: false,
 		},
+		Export: ExportConfig{
+			CSV:      CSVExportConfig{Path: "./eve_sde_csv", Delimiter: ",", Header: true},
+			Postgres: PostgresExportConfig{Path: "./eve_sde.sql"},
+		},
 	}
 }
 
-// Validate prüft Config-Constraints
+// Validate prüft Config-Constraints und löst import.workers = 0 (Auto) auf.
+//
+// Der Fehler ist ein *ValidationError mit allen gefundenen Problemen (siehe
+// Check); Error() verbindet deren Meldungen.
 func (c *Config) Validate() error {
+	if issues := c.Check(); len(issues) > 0 {
+		return &ValidationError{Issues: issues}
+	}
+	if c.Import.Workers == 0 {
+		c.Import.Workers = runtime.NumCPU()
+	}
+	return nil
+}
+
+// validLanguages sind die unterstützten Werte für import.language
+var validLanguages = []string{"en", "de", "fr", "ja", "ru", "zh", "es", "ko"}
+
+// validLogLevels sind die unterstützten Werte für logging.level
+var validLogLevels = []string{"debug", "info", "warn", "error"}
+
+// validLogFormats sind die unterstützten Werte für logging.format
+var validLogFormats = []string{"text", "json"}
+
+// checkTablePatterns prüft Tabellenfilter (import.tables, export.*.tables).
+//
+// Gruppennamen wie "universe" sind selbst gültige Glob-Patterns; aufgelöst
+// werden sie erst von parser.NewTableFilter.
+func checkTablePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Check prüft alle Config-Constraints und liefert jedes gefundene Problem
+// (SeverityError) mit seinem TOML-Schlüssel; nil = gültig. Anders als
+// Validate verändert Check die Config nicht. Tabellennamen prüft
+// CheckTableNames.
+func (c *Config) Check() []Issue {
+	var issues issueList
+
 	if c.Database.Path == "" {
-		return fmt.Errorf("database.path is required")
+		issues.add("database.path", "database.path is required")
+	}
+	// SQLite-Optionen (journal_mode, synchronous, cache/mmap/page size, ...)
+	for _, fe := range c.Database.checkFields() {
+		issues.add("database."+fe.key, "database: %v", fe.err)
 	}
 	if c.Import.SDEPath == "" {
-		return fmt.Errorf("import.sde_path is required")
+		issues.add("import.sde_path", "import.sde_path is required")
 	}
 
 	// Workers: 0 = Auto, 1-32 explizit
 	if c.Import.Workers < 0 || c.Import.Workers > 32 {
-		return fmt.Errorf("import.workers must be 0-32 (0=auto, got %d)", c.Import.Workers)
-	}
-	if c.Import.Workers == 0 {
-		c.Import.Workers = runtime.NumCPU()
+		issues.add("import.workers", "import.workers must be 0-32 (0=auto, got %d)", c.Import.Workers)
 	}
 
 	// Language Validation
-	validLangs := map[string]bool{
-		"en": true, "de": true, "fr": true, "ja": true,
-		"ru": true, "zh": true, "es": true, "ko": true,
+	if !containsString(validLanguages, c.Import.Language) {
+		issues.add("import.language", "invalid language: %s (must be: %s)", c.Import.Language, strings.Join(validLanguages, ", "))
+	}
+
+	// Tabellenfilter: Gruppen oder gültige Glob-Patterns
+	if err := checkTablePatterns(c.Import.Tables); err != nil {
+		issues.add("import.tables", "invalid import.tables: %v", err)
+	}
+	if err := checkTablePatterns(c.Import.ExcludeTables); err != nil {
+		issues.add("import.exclude_tables", "invalid import.exclude_tables: %v", err)
+	}
+
+	// Tabellen-Einstellungen (Tabellennamen siehe CheckTableNames)
+	for _, name := range c.tableNames() {
+		for _, fe := range c.Tables[name].checkFields() {
+			issues.add("tables."+name+"."+fe.key, "invalid tables.%s: %v", name, fe.err)
+		}
+	}
+
+	// Export-Ziele
+	for _, fe := range c.Export.CSV.checkFields() {
+		issues.add("export.csv."+fe.key, "invalid export.csv: %v", fe.err)
 	}
-	if !validLangs[c.Import.Language] {
-		return fmt.Errorf("invalid language: %s (must be: en, de, fr, ja, ru, zh, es, ko)", c.Import.Language)
+	for _, fe := range c.Export.Postgres.checkFields() {
+		issues.add("export.postgres."+fe.key, "invalid export.postgres: %v", fe.err)
 	}
 
 	// Logging Level
-	validLevels := map[string]bool{
-		"debug": true, "info": true, "warn": true, "error": true,
+	if !containsString(validLogLevels, c.Logging.Level) {
+		issues.add("logging.level", "invalid logging.level: %s (must be: %s)", c.Logging.Level, strings.Join(validLogLevels, ", "))
 	}
-	if !validLevels[c.Logging.Level] {
-		return fmt.Errorf("invalid logging.level: %s (must be: debug, info, warn, error)", c.Logging.Level)
+
+	// Logging Format
+	if !containsString(validLogFormats, c.Logging.Format) {
+		issues.add("logging.format", "invalid logging.format: %s (must be: %s)", c.Logging.Format, strings.Join(validLogFormats, ", "))
 	}
 
-	return nil
+	if len(issues) == 0 {
+		return nil
+	}
+	return issues
+}
+
+// CheckTableNames prüft, dass jede Tabelle aus [tables.<name>] in known
+// enthalten ist, und liefert ein Issue je unbekannter Tabelle; nil = gültig.
+//
+// Die Config kennt die Parser-Registry nicht: import und validate übergeben
+// die registrierten Tabellen (parser.RegisterParsers).
+func (c *Config) CheckTableNames(known []string) []Issue {
+	registered := make(map[string]bool, len(known))
+	for _, name := range known {
+		registered[name] = true
+	}
+	var issues issueList
+	for _, name := range c.tableNames() {
+		if !registered[name] {
+			issues.add("tables."+name, "invalid tables.%s: unknown table", name)
+		}
+	}
+	return issues
 }
 
-// applyEnvVars überschreibt Config mit Environment Variables
-func applyEnvVars(cfg *Config) {
+// tableNames liefert die Namen der [tables.<name>] Abschnitte (sortiert)
+func (c *Config) tableNames() []string {
+	names := make([]string, 0, len(c.Tables))
+	for name := range c.Tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyEnvVars überschreibt Config mit Environment Variables und vermerkt
+// die Quellen in sources (darf nil sein)
+func applyEnvVars(cfg *Config, sources Sources) {
 	if dbPath := os.Getenv("ESDEDB_DATABASE_PATH"); dbPath != "" {
 		cfg.Database.Path = dbPath
+		sources.set("database.path", SourceEnv, "ESDEDB_DATABASE_PATH")
 	}
 	if sdePath := os.Getenv("ESDEDB_SDE_PATH"); sdePath != "" {
 		cfg.Import.SDEPath = sdePath
+		sources.set("import.sde_path", SourceEnv, "ESDEDB_SDE_PATH")
 	}
 	if lang := os.Getenv("ESDEDB_LANGUAGE"); lang != "" {
 		cfg.Import.Language = lang
+		sources.set("import.language", SourceEnv, "ESDEDB_LANGUAGE")
 	}
 	if level := os.Getenv("ESDEDB_LOG_LEVEL"); level != "" {
 		cfg.Logging.Level = level
+		sources.set("logging.level", SourceEnv, "ESDEDB_LOG_LEVEL")
 	}
 	if format := os.Getenv("ESDEDB_LOGGING_FORMAT"); format != "" {
 		cfg.Logging.Format = format
+		sources.set("logging.format", SourceEnv, "ESDEDB_LOGGING_FORMAT")
+	}
+	if tables := os.Getenv("ESDEDB_IMPORT_TABLES"); tables != "" {
+		cfg.Import.Tables = splitList(tables)
+		sources.set("import.tables", SourceEnv, "ESDEDB_IMPORT_TABLES")
+	}
+	if tables := os.Getenv("ESDEDB_IMPORT_EXCLUDE_TABLES"); tables != "" {
+		cfg.Import.ExcludeTables = splitList(tables)
+		sources.set("import.exclude_tables", SourceEnv, "ESDEDB_IMPORT_EXCLUDE_TABLES")
 	}
 	if workers := os.Getenv("ESDEDB_IMPORT_WORKER_COUNT"); workers != "" {
 		// Note: Type conversion handled here, validation happens in Validate()
 		if w, err := strconv.Atoi(workers); err == nil {
 			cfg.Import.Workers = w
+			sources.set("import.workers", SourceEnv, "ESDEDB_IMPORT_WORKER_COUNT")
+		}
+	}
+}
+
+// splitList teilt eine komma-separierte Liste und entfernt leere Einträge
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
 		}
 	}
+	return items
 }
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -198,9 +199,16 @@ func TestValidationMissingPathsFromFile(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected validation error for missing database path")
 	}
-	// Should fail on database.path first
-	if err.Error() != "database.path is required" {
-		t.Errorf("expected error 'database.path is required', got %v", err)
+	// Alle Probleme werden gesammelt, database.path zuerst
+	var verr *ValidationError
+	if !errors.As(err, &verr) || len(verr.Issues) != 2 {
+		t.Fatalf("expected ValidationError with 2 issues, got %v", err)
+	}
+	if verr.Issues[0].Key != "database.path" || verr.Issues[1].Key != "import.sde_path" {
+		t.Errorf("unexpected issue keys: %+v", verr.Issues)
+	}
+	if err.Error() != "database.path is required; import.sde_path is required" {
+		t.Errorf("unexpected error message: %v", err)
 	}
 }
 
@@ -418,7 +426,7 @@ func TestApplyEnvVarsWithEmptyValues(t *testing.T) {
 
 	cfg := DefaultConfig()
 	originalPath := cfg.Database.Path
-	applyEnvVars(&cfg)
+	applyEnvVars(&cfg, nil)
 
 	// Empty env var should not override
 	if cfg.Database.Path != originalPath {
@@ -12,7 +12,7 @@
 //  1. Default-Werte (hartcodiert)
 //  2. TOML-Konfigurationsdatei
 //  3. Environment Variables
-//  4. CLI Flags (extern in main.go)
+//  4. CLI Flags (nur explizit gesetzte, per LoadWithOverrides)
 //
 // # Grundlegende Verwendung
 //
@@ -38,6 +38,7 @@
 //   - Import: SDE-Pfad, Sprache, Worker-Anzahl
 //   - Logging: Log-Level, Ausgabeformat
 //   - Update: Auto-Update-Einstellungen (optional, v2.0)
+//   - Export: Export-Ziele der Datenbank (CSV)
 //
 // # TOML-Beispiel
 //
@@ -46,7 +47,9 @@
 //	[database]
 //	path = "./eve_sde.db"
 //	journal_mode = "WAL"
+//	synchronous = "NORMAL"
 //	cache_size_mb = 64
+//	busy_timeout_ms = 5000
 //
 //	[import]
 //	sde_path = "./sde-JSONL"
@@ -67,6 +70,8 @@
 //   - ESDEDB_LOG_LEVEL: Überschreibt logging.level
 //   - ESDEDB_LOGGING_FORMAT: Überschreibt logging.format
 //   - ESDEDB_IMPORT_WORKER_COUNT: Überschreibt import.workers
+//   - ESDEDB_IMPORT_TABLES: Überschreibt import.tables (komma-separiert)
+//   - ESDEDB_IMPORT_EXCLUDE_TABLES: Überschreibt import.exclude_tables (komma-separiert)
 //
 // # Validierung
 //
@@ -82,6 +87,117 @@
 //   - import.workers: 0-32 (0 = Auto)
 //   - import.language: en, de, fr, ja, ru, zh, es, ko
 //   - logging.level: debug, info, warn, error
+//   - logging.format: text, json
+//   - database.*, [tables.<name>], [export.csv] und [export.postgres] siehe die
+//     jeweiligen Abschnitte
+//
+// Validate liefert bei ungültigen Werten einen *ValidationError mit allen
+// Fehlern (nicht nur dem ersten); Check liefert dieselben Issues ohne die
+// Konfiguration zu verändern. Tabellennamen in [tables.<name>] prüft
+// CheckTableNames gegen die vom Aufrufer übergebenen registrierten Tabellen.
+// Diagnose lädt eine Datei ohne abzubrechen und meldet zusätzlich Syntax-
+// und Typfehler sowie unbekannte Schlüssel (SeverityWarning), jeweils mit
+// Zeile und Spalte in der Datei:
+//
+//	cfg, issues, err := config.Diagnose("config.toml", "", tables)
+//	for _, issue := range issues {
+//		fmt.Printf("%s: %s: %s\n", issue.Location("config.toml"), issue.Severity, issue.Message)
+//	}
+//
+// JSONSchema erzeugt ein JSON Schema der Konfiguration für Editoren
+// (config.schema.json im Repository, "esdedb config schema"); wie bei
+// Diagnose übergibt der Aufrufer die registrierten Tabellen.
+//
+// Die Konfiguration ist reine Daten: config hängt weder von database noch von
+// parser ab. Erlaubte Werte (journal_mode, error_mode, on_conflict, ...) prüft
+// Check selbst; die Umwandlung in database.Options und das Auflösen von
+// Tabellengruppen übernehmen die Aufrufer (cmd/esdedb) bzw. parser.
+//
+// # CLI Flags
+//
+// LoadWithOverrides ruft nach den Environment Variables eine Override-Funktion
+// auf, bevor validiert wird. Das CLI übernimmt dort nur explizit gesetzte
+// Flags, sodass Flag-Defaults keine Werte aus TOML oder Environment
+// überschreiben:
+//
+//	cfg, err := config.LoadWithOverrides("config.toml", func(cfg *config.Config) error {
+//	    if flags.Changed("db") {
+//	        cfg.Database.Path, _ = flags.GetString("db")
+//	    }
+//	    return nil
+//	})
+//
+// # Profile
+//
+// Abschnitte [profiles.<name>] haben denselben Aufbau wie die
+// Basis-Konfiguration und überschreiben nur die darin gesetzten Schlüssel.
+// LoadWithSources wendet das angegebene Profil nach der Datei und vor den
+// Environment Variables an; Config.Profiles listet alle definierten Profile:
+//
+//	[profiles.web.database]
+//	path = "./eve_sde_web.db"
+//
+//	[profiles.web.import]
+//	tables = ["inventory"]
+//
+//	cfg, _, err := config.LoadWithSources("config.toml", "web", nil)
+//
+// # Tabellen-Einstellungen
+//
+// Abschnitte [tables.<name>] (Config.Tables) konfigurieren den Import
+// einzelner Tabellen: batch_size, error_mode ("fail_fast" oder "skip"),
+// max_errors, on_conflict ("abort", "ignore", "replace") und skip. Validate
+// lehnt ungültige Werte ab, CheckTableNames unbekannte Tabellennamen. In Profilen
+// überschreibt [profiles.<name>.tables.<tabelle>] nur die gesetzten Schlüssel:
+//
+//	[tables.mapMoons]
+//	error_mode = "skip"
+//	max_errors = 100
+//
+//	[tables.skinLicenses]
+//	skip = true
+//
+// Der Abschnitt [import.finalize] wählt die Schritte der Finalize-Phase nach
+// dem Import; die Schritte entsprechen den Flags --analyze (ANALYZE),
+// --optimize (PRAGMA optimize), --checkpoint (WAL-Checkpoint), --vacuum,
+// --vacuum-into und --integrity-check. analyze, optimize und checkpoint sind
+// standardmäßig an (--finalize=false schaltet sie gemeinsam ab), die übrigen
+// Schritte standardmäßig aus:
+//
+//	[import.finalize]
+//	checkpoint = false
+//	integrity_check = true
+//
+// # Export-Ziele
+//
+// Der Abschnitt [export.csv] beschreibt das CSV-Exportziel von "esdedb export
+// csv": Ausgabeverzeichnis (path, Default "./eve_sde_csv"), Feldtrenner
+// (delimiter, genau ein Zeichen, Default ","), decimal_comma für
+// Dezimalzahlen mit Komma, header (Kopfzeile, Default true), null
+// (Darstellung von NULL, Default leer), gzip sowie tables und exclude_tables
+// (Patterns und Gruppen wie bei import.tables). "esdedb config convert"
+// übernimmt hierhin die CSV-Einstellungen der VB.NET-Version:
+//
+//	[export.csv]
+//	path = "./eve_sde_CSV"
+//	delimiter = ";"
+//	decimal_comma = true
+//
+// Der Abschnitt [export.postgres] beschreibt den PostgreSQL-Dump von "esdedb
+// export postgres": Ausgabedatei (path, Default "./eve_sde.sql"), schema für
+// die Tabellen (leer = search_path), drop_tables für DROP TABLE IF EXISTS vor
+// dem Anlegen sowie tables und exclude_tables wie bei [export.csv].
+//
+// # Herkunft der Werte
+//
+// LoadWithSources lädt wie LoadWithOverrides und liefert zusätzlich für jeden
+// Schlüssel die Quelle (default, file, env, flag); Fields listet alle Werte
+// mit ihren TOML-Schlüsseln. Darauf baut "esdedb config show --effective" auf:
+//
+//	cfg, sources, err := config.LoadWithSources("config.toml", "", nil)
+//	for _, f := range cfg.Fields() {
+//	    fmt.Printf("%s = %v  # %s\n", f.Key, f.Value, sources.Get(f.Key))
+//	}
 //
 // # Worker-Auto-Konfiguration
 //
@@ -408,3 +408,27 @@ func TestEnvVarInvalidLanguage(t *testing.T) {
 		t.Errorf("expected error '%s', got '%s'", expectedErr, err.Error())
 	}
 }
+
+// TestEnvVarOverrideTables tests that ESDEDB_IMPORT_TABLES and
+// ESDEDB_IMPORT_EXCLUDE_TABLES override TOML config
+func TestEnvVarOverrideTables(t *testing.T) {
+	_ = os.Setenv("ESDEDB_IMPORT_TABLES", "dogma, inv*")
+	_ = os.Setenv("ESDEDB_IMPORT_EXCLUDE_TABLES", "typeDogma")
+	defer func() {
+		_ = os.Unsetenv("ESDEDB_IMPORT_TABLES")
+		_ = os.Unsetenv("ESDEDB_IMPORT_EXCLUDE_TABLES")
+	}()
+
+	configPath := filepath.Join("testdata", "table-filter.toml")
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if len(cfg.Import.Tables) != 2 || cfg.Import.Tables[0] != "dogma" || cfg.Import.Tables[1] != "inv*" {
+		t.Errorf("expected tables [dogma inv*], got %v", cfg.Import.Tables)
+	}
+	if len(cfg.Import.ExcludeTables) != 1 || cfg.Import.ExcludeTables[0] != "typeDogma" {
+		t.Errorf("expected exclude tables [typeDogma], got %v", cfg.Import.ExcludeTables)
+	}
+}
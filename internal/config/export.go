@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// ExportConfig konfiguriert Exporte der Datenbank ([export.csv], [export.postgres])
+type ExportConfig struct {
+	CSV      CSVExportConfig      `toml:"csv"`
+	Postgres PostgresExportConfig `toml:"postgres"`
+}
+
+// CSVExportConfig konfiguriert den CSV-Export ([export.csv])
+type CSVExportConfig struct {
+	Path      string `toml:"path"`      // Ausgabeverzeichnis (eine Datei je Tabelle)
+	Delimiter string `toml:"delimiter"` // Feldtrenner, genau ein Zeichen (Default ",")
+	// DecimalComma schreibt Dezimalzahlen mit Komma statt Punkt (z.B. für
+	// europäische Tabellenkalkulationen, meist zusammen mit delimiter = ";")
+	DecimalComma bool   `toml:"decimal_comma"`
+	Header       bool   `toml:"header"` // Kopfzeile mit den Spaltennamen (Default true)
+	Null         string `toml:"null"`   // Darstellung von NULL (Default "" = leeres Feld)
+	Gzip         bool   `toml:"gzip"`   // <tabelle>.csv.gz statt <tabelle>.csv schreiben
+	// Tables beschränkt den Export auf Tabellen (Glob-Patterns oder Gruppen
+	// wie bei import.tables; leer = alle Tabellen der Datenbank)
+	Tables []string `toml:"tables"`
+	// ExcludeTables schließt Tabellen (Glob-Patterns oder Gruppen) vom Export aus
+	ExcludeTables []string `toml:"exclude_tables"`
+}
+
+// Validate prüft die CSV-Export-Einstellungen
+func (c CSVExportConfig) Validate() error {
+	return firstError(c.checkFields())
+}
+
+// checkFields prüft die CSV-Export-Einstellungen je Schlüssel
+func (c CSVExportConfig) checkFields() []fieldError {
+	var errs []fieldError
+	if err := checkDelimiter(c.Delimiter); err != nil {
+		errs = append(errs, fieldError{"delimiter", err})
+	}
+	if err := checkTablePatterns(c.Tables); err != nil {
+		errs = append(errs, fieldError{"tables", fmt.Errorf("tables: %w", err)})
+	}
+	if err := checkTablePatterns(c.ExcludeTables); err != nil {
+		errs = append(errs, fieldError{"exclude_tables", fmt.Errorf("exclude_tables: %w", err)})
+	}
+	return errs
+}
+
+// checkDelimiter prüft, ob delimiter als CSV-Feldtrenner taugt (leer = Default)
+func checkDelimiter(delimiter string) error {
+	if delimiter == "" {
+		return nil
+	}
+	r, size := utf8.DecodeRuneInString(delimiter)
+	if size != len(delimiter) || r == utf8.RuneError {
+		return fmt.Errorf("delimiter must be a single character (got %q)", delimiter)
+	}
+	if r == '"' || r == '\r' || r == '\n' {
+		return fmt.Errorf("delimiter must not be a quote or line break (got %q)", delimiter)
+	}
+	return nil
+}
+
+// DelimiterRune liefert den Feldtrenner als Rune (Default ',')
+func (c CSVExportConfig) DelimiterRune() rune {
+	if c.Delimiter == "" {
+		return ','
+	}
+	r, _ := utf8.DecodeRuneInString(c.Delimiter)
+	return r
+}
+
+// PostgresExportConfig konfiguriert den PostgreSQL-Dump ([export.postgres])
+type PostgresExportConfig struct {
+	Path string `toml:"path"` // Ausgabedatei (.sql, mit psql ladbar)
+	// Schema legt die Tabellen in diesem PostgreSQL-Schema an (leer = Schema
+	// aus dem search_path, meist public)
+	Schema     string `toml:"schema"`
+	DropTables bool   `toml:"drop_tables"` // DROP TABLE IF EXISTS vor CREATE TABLE
+	// Tables beschränkt den Export auf Tabellen (Glob-Patterns oder Gruppen
+	// wie bei import.tables; leer = alle Tabellen der Datenbank)
+	Tables []string `toml:"tables"`
+	// ExcludeTables schließt Tabellen (Glob-Patterns oder Gruppen) vom Export aus
+	ExcludeTables []string `toml:"exclude_tables"`
+}
+
+// Validate prüft die PostgreSQL-Export-Einstellungen
+func (c PostgresExportConfig) Validate() error {
+	return firstError(c.checkFields())
+}
+
+// checkFields prüft die PostgreSQL-Export-Einstellungen je Schlüssel
+func (c PostgresExportConfig) checkFields() []fieldError {
+	var errs []fieldError
+	if err := checkTablePatterns(c.Tables); err != nil {
+		errs = append(errs, fieldError{"tables", fmt.Errorf("tables: %w", err)})
+	}
+	if err := checkTablePatterns(c.ExcludeTables); err != nil {
+		errs = append(errs, fieldError{"exclude_tables", fmt.Errorf("exclude_tables: %w", err)})
+	}
+	return errs
+}
@@ -0,0 +1,254 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Severity ist der Schweregrad eines Issue
+type Severity string
+
+// Schweregrade von Validierungsproblemen
+const (
+	SeverityError   Severity = "error"   // Konfiguration ist ungültig
+	SeverityWarning Severity = "warning" // z.B. unbekannter Schlüssel, der ignoriert wird
+)
+
+// Issue ist ein einzelnes Problem der Konfiguration
+type Issue struct {
+	Severity Severity
+	Key      string // TOML-Schlüssel, z.B. "import.workers" (leer = ganze Datei)
+	Message  string
+	// Source ist die Herkunft des Werts (nur bei Diagnose gesetzt)
+	Source Source
+	// Line und Column sind die Position in der Konfigurationsdatei
+	// (1-basiert, 0 = unbekannt, z.B. bei Werten aus Environment Variables)
+	Line   int
+	Column int
+}
+
+// Location beschreibt, wo das Problem herkommt: "<file>:<zeile>:<spalte>" für
+// Werte aus der Datei (bzw. einem Profil), sonst die Quelle, z.B.
+// "env ESDEDB_LANGUAGE" oder "default".
+func (i Issue) Location(file string) string {
+	switch {
+	case i.Line > 0:
+		return fmt.Sprintf("%s:%d:%d", file, i.Line, i.Column)
+	case i.Source.Kind == SourceFile || i.Source.Kind == SourceProfile:
+		return file
+	case i.Source.Kind == "":
+		return string(SourceDefault)
+	default:
+		return i.Source.String()
+	}
+}
+
+// ValidationError enthält alle Fehler einer Validierung (siehe Config.Check)
+type ValidationError struct {
+	Issues []Issue
+}
+
+// Error liefert die Meldungen aller Fehler, getrennt durch "; "
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		messages[i] = issue.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// issueList sammelt Fehler während Check
+type issueList []Issue
+
+// add vermerkt einen Fehler für key
+func (l *issueList) add(key, format string, args ...interface{}) {
+	*l = append(*l, Issue{Severity: SeverityError, Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+// fieldError ist der Fehler eines einzelnen Schlüssels eines Abschnitts
+type fieldError struct {
+	key string // Schlüssel relativ zum Abschnitt, z.B. "batch_size"
+	err error
+}
+
+// firstError liefert den ersten Fehler aus errs (nil = keiner)
+func firstError(errs []fieldError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs[0].err
+}
+
+// lastKeyPattern erkennt Typfehler des TOML-Decoders, die kein ParseError sind
+var lastKeyPattern = regexp.MustCompile(`^toml: line (\d+) \(last key "([^"]+)"\): (.*)$`)
+
+// Diagnose lädt configPath wie LoadWithSources (ohne Overrides) und liefert
+// statt des ersten Fehlers alle Probleme: Syntax- und Typfehler, ungültige
+// Werte (siehe Check), unbekannte Tabellen (siehe CheckTableNames; nil =
+// nicht prüfen) und unbekannte Schlüssel, die beim Laden ignoriert werden
+// (SeverityWarning). Stammt ein Wert aus der Datei, enthält das Issue Zeile
+// und Spalte.
+//
+// cfg ist nil, wenn die Datei kein gültiges TOML ist. Der Fehler ist nur
+// gesetzt, wenn die Datei nicht gelesen werden kann; eine fehlende Datei
+// bedeutet wie bei Load reine Defaults.
+func Diagnose(configPath, profile string, knownTables []string) (*Config, []Issue, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	positions := keyPositions(string(data))
+
+	cfg := DefaultConfig()
+	sources := Sources{}
+	var issues []Issue
+	if data != nil {
+		md, err := toml.Decode(string(data), &cfg)
+		if err != nil {
+			return nil, []Issue{decodeIssue(err, positions)}, nil
+		}
+		for _, key := range md.Keys() {
+			sources.set(key.String(), SourceFile, configPath)
+		}
+		cfg.Profiles = profileNames(md)
+
+		undecoded, err := undecodedKeys(string(data), md)
+		if err != nil {
+			return nil, []Issue{decodeIssue(err, positions)}, nil
+		}
+		for _, key := range undecoded {
+			issue := Issue{
+				Severity: SeverityWarning,
+				Key:      key,
+				Message:  fmt.Sprintf("unknown key %q (ignored)", key),
+				Source:   Source{Kind: SourceFile, Name: configPath},
+			}
+			issue.Line, issue.Column = positionOf(positions, key)
+			issues = append(issues, issue)
+		}
+	}
+
+	if profile != "" {
+		if err := applyProfile(&cfg, sources, configPath, profile); err != nil {
+			issues = append(issues, Issue{Severity: SeverityError, Key: "profiles", Message: err.Error()})
+		}
+	}
+	applyEnvVars(&cfg, sources)
+
+	checked := cfg.Check()
+	if knownTables != nil {
+		checked = append(checked, cfg.CheckTableNames(knownTables)...)
+	}
+	for _, issue := range checked {
+		issue.Source = sourceOf(sources, issue.Key)
+		switch issue.Source.Kind {
+		case SourceFile:
+			issue.Line, issue.Column = positionOf(positions, issue.Key)
+		case SourceProfile:
+			issue.Line, issue.Column = positionOf(positions, "profiles."+issue.Source.Name+"."+issue.Key)
+		}
+		issues = append(issues, issue)
+	}
+
+	// Fehler vor Warnungen, jeweils in Datei-Reihenfolge; Probleme ohne
+	// Position (Defaults, Environment) am Ende
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.Severity != b.Severity {
+			return a.Severity == SeverityError
+		}
+		if (a.Line == 0) != (b.Line == 0) {
+			return b.Line == 0
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return &cfg, issues, nil
+}
+
+// undecodedKeys liefert die Schlüssel der Datei, die weder in Config noch in
+// einem Profil eine Entsprechung haben. md stammt aus dem Decode in Config;
+// Profile werden dafür einzeln dekodiert.
+func undecodedKeys(data string, md toml.MetaData) ([]string, error) {
+	var keys []string
+	for _, key := range md.Undecoded() {
+		if key[0] != "profiles" {
+			keys = append(keys, key.String())
+		}
+	}
+
+	var file profilesFile
+	pmd, err := toml.Decode(data, &file)
+	if err != nil {
+		return nil, err
+	}
+	for _, prim := range file.Profiles {
+		var profile Config
+		if err := pmd.PrimitiveDecode(prim, &profile); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range pmd.Undecoded() {
+		if key[0] == "profiles" && len(key) > 2 {
+			keys = append(keys, key.String())
+		}
+	}
+	return keys, nil
+}
+
+// decodeIssue wandelt einen Fehler des TOML-Decoders in ein Issue mit Position um
+func decodeIssue(err error, positions map[string]position) Issue {
+	issue := Issue{Severity: SeverityError, Message: err.Error(), Source: Source{Kind: SourceFile}}
+
+	var perr toml.ParseError
+	if errors.As(err, &perr) {
+		issue.Key = perr.LastKey
+		issue.Message = perr.Message
+		issue.Line, issue.Column = perr.Position.Line, perr.Position.Col
+		return issue
+	}
+	if m := lastKeyPattern.FindStringSubmatch(err.Error()); m != nil {
+		issue.Key = m[2]
+		issue.Message = m[3]
+		issue.Line, issue.Column = positionOf(positions, m[2])
+		if issue.Line == 0 {
+			issue.Line, _ = strconv.Atoi(m[1])
+			issue.Column = 1
+		}
+	}
+	return issue
+}
+
+// positionOf liefert Zeile und Spalte von key (0, 0 = unbekannt)
+func positionOf(positions map[string]position, key string) (int, int) {
+	pos, _ := lookup(positions, key)
+	return pos.Line, pos.Column
+}
+
+// sourceOf liefert die Quelle von key; für Abschnitte wie "tables.foo" ohne
+// eigenen Eintrag die Quelle des ersten darin gesetzten Schlüssels.
+func sourceOf(sources Sources, key string) Source {
+	if src, ok := sources[key]; ok {
+		return src
+	}
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		if strings.HasPrefix(name, key+".") {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return Source{Kind: SourceDefault}
+	}
+	sort.Strings(names)
+	return sources[names[0]]
+}
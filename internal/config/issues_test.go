@@ -0,0 +1,211 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestDiagnoseCollectsAllIssues tests that all errors and unknown keys are reported with positions
+func TestDiagnoseCollectsAllIssues(t *testing.T) {
+	path := writeTestConfig(t, `version = "1.0.0"
+colour = "blue"
+
+[database]
+path = "./test.db"
+journal_mode = "FAST"
+page_size = 1000
+
+[import]
+sde_path = "./sde"
+  workers = 64
+tables = [
+  "inv[",
+]
+
+[tables.invTypes]
+batch_size = -1
+on_conflict = "merge"
+batchsize = 10
+
+[tables.nope]
+skip = true
+
+[logging]
+level = "loud"
+
+[profiles.web.import]
+langauge = "de"
+`)
+
+	cfg, issues, err := Diagnose(path, "", testTables)
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+	if cfg == nil {
+		t.Fatal("expected config for a syntactically valid file")
+	}
+
+	type want struct {
+		severity     Severity
+		key          string
+		line, column int
+	}
+	wants := []want{
+		{SeverityError, "database.journal_mode", 6, 1},
+		{SeverityError, "database.page_size", 7, 1},
+		{SeverityError, "import.workers", 11, 3},
+		{SeverityError, "import.tables", 12, 1},
+		{SeverityError, "tables.invTypes.batch_size", 17, 1},
+		{SeverityError, "tables.invTypes.on_conflict", 18, 1},
+		{SeverityError, "tables.nope", 21, 1},
+		{SeverityError, "logging.level", 25, 1},
+		{SeverityWarning, "colour", 2, 1},
+		{SeverityWarning, "tables.invTypes.batchsize", 19, 1},
+		{SeverityWarning, "profiles.web.import.langauge", 28, 1},
+	}
+	if len(issues) != len(wants) {
+		for _, issue := range issues {
+			t.Logf("%s %s %d:%d %s", issue.Severity, issue.Key, issue.Line, issue.Column, issue.Message)
+		}
+		t.Fatalf("expected %d issues, got %d", len(wants), len(issues))
+	}
+	for i, w := range wants {
+		got := issues[i]
+		if got.Severity != w.severity || got.Key != w.key || got.Line != w.line || got.Column != w.column {
+			t.Errorf("issue %d: got %s %s %d:%d (%s), want %s %s %d:%d",
+				i, got.Severity, got.Key, got.Line, got.Column, got.Message, w.severity, w.key, w.line, w.column)
+		}
+	}
+
+	if loc := issues[2].Location(path); loc != path+":11:3" {
+		t.Errorf("unexpected location %q", loc)
+	}
+	if !strings.Contains(issues[8].Message, `unknown key "colour"`) {
+		t.Errorf("unexpected warning message: %s", issues[8].Message)
+	}
+}
+
+// TestDiagnoseSources tests positions for profile values and issues from environment variables
+func TestDiagnoseSources(t *testing.T) {
+	path := writeTestConfig(t, `[database]
+path = "./test.db"
+
+[profiles.web.import]
+workers = 99
+`)
+	t.Setenv("ESDEDB_LANGUAGE", "xx")
+
+	_, issues, err := Diagnose(path, "web", testTables)
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %+v", issues)
+	}
+
+	workers := issues[0]
+	if workers.Key != "import.workers" || workers.Source.Kind != SourceProfile || workers.Line != 5 {
+		t.Errorf("expected profile issue at line 5, got %+v", workers)
+	}
+
+	language := issues[1]
+	if language.Key != "import.language" || language.Line != 0 {
+		t.Errorf("expected env issue without position, got %+v", language)
+	}
+	if loc := language.Location(path); loc != "env ESDEDB_LANGUAGE" {
+		t.Errorf("unexpected location %q", loc)
+	}
+
+	// Unbekanntes Profil
+	_, issues, err = Diagnose(path, "missing", testTables)
+	if err != nil || len(issues) == 0 || issues[0].Key != "profiles" {
+		t.Errorf("expected unknown profile issue, got %+v, %v", issues, err)
+	}
+}
+
+// TestDiagnoseDecodeErrors tests syntax and type errors
+func TestDiagnoseDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		key          string
+		line, column int
+	}{
+		{"syntax", "[database]\npath = \"unterminated\n", "database.path", 2, 21},
+		{"type", "[import]\nsde_path = \"./sde\"\nworkers = \"four\"\n", "import.workers", 3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, issues, err := Diagnose(writeTestConfig(t, tt.content), "", testTables)
+			if err != nil {
+				t.Fatalf("Diagnose failed: %v", err)
+			}
+			if cfg != nil || len(issues) != 1 {
+				t.Fatalf("expected a single decode issue and no config, got %+v", issues)
+			}
+			issue := issues[0]
+			if issue.Severity != SeverityError || issue.Key != tt.key || issue.Line != tt.line || issue.Column != tt.column {
+				t.Errorf("got %s %s %d:%d (%s)", issue.Severity, issue.Key, issue.Line, issue.Column, issue.Message)
+			}
+		})
+	}
+}
+
+// TestDiagnoseMissingFile tests that a missing file uses the defaults without issues
+func TestDiagnoseMissingFile(t *testing.T) {
+	cfg, issues, err := Diagnose(filepath.Join(t.TempDir(), "missing.toml"), "", testTables)
+	if err != nil || len(issues) != 0 || cfg == nil {
+		t.Errorf("expected defaults without issues, got %v, %+v", err, issues)
+	}
+}
+
+// TestKeyPositions tests the key locator with multi-line values, dotted and quoted keys
+func TestKeyPositions(t *testing.T) {
+	positions := keyPositions(`# comment
+top = 1
+[a]
+list = [
+  "x = 1",
+  [2],
+]
+text = """
+b = 2
+"""
+"quoted.key" = 'v'
+dotted.sub = { inner = 1 }
+
+  [[c.d]]
+  e = "#" # trailing
+`)
+	tests := []struct {
+		key          string
+		line, column int
+	}{
+		{"top", 2, 1},
+		{"a", 3, 1},
+		{"a.list", 4, 1},
+		{"a.text", 8, 1},
+		{"a.quoted.key", 11, 1},
+		{"a.dotted", 12, 1},
+		{"a.dotted.sub", 12, 1},
+		{"c.d", 14, 3},
+		{"c.d.e", 15, 3},
+	}
+	for _, tt := range tests {
+		pos, ok := positions[tt.key]
+		if !ok || pos.Line != tt.line || pos.Column != tt.column {
+			t.Errorf("%s: got %+v (found %v), want %d:%d", tt.key, pos, ok, tt.line, tt.column)
+		}
+	}
+	for _, key := range []string{"a.b", "b", "x"} {
+		if _, ok := positions[key]; ok {
+			t.Errorf("%s must not be recognized inside a multi-line value", key)
+		}
+	}
+
+	// Schlüssel in Inline-Tabellen erhalten die Position des übergeordneten Schlüssels
+	if pos, ok := lookup(positions, "a.dotted.sub.inner"); !ok || pos.Line != 12 {
+		t.Errorf("expected fallback to a.dotted.sub, got %+v", pos)
+	}
+}
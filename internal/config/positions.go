@@ -0,0 +1,188 @@
+package config
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// position ist die Stelle eines Schlüssels in der TOML-Datei (1-basiert)
+type position struct {
+	Line   int
+	Column int
+}
+
+// keyPositions ermittelt die Position jedes Schlüssels und Tabellen-Headers
+// in data, z.B. "import.workers" oder "tables.invTypes".
+//
+// Die BurntSushi-Metadaten enthalten keine Positionen, daher werden Header
+// ([a.b], [[a.b]]) und Zuweisungen (key = value, auch a.b = value) hier
+// zeilenweise erkannt. Mehrzeilige Strings und Arrays werden übersprungen;
+// Schlüssel in Inline-Tabellen erhalten keine eigene Position (siehe lookup).
+func keyPositions(data string) map[string]position {
+	positions := map[string]position{}
+	record := func(path []string, pos position) {
+		for i := range path {
+			key := strings.Join(path[:i+1], ".")
+			if _, ok := positions[key]; !ok {
+				positions[key] = pos
+			}
+		}
+	}
+
+	var table []string
+	var value valueScanner
+	for i, line := range strings.Split(data, "\n") {
+		if value.open() {
+			value.scan(line)
+			continue
+		}
+
+		trimmed := strings.TrimLeft(line, " \t")
+		pos := position{Line: i + 1, Column: utf8.RuneCountInString(line[:len(line)-len(trimmed)]) + 1}
+		switch {
+		case trimmed == "" || trimmed[0] == '#':
+			continue
+		case strings.HasPrefix(trimmed, "[["):
+			if path, rest, ok := parseKey(trimmed[2:]); ok && strings.HasPrefix(rest, "]]") {
+				table = path
+				record(table, pos)
+			}
+		case trimmed[0] == '[':
+			if path, rest, ok := parseKey(trimmed[1:]); ok && strings.HasPrefix(rest, "]") {
+				table = path
+				record(table, pos)
+			}
+		default:
+			path, rest, ok := parseKey(trimmed)
+			if !ok || !strings.HasPrefix(rest, "=") {
+				continue
+			}
+			record(append(append([]string(nil), table...), path...), pos)
+			value.scan(rest[1:])
+		}
+	}
+	return positions
+}
+
+// lookup liefert die Position von key oder – falls key selbst nicht
+// gefunden wird (z.B. in einer Inline-Tabelle) – die des nächsten
+// übergeordneten Schlüssels.
+func lookup(positions map[string]position, key string) (position, bool) {
+	for key != "" {
+		if pos, ok := positions[key]; ok {
+			return pos, true
+		}
+		i := strings.LastIndex(key, ".")
+		if i < 0 {
+			break
+		}
+		key = key[:i]
+	}
+	return position{}, false
+}
+
+// parseKey liest einen (ggf. gepunkteten, gequoteten) Schlüssel am Anfang
+// von s und liefert seine Teile und den Rest nach dem Schlüssel.
+func parseKey(s string) ([]string, string, bool) {
+	var parts []string
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if s == "" {
+			return nil, "", false
+		}
+
+		var part string
+		switch s[0] {
+		case '"':
+			end := 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				return nil, "", false
+			}
+			part, s = s[1:end], s[end+1:]
+		case '\'':
+			end := strings.IndexByte(s[1:], '\'')
+			if end < 0 {
+				return nil, "", false
+			}
+			part, s = s[1:end+1], s[end+2:]
+		default:
+			end := 0
+			for end < len(s) && isBareKeyChar(s[end]) {
+				end++
+			}
+			if end == 0 {
+				return nil, "", false
+			}
+			part, s = s[:end], s[end:]
+		}
+		parts = append(parts, part)
+
+		s = strings.TrimLeft(s, " \t")
+		if !strings.HasPrefix(s, ".") {
+			return parts, s, true
+		}
+		s = s[1:]
+	}
+}
+
+// isBareKeyChar prüft, ob c in einem ungequoteten TOML-Schlüssel vorkommen darf
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+// valueScanner verfolgt, ob ein Wert über das Zeilenende hinaus geht
+// (mehrzeilige Strings, Arrays und Inline-Tabellen).
+type valueScanner struct {
+	depth     int    // offene [ bzw. {
+	multiline string // offener mehrzeiliger String (`"""` oder `'''`)
+}
+
+// open prüft, ob der zuletzt gelesene Wert noch nicht abgeschlossen ist
+func (v *valueScanner) open() bool {
+	return v.depth > 0 || v.multiline != ""
+}
+
+// scan liest eine (Teil-)Zeile eines Werts
+func (v *valueScanner) scan(line string) {
+	for i := 0; i < len(line); i++ {
+		if v.multiline != "" {
+			end := strings.Index(line[i:], v.multiline)
+			if end < 0 {
+				return
+			}
+			i += end + len(v.multiline) - 1
+			v.multiline = ""
+			continue
+		}
+
+		switch c := line[i]; c {
+		case '#':
+			return
+		case '[', '{':
+			v.depth++
+		case ']', '}':
+			if v.depth > 0 {
+				v.depth--
+			}
+		case '"', '\'':
+			quote := string(c)
+			if strings.HasPrefix(line[i:], quote+quote+quote) {
+				v.multiline = quote + quote + quote
+				i += 2
+				continue
+			}
+			// einzeiliger String bis zum schließenden Quote
+			for i++; i < len(line) && line[i] != c; i++ {
+				if c == '"' && line[i] == '\\' {
+					i++
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// profilesFile enthält die [profiles.<name>] Abschnitte einer Konfigurationsdatei.
+//
+// Ein Profil hat denselben Aufbau wie die Basis-Konfiguration
+// ([profiles.slim.database], [profiles.slim.import], ...) und überschreibt
+// nur die darin gesetzten Schlüssel.
+type profilesFile struct {
+	Profiles map[string]toml.Primitive `toml:"profiles"`
+}
+
+// profileTables enthält die [profiles.<name>.tables.<tabelle>] Abschnitte eines Profils
+type profileTables struct {
+	Tables map[string]toml.Primitive `toml:"tables"`
+}
+
+// applyProfile überschreibt cfg mit den Werten des Profils name aus
+// configPath und vermerkt die gesetzten Schlüssel als SourceProfile.
+func applyProfile(cfg *Config, sources Sources, configPath, name string) error {
+	if !containsString(cfg.Profiles, name) {
+		return fmt.Errorf("unknown profile %q (available: %s)", name, profileList(cfg.Profiles))
+	}
+
+	var file profilesFile
+	md, err := toml.DecodeFile(configPath, &file)
+	if err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	base := make(map[string]TableConfig, len(cfg.Tables))
+	for table, tc := range cfg.Tables {
+		base[table] = tc
+	}
+	if err := md.PrimitiveDecode(file.Profiles[name], cfg); err != nil {
+		return fmt.Errorf("failed to parse profile %q: %w", name, err)
+	}
+
+	// [profiles.<name>.tables.<tabelle>] überschreibt nur die gesetzten
+	// Schlüssel der Tabelle (der Map-Decoder ersetzt sonst den ganzen Eintrag)
+	var tables profileTables
+	if err := md.PrimitiveDecode(file.Profiles[name], &tables); err != nil {
+		return fmt.Errorf("failed to parse profile %q: %w", name, err)
+	}
+	for table, prim := range tables.Tables {
+		merged := base[table]
+		if err := md.PrimitiveDecode(prim, &merged); err != nil {
+			return fmt.Errorf("failed to parse profile %q: %w", name, err)
+		}
+		cfg.Tables[table] = merged
+	}
+
+	prefix := "profiles." + name + "."
+	for _, key := range md.Keys() {
+		if k := key.String(); strings.HasPrefix(k, prefix) {
+			sources.set(strings.TrimPrefix(k, prefix), SourceProfile, name)
+		}
+	}
+	cfg.Profile = name
+	return nil
+}
+
+// profileNames liefert die Namen aller Profile in md (sortiert). Profile
+// können auch nur implizit über Untertabellen wie [profiles.web.database]
+// definiert sein.
+func profileNames(md toml.MetaData) []string {
+	var names []string
+	for _, key := range md.Keys() {
+		if len(key) >= 2 && key[0] == "profiles" && !containsString(names, key[1]) {
+			names = append(names, key[1])
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// profileList formatiert Profilnamen für Fehlermeldungen
+func profileList(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
+
+// containsString prüft, ob values s enthält
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold meldet, ob values s ohne Beachtung der Groß-/Kleinschreibung enthält
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
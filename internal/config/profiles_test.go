@@ -0,0 +1,76 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const profilesTestConfig = `
+[database]
+path = "./full.db"
+
+[import]
+tables = ["inventory", "dogma"]
+
+[profiles.web.database]
+path = "./web.db"
+journal_mode = "DELETE"
+
+[profiles.web.import]
+tables = ["inventory"]
+language = "de"
+
+[profiles.universe.import]
+tables = ["universe"]
+`
+
+// TestLoadWithSources_Profile testet, dass ein Profil nur die gesetzten Schlüssel überschreibt
+func TestLoadWithSources_Profile(t *testing.T) {
+	path := writeTestConfig(t, profilesTestConfig)
+
+	cfg, sources, err := LoadWithSources(path, "web", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Profile != "web" || strings.Join(cfg.Profiles, ",") != "universe,web" {
+		t.Errorf("unexpected profile info: %q %v", cfg.Profile, cfg.Profiles)
+	}
+	if cfg.Database.Path != "./web.db" || cfg.Database.JournalMode != "DELETE" || cfg.Import.Language != "de" {
+		t.Errorf("expected profile values, got %+v %+v", cfg.Database, cfg.Import)
+	}
+	if len(cfg.Import.Tables) != 1 || cfg.Import.Tables[0] != "inventory" {
+		t.Errorf("expected profile tables, got %v", cfg.Import.Tables)
+	}
+	if cfg.Database.CacheSizeMB != 64 {
+		t.Errorf("expected unset keys to keep base values, got cache_size_mb %d", cfg.Database.CacheSizeMB)
+	}
+	if got := sources.Get("database.journal_mode").String(); got != "profile web" {
+		t.Errorf("expected profile source, got %q", got)
+	}
+
+	// Environment Variables überschreiben das Profil
+	t.Setenv("ESDEDB_DATABASE_PATH", "./env.db")
+	cfg, _, err = LoadWithSources(path, "universe", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Database.Path != "./env.db" || cfg.Import.Tables[0] != "universe" {
+		t.Errorf("expected env path and universe tables, got %s %v", cfg.Database.Path, cfg.Import.Tables)
+	}
+}
+
+// TestLoadWithSources_UnknownProfile testet die Fehlermeldung für unbekannte Profile
+func TestLoadWithSources_UnknownProfile(t *testing.T) {
+	path := writeTestConfig(t, profilesTestConfig)
+
+	_, _, err := LoadWithSources(path, "slim", nil)
+	if err == nil || !strings.Contains(err.Error(), "available: universe, web") {
+		t.Errorf("expected unknown profile error listing profiles, got %v", err)
+	}
+
+	_, _, err = LoadWithSources(filepath.Join(t.TempDir(), "missing.toml"), "web", nil)
+	if err == nil || !strings.Contains(err.Error(), "available: none") {
+		t.Errorf("expected error without config file, got %v", err)
+	}
+}
@@ -0,0 +1,260 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// schemaHint ergänzt das aus den Struct-Feldern erzeugte Schema eines
+// Schlüssels. Schlüssel von Tabellen-Abschnitten stehen als "tables.*.<key>".
+type schemaHint struct {
+	Description string
+	Enum        []interface{}
+	Minimum     *int
+	Maximum     *int
+	Length      int // exakte Länge in Zeichen (0 = beliebig)
+}
+
+// intPtr liefert einen Zeiger auf v (für schemaHint.Minimum/Maximum)
+func intPtr(v int) *int {
+	return &v
+}
+
+// stringEnum wandelt values in Enum-Werte um
+func stringEnum(values ...string) []interface{} {
+	enum := make([]interface{}, len(values))
+	for i, v := range values {
+		enum[i] = v
+	}
+	return enum
+}
+
+// foldedEnum liefert values in Groß- und Kleinschreibung (für Werte, die
+// case-insensitiv verglichen werden)
+func foldedEnum(values []string) []interface{} {
+	var enum []interface{}
+	for _, v := range values {
+		enum = append(enum, strings.ToUpper(v), strings.ToLower(v))
+	}
+	return enum
+}
+
+// pageSizes sind die erlaubten Werte für database.page_size (0 = SQLite-Default)
+func pageSizes() []interface{} {
+	sizes := []interface{}{0}
+	for size := 512; size <= 65536; size *= 2 {
+		sizes = append(sizes, size)
+	}
+	return sizes
+}
+
+// schemaHints beschreibt die Schlüssel der Konfiguration für JSONSchema
+var schemaHints = map[string]schemaHint{
+	"version":  {Description: "Configuration format version"},
+	"database": {Description: "SQLite database settings"},
+	"database.path": {
+		Description: "Path of the SQLite database file",
+	},
+	"database.journal_mode": {
+		Description: "PRAGMA journal_mode",
+		Enum:        foldedEnum(validJournalModes),
+	},
+	"database.synchronous": {
+		Description: "PRAGMA synchronous",
+		Enum:        foldedEnum(validSynchronousLevels),
+	},
+	"database.cache_size_mb":   {Description: "Page cache in MB (0 = default 64)", Minimum: intPtr(0)},
+	"database.mmap_size_mb":    {Description: "Memory-mapped I/O in MiB (0 = disabled)", Minimum: intPtr(0)},
+	"database.busy_timeout_ms": {Description: "Wait time for a locked database in milliseconds", Minimum: intPtr(0)},
+	"database.page_size": {
+		Description: "Page size in bytes, power of two 512-65536 (0 = SQLite default; new databases only)",
+		Enum:        pageSizes(),
+	},
+	"database.read_only": {Description: "Open the database with mode=ro (not allowed for import)"},
+	"database.immutable": {Description: "Open the database with immutable=1, implies read_only"},
+
+	"import":          {Description: "JSONL import settings"},
+	"import.sde_path": {Description: "Directory of the JSONL SDE"},
+	"import.language": {
+		Description: "Language of translated texts",
+		Enum:        stringEnum(validLanguages...),
+	},
+	"import.workers": {Description: "Parallel parse workers (0 = number of CPUs)", Minimum: intPtr(0), Maximum: intPtr(32)},
+	"import.tables": {
+		Description: "Tables to import: glob patterns (e.g. \"map*\") or groups (e.g. \"universe\"); empty = all tables",
+	},
+	"import.exclude_tables":           {Description: "Tables excluded from the import: glob patterns or groups"},
+	"import.finalize":                 {Description: "Finalize phase after the import (analyze, optimize and checkpoint on by default)"},
+	"import.finalize.analyze":         {Description: "Run ANALYZE to collect query planner statistics (--analyze, --finalize)"},
+	"import.finalize.optimize":        {Description: "Run PRAGMA optimize (--optimize, --finalize)"},
+	"import.finalize.checkpoint":      {Description: "Run a WAL checkpoint with truncate (--checkpoint, --finalize)"},
+	"import.finalize.vacuum":          {Description: "Run VACUUM to release free pages (--vacuum)"},
+	"import.finalize.vacuum_into":     {Description: "Write a compacted copy to this file (--vacuum-into; empty = none)"},
+	"import.finalize.integrity_check": {Description: "Run PRAGMA integrity_check; the import fails if it is not ok (--integrity-check)"},
+
+	"logging":        {Description: "Logging settings"},
+	"logging.level":  {Description: "Log level", Enum: stringEnum(validLogLevels...)},
+	"logging.format": {Description: "Log output format", Enum: stringEnum(validLogFormats...)},
+
+	"update":           {Description: "Update check (not used yet)"},
+	"update.enabled":   {Description: "Check for new versions"},
+	"update.check_url": {Description: "URL of the update check"},
+
+	"export":                         {Description: "Export targets"},
+	"export.csv":                     {Description: "CSV export"},
+	"export.csv.path":                {Description: "Output directory (one file per table)"},
+	"export.csv.delimiter":           {Description: "Field delimiter, exactly one character (not a quote or line break)", Length: 1},
+	"export.csv.decimal_comma":       {Description: "Write decimals with a comma (European spreadsheets)"},
+	"export.csv.header":              {Description: "Write a header row with the column names"},
+	"export.csv.null":                {Description: "Representation of NULL values (default: empty field)"},
+	"export.csv.gzip":                {Description: "Compress the files (<table>.csv.gz)"},
+	"export.csv.tables":              {Description: "Tables to export: glob patterns or groups as in import.tables; empty = all tables"},
+	"export.csv.exclude_tables":      {Description: "Tables excluded from the export: glob patterns or groups"},
+	"export.postgres":                {Description: "PostgreSQL dump (schema and COPY data in one .sql file)"},
+	"export.postgres.path":           {Description: "Output file, loadable with psql -f"},
+	"export.postgres.schema":         {Description: "PostgreSQL schema for the tables (empty = search_path, usually public)"},
+	"export.postgres.drop_tables":    {Description: "Drop existing tables before creating them"},
+	"export.postgres.tables":         {Description: "Tables to export: glob patterns or groups as in import.tables; empty = all tables"},
+	"export.postgres.exclude_tables": {Description: "Tables excluded from the export: glob patterns or groups"},
+
+	"tables":              {Description: "Per-table import settings ([tables.<name>])"},
+	"tables.*":            {Description: "Import settings of one table"},
+	"tables.*.batch_size": {Description: "Rows per INSERT statement (0 = default 1000)", Minimum: intPtr(0)},
+	"tables.*.error_mode": {
+		Description: "fail_fast (default) or skip: skip malformed lines",
+		Enum:        stringEnum(validErrorModes...),
+	},
+	"tables.*.max_errors": {
+		Description: "With error_mode = \"skip\": fail the file after N malformed lines (0 = unlimited)",
+		Minimum:     intPtr(0),
+	},
+	"tables.*.on_conflict": {
+		Description: "Rows with duplicate keys: abort (default), ignore or replace",
+		Enum:        stringEnum(validConflictStrategies...),
+	},
+	"tables.*.skip": {Description: "Do not import this table"},
+}
+
+// JSONSchema liefert ein JSON Schema (Draft 07) für config.toml, z.B. für
+// Autovervollständigung und Prüfung in Editoren.
+//
+// Das Schema wird aus den toml-Tags von Config erzeugt; Beschreibungen,
+// erlaubte Werte und Grenzen stammen aus schemaHints, Defaults aus
+// DefaultConfig. Unbekannte Schlüssel sind wie bei Diagnose nicht erlaubt;
+// als Tabellen-Abschnitte ([tables.<name>]) sind nur die vom Aufrufer
+// übergebenen registrierten Tabellen knownTables erlaubt (nil = beliebige).
+func JSONSchema(knownTables []string) ([]byte, error) {
+	defaults := map[string]interface{}{}
+	defaultConfig := DefaultConfig()
+	for _, f := range defaultConfig.Fields() {
+		defaults[f.Key] = f.Value
+	}
+
+	definitions := map[string]interface{}{}
+	properties := map[string]interface{}{}
+	sections := map[string]interface{}{}
+
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("toml")
+		if name == "" || name == "-" {
+			continue
+		}
+		ft := t.Field(i).Type
+		if ft.Kind() != reflect.Struct && ft.Kind() != reflect.Map {
+			properties[name] = schemaFor(ft, name, defaults, definitions)
+			continue
+		}
+		definition := schemaFor(ft, name, defaults, definitions)
+		if ft.Kind() == reflect.Map && knownTables != nil {
+			definition["propertyNames"] = map[string]interface{}{"enum": knownTables}
+		}
+		definitions[name] = definition
+		ref := map[string]interface{}{"$ref": "#/definitions/" + name}
+		properties[name] = ref
+		sections[name] = ref
+	}
+
+	definitions["profile"] = map[string]interface{}{
+		"type":                 "object",
+		"description":          "Profile: overrides only the keys it sets (select with --profile <name>)",
+		"properties":           sections,
+		"additionalProperties": false,
+	}
+	properties["profiles"] = map[string]interface{}{
+		"type":                 "object",
+		"description":          "Named variants of this configuration ([profiles.<name>])",
+		"additionalProperties": map[string]interface{}{"$ref": "#/definitions/profile"},
+	}
+
+	schema := map[string]interface{}{
+		"$schema":              "http://json-schema.org/draft-07/schema#",
+		"title":                "EVE SDE Database Builder configuration (config.toml)",
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+		"definitions":          definitions,
+	}
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JSON schema: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// schemaFor erzeugt das Schema für einen Wert vom Typ t unter key.
+// Map-Einträge (Tabellen-Abschnitte) werden als Definition "table" abgelegt.
+func schemaFor(t reflect.Type, key string, defaults, definitions map[string]interface{}) map[string]interface{} {
+	schema := map[string]interface{}{}
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		for i := 0; i < t.NumField(); i++ {
+			name := t.Field(i).Tag.Get("toml")
+			if name == "" || name == "-" {
+				continue
+			}
+			properties[name] = schemaFor(t.Field(i).Type, key+"."+name, defaults, definitions)
+		}
+		schema["type"] = "object"
+		schema["properties"] = properties
+		schema["additionalProperties"] = false
+	case reflect.Map:
+		definitions["table"] = schemaFor(t.Elem(), key+".*", defaults, definitions)
+		schema["type"] = "object"
+		schema["additionalProperties"] = map[string]interface{}{"$ref": "#/definitions/table"}
+	case reflect.Slice:
+		schema["type"] = "array"
+		schema["items"] = map[string]interface{}{"type": "string"}
+	case reflect.String:
+		schema["type"] = "string"
+	case reflect.Int:
+		schema["type"] = "integer"
+	case reflect.Bool:
+		schema["type"] = "boolean"
+	}
+
+	hint := schemaHints[key]
+	if hint.Description != "" {
+		schema["description"] = hint.Description
+	}
+	if hint.Enum != nil {
+		schema["enum"] = hint.Enum
+	}
+	if hint.Minimum != nil {
+		schema["minimum"] = *hint.Minimum
+	}
+	if hint.Maximum != nil {
+		schema["maximum"] = *hint.Maximum
+	}
+	if hint.Length > 0 {
+		schema["minLength"] = hint.Length
+		schema["maxLength"] = hint.Length
+	}
+	if value, ok := defaults[key]; ok && t.Kind() != reflect.Slice {
+		schema["default"] = value
+	}
+	return schema
+}
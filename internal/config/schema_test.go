@@ -0,0 +1,115 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+)
+
+var updateSchema = flag.Bool("update", false, "update config.schema.json")
+
+// schemaFile ist das eingecheckte Schema im Repository-Root
+var schemaFile = filepath.Join("..", "..", "config.schema.json")
+
+// registeredTables liefert die Tabellennamen aller registrierten Parser
+// (sortiert), wie sie "esdedb config schema" übergibt
+func registeredTables() []string {
+	var tables []string
+	for _, p := range parser.RegisterParsers() {
+		tables = append(tables, p.TableName())
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// TestJSONSchemaUpToDate prüft, dass config.schema.json dem generierten Schema entspricht
+func TestJSONSchemaUpToDate(t *testing.T) {
+	schema, err := JSONSchema(registeredTables())
+	if err != nil {
+		t.Fatalf("JSONSchema failed: %v", err)
+	}
+	if *updateSchema {
+		if err := os.WriteFile(schemaFile, schema, 0644); err != nil {
+			t.Fatalf("failed to update %s: %v", schemaFile, err)
+		}
+	}
+	want, err := os.ReadFile(schemaFile)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", schemaFile, err)
+	}
+	if !bytes.Equal(schema, want) {
+		t.Errorf("%s is outdated; run: go test ./internal/config -run TestJSONSchemaUpToDate -update", schemaFile)
+	}
+}
+
+// TestJSONSchemaCoversFields prüft, dass das Schema jeden Config-Schlüssel beschreibt
+func TestJSONSchemaCoversFields(t *testing.T) {
+	data, err := JSONSchema(registeredTables())
+	if err != nil {
+		t.Fatalf("JSONSchema failed: %v", err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+	definitions := schema["definitions"].(map[string]interface{})
+
+	// resolve folgt $ref-Verweisen auf definitions
+	resolve := func(node map[string]interface{}) map[string]interface{} {
+		if ref, ok := node["$ref"].(string); ok {
+			return definitions[strings.TrimPrefix(ref, "#/definitions/")].(map[string]interface{})
+		}
+		return node
+	}
+
+	cfg := DefaultConfig()
+	cfg.Tables = map[string]TableConfig{"invTypes": {}}
+	for _, field := range cfg.Fields() {
+		node := schema
+		for _, part := range strings.Split(field.Key, ".") {
+			node = resolve(node)
+			if props, ok := node["properties"].(map[string]interface{}); ok {
+				next, ok := props[part].(map[string]interface{})
+				if !ok {
+					t.Errorf("schema has no property for %s", field.Key)
+					break
+				}
+				node = next
+				continue
+			}
+			// Tabellen-Abschnitte: Name über propertyNames, Inhalt über additionalProperties
+			names := node["propertyNames"].(map[string]interface{})["enum"].([]interface{})
+			if !containsValue(names, part) {
+				t.Errorf("schema does not allow table %q", part)
+			}
+			node = node["additionalProperties"].(map[string]interface{})
+		}
+		if _, ok := resolve(node)["description"]; !ok {
+			t.Errorf("schema property %s has no description", field.Key)
+		}
+	}
+
+	profile := definitions["profile"].(map[string]interface{})["properties"].(map[string]interface{})
+	for _, section := range []string{"database", "import", "logging", "update", "export", "tables"} {
+		if _, ok := profile[section]; !ok {
+			t.Errorf("profile schema is missing section %s", section)
+		}
+	}
+}
+
+// containsValue prüft, ob values v enthält
+func containsValue(values []interface{}, v interface{}) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
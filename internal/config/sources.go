@@ -0,0 +1,177 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+)
+
+// SourceKind beschreibt, woher ein Config-Wert stammt
+type SourceKind string
+
+// Quellen in aufsteigender Priorität
+const (
+	SourceDefault SourceKind = "default"
+	SourceFile    SourceKind = "file"
+	SourceProfile SourceKind = "profile"
+	SourceEnv     SourceKind = "env"
+	SourceFlag    SourceKind = "flag"
+)
+
+// Source ist die Herkunft eines einzelnen Config-Werts
+type Source struct {
+	Kind SourceKind
+	// Name ist der Dateipfad, das Profil, die Environment Variable bzw. das
+	// Flag (leer bei default)
+	Name string
+	// Auto markiert Werte, die Validate() automatisch ermittelt hat (import.workers = 0)
+	Auto bool
+}
+
+// String liefert die Quelle lesbar, z.B. "env ESDEDB_LOG_LEVEL" oder "default, auto"
+func (s Source) String() string {
+	str := string(s.Kind)
+	if s.Name != "" {
+		str += " " + s.Name
+	}
+	if s.Auto {
+		str += ", auto"
+	}
+	return str
+}
+
+// Sources ordnet Config-Schlüsseln (z.B. "database.path") ihre Quelle zu.
+// Nicht enthaltene Schlüssel stammen aus den Defaults.
+type Sources map[string]Source
+
+// Get liefert die Quelle für key (SourceDefault, falls nicht gesetzt)
+func (s Sources) Get(key string) Source {
+	if src, ok := s[key]; ok {
+		return src
+	}
+	return Source{Kind: SourceDefault}
+}
+
+// set speichert die Quelle für key; nil-Maps werden ignoriert
+func (s Sources) set(key string, kind SourceKind, name string) {
+	if s != nil {
+		s[key] = Source{Kind: kind, Name: name}
+	}
+}
+
+// Field ist ein einzelner Config-Wert mit vollständigem Schlüssel
+type Field struct {
+	Key   string      // z.B. "database.path"
+	Value interface{} // string, int, bool oder []string
+}
+
+// Fields liefert alle Config-Werte in der Reihenfolge der Struct-Felder.
+// Die Schlüssel entsprechen den TOML-Schlüsseln ("version", "database.path",
+// "tables.invTypes.batch_size", ...); Tabellen-Abschnitte sind nach Namen sortiert.
+func (c *Config) Fields() []Field {
+	var fields []Field
+	collectFields(reflect.ValueOf(*c), "", &fields)
+	return fields
+}
+
+// collectFields sammelt die Felder von v rekursiv anhand der toml-Tags
+func collectFields(v reflect.Value, prefix string, fields *[]Field) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("toml")
+		if name == "" || name == "-" {
+			continue
+		}
+		key := prefix + name
+		switch t.Field(i).Type.Kind() {
+		case reflect.Struct:
+			collectFields(v.Field(i), key+".", fields)
+			continue
+		case reflect.Map:
+			// Tabellen-Abschnitte ([tables.<name>]) nach Namen sortiert
+			m := v.Field(i)
+			names := make([]string, 0, m.Len())
+			for _, k := range m.MapKeys() {
+				names = append(names, k.String())
+			}
+			sort.Strings(names)
+			for _, n := range names {
+				collectFields(m.MapIndex(reflect.ValueOf(n)), key+"."+n+".", fields)
+			}
+			continue
+		}
+		*fields = append(*fields, Field{Key: key, Value: v.Field(i).Interface()})
+	}
+}
+
+// LoadWithSources arbeitet wie LoadWithOverrides und liefert zusätzlich die
+// Quelle jedes Werts.
+//
+// Ist profile gesetzt, überschreibt der Abschnitt [profiles.<profile>] der
+// Datei die Basis-Werte (vor Environment Variables und Overrides); ein
+// unbekanntes Profil ist ein Fehler.
+//
+// override erhält die Sources-Map und trägt dort die Schlüssel ein, die es
+// überschreibt (z.B. mit SourceFlag). Hat Validate() import.workers aus 0
+// (Auto) ermittelt, ist die Quelle von import.workers als Auto markiert.
+func LoadWithSources(configPath, profile string, override func(*Config, Sources) error) (*Config, Sources, error) {
+	// 1. Default Config + 2. TOML laden (falls vorhanden)
+	cfg, sources, err := LoadFile(configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 2b. Profil anwenden
+	if profile != "" {
+		if err := applyProfile(&cfg, sources, configPath, profile); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 3. Environment Variables überschreiben
+	applyEnvVars(&cfg, sources)
+
+	// 4. CLI Flags (bzw. andere Overrides des Aufrufers)
+	if override != nil {
+		if err := override(&cfg, sources); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 5. Validierung (löst import.workers = 0 auf)
+	autoWorkers := cfg.Import.Workers == 0
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if autoWorkers {
+		src := sources.Get("import.workers")
+		src.Auto = true
+		sources["import.workers"] = src
+	}
+
+	return &cfg, sources, nil
+}
+
+// LoadFile lädt Defaults und die TOML-Datei (falls vorhanden) ohne
+// Environment Variables, Overrides und Validierung und vermerkt
+// alle in der Datei gesetzten Schlüssel als SourceFile.
+func LoadFile(configPath string) (Config, Sources, error) {
+	cfg := DefaultConfig()
+	sources := Sources{}
+
+	if _, err := os.Stat(configPath); err == nil {
+		md, err := toml.DecodeFile(configPath, &cfg)
+		if err != nil {
+			return cfg, nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+		for _, key := range md.Keys() {
+			sources.set(key.String(), SourceFile, configPath)
+		}
+		cfg.Profiles = profileNames(md)
+	}
+
+	return cfg, sources, nil
+}
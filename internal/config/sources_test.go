@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestLoadWithSources testet die Quellen aus Datei, Env, Override und Auto-Workers
+func TestLoadWithSources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := "[database]\npath = \"./file.db\"\n\n[import]\nlanguage = \"de\"\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	t.Setenv("ESDEDB_LOG_LEVEL", "warn")
+	t.Setenv("ESDEDB_LANGUAGE", "fr")
+
+	cfg, sources, err := LoadWithSources(path, "", func(cfg *Config, sources Sources) error {
+		cfg.Import.SDEPath = "./flag-sde"
+		sources["import.sde_path"] = Source{Kind: SourceFlag, Name: "--sde-dir"}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := map[string]string{
+		"database.path":         "file " + path,
+		"database.journal_mode": "default",
+		"import.language":       "env ESDEDB_LANGUAGE",
+		"logging.level":         "env ESDEDB_LOG_LEVEL",
+		"import.sde_path":       "flag --sde-dir",
+		"import.workers":        "default, auto",
+	}
+	for key, want := range tests {
+		if got := sources.Get(key).String(); got != want {
+			t.Errorf("source of %s = %q, want %q", key, got, want)
+		}
+	}
+	if cfg.Import.Workers != runtime.NumCPU() || cfg.Import.Language != "fr" {
+		t.Errorf("unexpected resolved config: %+v", cfg.Import)
+	}
+}
+
+// TestConfigFields testet die Schlüssel von Fields()
+func TestConfigFields(t *testing.T) {
+	cfg := DefaultConfig()
+	fields := cfg.Fields()
+
+	if len(fields) == 0 || fields[0].Key != "version" {
+		t.Fatalf("expected version as first field, got %+v", fields)
+	}
+	keys := map[string]interface{}{}
+	for _, f := range fields {
+		keys[f.Key] = f.Value
+	}
+	for _, key := range []string{"database.path", "database.busy_timeout_ms", "import.exclude_tables", "logging.format", "update.check_url"} {
+		if _, ok := keys[key]; !ok {
+			t.Errorf("missing field %s", key)
+		}
+	}
+	if keys["database.cache_size_mb"] != 64 {
+		t.Errorf("expected cache_size_mb 64, got %v", keys["database.cache_size_mb"])
+	}
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestConfig schreibt content als config.toml in ein temporäres Verzeichnis
+func writeTestConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+// testTables sind die registrierten Tabellen für CheckTableNames und Diagnose
+var testTables = []string{"invTypes", "mapMoons", "skins"}
+
+// TestLoadWithSources_Tables testet [tables.<name>] Abschnitte inkl. Quellen und Fields
+func TestLoadWithSources_Tables(t *testing.T) {
+	path := writeTestConfig(t, `
+[tables.mapMoons]
+batch_size = 250
+error_mode = "skip"
+max_errors = 10
+
+[tables.invTypes]
+on_conflict = "replace"
+
+[tables.skins]
+skip = true
+`)
+
+	cfg, sources, err := LoadWithSources(path, "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	moons := cfg.Tables["mapMoons"]
+	if moons.BatchSize != 250 || moons.ErrorMode != "skip" || moons.MaxErrors != 10 {
+		t.Errorf("unexpected mapMoons settings: %+v", moons)
+	}
+	if !cfg.Tables["skins"].Skip || cfg.Tables["invTypes"].OnConflict != "replace" {
+		t.Errorf("unexpected table settings: %+v", cfg.Tables)
+	}
+	if got := sources.Get("tables.mapMoons.batch_size").Kind; got != SourceFile {
+		t.Errorf("expected file source, got %q", got)
+	}
+
+	var keys []string
+	for _, f := range cfg.Fields() {
+		if strings.HasPrefix(f.Key, "tables.") {
+			keys = append(keys, f.Key)
+		}
+	}
+	if len(keys) != 15 || keys[0] != "tables.invTypes.batch_size" || keys[14] != "tables.skins.skip" {
+		t.Errorf("expected sorted table fields, got %v", keys)
+	}
+}
+
+// TestValidationInvalidTables testet die Validierung der Tabellen-Einstellungen
+func TestValidationInvalidTables(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"negative batch size", "[tables.invTypes]\nbatch_size = -1", "batch_size"},
+		{"invalid error mode", "[tables.invTypes]\nerror_mode = \"ignore\"", "invalid error mode"},
+		{"max errors without skip", "[tables.invTypes]\nmax_errors = 5", "max_errors requires"},
+		{"invalid conflict", "[tables.invTypes]\non_conflict = \"update\"", "invalid conflict strategy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeTestConfig(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestCheckTableNames testet, dass nur Tabellen außerhalb von known gemeldet
+// werden und Load die Tabellennamen nicht prüft
+func TestCheckTableNames(t *testing.T) {
+	cfg, err := Load(writeTestConfig(t, "[tables.noSuchTable]\nskip = true\n\n[tables.invTypes]\nskip = true"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	issues := cfg.CheckTableNames(testTables)
+	if len(issues) != 1 || issues[0].Key != "tables.noSuchTable" || !strings.Contains(issues[0].Message, "unknown table") {
+		t.Errorf("expected unknown table issue for noSuchTable, got %v", issues)
+	}
+}
+
+// TestLoadWithSources_ProfileTables testet, dass ein Profil einzelne
+// Tabellen-Schlüssel überschreibt
+func TestLoadWithSources_ProfileTables(t *testing.T) {
+	path := writeTestConfig(t, `
+[tables.mapMoons]
+batch_size = 250
+error_mode = "skip"
+
+[profiles.slim.tables.mapMoons]
+skip = true
+
+[profiles.slim.tables.skins]
+skip = true
+`)
+
+	cfg, sources, err := LoadWithSources(path, "slim", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	moons := cfg.Tables["mapMoons"]
+	if !moons.Skip || moons.BatchSize != 250 || moons.ErrorMode != "skip" {
+		t.Errorf("expected merged mapMoons settings, got %+v", moons)
+	}
+	if !cfg.Tables["skins"].Skip {
+		t.Errorf("expected skins to be skipped, got %+v", cfg.Tables)
+	}
+	if got := sources.Get("tables.mapMoons.skip").String(); got != "profile slim" {
+		t.Errorf("expected profile source, got %q", got)
+	}
+
+	// Ohne Profil bleibt die Basis-Konfiguration unverändert
+	base, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if base.Tables["mapMoons"].Skip || len(base.Tables) != 1 {
+		t.Errorf("expected base tables without profile, got %+v", base.Tables)
+	}
+}
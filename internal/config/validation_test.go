@@ -1,8 +1,14 @@
 package config
 
 import (
+	"reflect"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
 )
 
 // TestValidationValidConfig tests that a completely valid configuration passes validation
@@ -55,3 +61,151 @@ func TestValidationSDEPathNotExists(t *testing.T) {
 		}
 	})
 }
+
+// TestValidationTableFilters tests table include/exclude pattern validation
+func TestValidationTableFilters(t *testing.T) {
+	cfg, err := Load("testdata/table-filter.toml")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if len(cfg.Import.Tables) != 2 || len(cfg.Import.ExcludeTables) != 1 {
+		t.Errorf("unexpected table filters: %v / %v", cfg.Import.Tables, cfg.Import.ExcludeTables)
+	}
+
+	cfg2 := DefaultConfig()
+	cfg2.Import.Tables = []string{"inv["}
+	if err := cfg2.Validate(); err == nil {
+		t.Error("expected validation error for invalid import.tables pattern")
+	}
+
+	cfg3 := DefaultConfig()
+	cfg3.Import.ExcludeTables = []string{"[a-"}
+	if err := cfg3.Validate(); err == nil {
+		t.Error("expected validation error for invalid import.exclude_tables pattern")
+	}
+}
+
+// TestValidationDatabaseOptions tests loading and validation of the SQLite options
+func TestValidationDatabaseOptions(t *testing.T) {
+	cfg, err := Load("testdata/database-options.toml")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	d := cfg.Database
+	if d.JournalMode != "DELETE" || d.Synchronous != "FULL" || d.CacheSizeMB != 32 {
+		t.Errorf("unexpected journal/synchronous/cache options: %+v", d)
+	}
+	if d.MmapSizeMB != 256 || d.BusyTimeoutMS != 10000 || d.PageSize != 8192 {
+		t.Errorf("unexpected mmap/busy timeout/page size options: %+v", d)
+	}
+	if !d.ReadOnly || d.Immutable {
+		t.Errorf("expected read-only, not immutable: %+v", d)
+	}
+
+	defaults := DefaultConfig().Database
+	want := database.DefaultOptions()
+	if defaults.JournalMode != want.JournalMode || defaults.Synchronous != want.Synchronous ||
+		defaults.CacheSizeMB != want.CacheSizeMB || time.Duration(defaults.BusyTimeoutMS)*time.Millisecond != want.BusyTimeout {
+		t.Errorf("default config must match database.DefaultOptions: %+v", defaults)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*DatabaseConfig)
+		want   string
+	}{
+		{"journal mode", func(d *DatabaseConfig) { d.JournalMode = "FAST" }, "journal_mode"},
+		{"synchronous", func(d *DatabaseConfig) { d.Synchronous = "SOMETIMES" }, "synchronous"},
+		{"cache size", func(d *DatabaseConfig) { d.CacheSizeMB = -1 }, "cache_size_mb"},
+		{"mmap size", func(d *DatabaseConfig) { d.MmapSizeMB = -1 }, "mmap_size_mb"},
+		{"busy timeout", func(d *DatabaseConfig) { d.BusyTimeoutMS = -1 }, "busy_timeout"},
+		{"page size", func(d *DatabaseConfig) { d.PageSize = 1000 }, "page_size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(&cfg.Database)
+			err := cfg.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error mentioning %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestValidationMatchesDatabaseAndParser tests that the values accepted by
+// the config match those of the database and parser packages
+func TestValidationMatchesDatabaseAndParser(t *testing.T) {
+	if !reflect.DeepEqual(validJournalModes, database.JournalModes()) {
+		t.Errorf("journal modes %v differ from database %v", validJournalModes, database.JournalModes())
+	}
+	if !reflect.DeepEqual(validSynchronousLevels, database.SynchronousLevels()) {
+		t.Errorf("synchronous levels %v differ from database %v", validSynchronousLevels, database.SynchronousLevels())
+	}
+	for _, mode := range validErrorModes {
+		if _, err := parser.ParseErrorMode(mode); err != nil {
+			t.Errorf("error mode %q: %v", mode, err)
+		}
+	}
+	for _, strategy := range validConflictStrategies {
+		if _, err := database.ParseConflictStrategy(strategy); err != nil {
+			t.Errorf("conflict strategy %q: %v", strategy, err)
+		}
+	}
+}
+
+// TestValidationTablePatterns tests that the config accepts the same table
+// patterns as parser.ValidateTablePatterns
+func TestValidationTablePatterns(t *testing.T) {
+	for _, pattern := range append(parser.TableGroupNames(), "map*", "inv[TG]*", "[a-", "map[") {
+		want := parser.ValidateTablePatterns([]string{pattern}) == nil
+		if got := checkTablePatterns([]string{pattern}) == nil; got != want {
+			t.Errorf("table pattern %q: config valid = %v, parser valid = %v", pattern, got, want)
+		}
+	}
+}
+
+// TestValidationExportCSV tests validation of the [export.csv] settings
+func TestValidationExportCSV(t *testing.T) {
+	if DefaultConfig().Export.CSV.Delimiter != "," {
+		t.Errorf("expected default delimiter \",\", got %q", DefaultConfig().Export.CSV.Delimiter)
+	}
+
+	for _, delimiter := range []string{"", ",", ";", "\t", "|", "§"} {
+		cfg := DefaultConfig()
+		cfg.Export.CSV.Delimiter = delimiter
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("delimiter %q: unexpected error: %v", delimiter, err)
+		}
+	}
+	for _, delimiter := range []string{";;", "\"", "\n", "\xff"} {
+		cfg := DefaultConfig()
+		cfg.Export.CSV.Delimiter = delimiter
+		err := cfg.Validate()
+		if err == nil || !strings.Contains(err.Error(), "export.csv") {
+			t.Errorf("delimiter %q: expected export.csv error, got %v", delimiter, err)
+		}
+	}
+}
+
+// TestValidationExportPostgres tests validation of the [export.postgres] settings
+func TestValidationExportPostgres(t *testing.T) {
+	if DefaultConfig().Export.Postgres.Path != "./eve_sde.sql" {
+		t.Errorf("expected default path ./eve_sde.sql, got %q", DefaultConfig().Export.Postgres.Path)
+	}
+
+	cfg := DefaultConfig()
+	cfg.Export.Postgres.Tables = []string{"universe", "inv*"}
+	cfg.Export.Postgres.ExcludeTables = []string{"mapMoons"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	cfg = DefaultConfig()
+	cfg.Export.Postgres.ExcludeTables = []string{"map["}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "export.postgres") {
+		t.Errorf("expected export.postgres error, got %v", err)
+	}
+}
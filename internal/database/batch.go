@@ -40,6 +40,64 @@ func BatchInsert(ctx context.Context, db *sqlx.DB, table string, columns []strin
 // Additional parameter:
 //   - progressCallback: Optional callback function to report progress (can be nil)
 func BatchInsertWithProgress(ctx context.Context, db *sqlx.DB, table string, columns []string, rows [][]interface{}, batchSize int, progressCallback ProgressCallback) error {
+	return BatchInsertWithOptions(ctx, db, table, columns, rows, BatchOptions{
+		BatchSize: batchSize,
+		Progress:  progressCallback,
+	})
+}
+
+// ConflictStrategy controls how batch inserts handle rows that violate a
+// PRIMARY KEY or UNIQUE constraint.
+type ConflictStrategy string
+
+const (
+	// ConflictAbort fails the insert and rolls back the transaction (default)
+	ConflictAbort ConflictStrategy = "abort"
+	// ConflictIgnore keeps the existing row and skips the new one (INSERT OR IGNORE)
+	ConflictIgnore ConflictStrategy = "ignore"
+	// ConflictReplace replaces the existing row (INSERT OR REPLACE)
+	ConflictReplace ConflictStrategy = "replace"
+)
+
+// ParseConflictStrategy parses a conflict strategy name ("abort", "ignore",
+// "replace"; case-insensitive). An empty string yields ConflictAbort.
+func ParseConflictStrategy(name string) (ConflictStrategy, error) {
+	switch strings.ToLower(name) {
+	case "", string(ConflictAbort):
+		return ConflictAbort, nil
+	case string(ConflictIgnore):
+		return ConflictIgnore, nil
+	case string(ConflictReplace):
+		return ConflictReplace, nil
+	default:
+		return "", fmt.Errorf("invalid conflict strategy %q (must be: abort, ignore, replace)", name)
+	}
+}
+
+// BatchOptions configures BatchInsertWithOptions.
+type BatchOptions struct {
+	// BatchSize is the number of rows per INSERT statement (must be > 0)
+	BatchSize int
+	// OnConflict selects the conflict clause (empty = ConflictAbort)
+	OnConflict ConflictStrategy
+	// Progress is called after each batch (optional)
+	Progress ProgressCallback
+}
+
+// BatchInsertWithOptions performs batch insertion like BatchInsertWithProgress
+// and additionally applies a conflict strategy to every INSERT statement.
+//
+// With ConflictIgnore or ConflictReplace, rows violating a PRIMARY KEY or
+// UNIQUE constraint no longer abort the transaction; the progress callback
+// still counts them as processed.
+func BatchInsertWithOptions(ctx context.Context, db *sqlx.DB, table string, columns []string, rows [][]interface{}, opts BatchOptions) error {
+	batchSize := opts.BatchSize
+	progressCallback := opts.Progress
+	verb, err := insertVerb(opts.OnConflict)
+	if err != nil {
+		return err
+	}
+
 	// Validate inputs
 	if table == "" {
 		return fmt.Errorf("table name cannot be empty")
@@ -92,7 +150,7 @@ func BatchInsertWithProgress(ctx context.Context, db *sqlx.DB, table string, col
 		currentBatchSize := len(batch)
 
 		// Build SQL for this batch
-		sql := buildBatchInsertSQL(table, columns, currentBatchSize)
+		sql := buildInsertSQL(verb, table, columns, currentBatchSize)
 
 		// Flatten batch data for SQL execution
 		args := make([]interface{}, 0, currentBatchSize*len(columns))
@@ -122,6 +180,21 @@ func BatchInsertWithProgress(ctx context.Context, db *sqlx.DB, table string, col
 	return nil
 }
 
+// insertVerb returns the statement prefix for a conflict strategy
+// ("INSERT INTO ", "INSERT OR IGNORE INTO ", "INSERT OR REPLACE INTO ").
+func insertVerb(strategy ConflictStrategy) (string, error) {
+	switch strategy {
+	case "", ConflictAbort:
+		return "INSERT INTO ", nil
+	case ConflictIgnore:
+		return "INSERT OR IGNORE INTO ", nil
+	case ConflictReplace:
+		return "INSERT OR REPLACE INTO ", nil
+	default:
+		return "", fmt.Errorf("invalid conflict strategy %q", strategy)
+	}
+}
+
 // buildBatchInsertSQL generates an optimized multi-row INSERT statement.
 //
 // Example output:
@@ -136,10 +209,16 @@ func BatchInsertWithProgress(ctx context.Context, db *sqlx.DB, table string, col
 // Returns:
 //   - string: The generated SQL INSERT statement
 func buildBatchInsertSQL(table string, columns []string, batchSize int) string {
+	return buildInsertSQL("INSERT INTO ", table, columns, batchSize)
+}
+
+// buildInsertSQL generates a multi-row INSERT statement with the given
+// statement prefix (see insertVerb).
+func buildInsertSQL(verb, table string, columns []string, batchSize int) string {
 	var sb strings.Builder
 
 	// Build column list
-	sb.WriteString("INSERT INTO ")
+	sb.WriteString(verb)
 	sb.WriteString(table)
 	sb.WriteString(" (")
 	sb.WriteString(strings.Join(columns, ", "))
@@ -563,3 +563,82 @@ func BenchmarkBatchInsert_DifferentBatchSizes(b *testing.B) {
 		})
 	}
 }
+
+// TestBatchInsertWithOptions_ConflictStrategies tests duplicate primary keys
+// with each conflict strategy
+func TestBatchInsertWithOptions_ConflictStrategies(t *testing.T) {
+	tests := []struct {
+		strategy  ConflictStrategy
+		wantErr   bool
+		wantCount int
+		wantName  string
+	}{
+		{strategy: "", wantErr: true, wantCount: 1, wantName: "existing"},
+		{strategy: ConflictAbort, wantErr: true, wantCount: 1, wantName: "existing"},
+		{strategy: ConflictIgnore, wantCount: 2, wantName: "existing"},
+		{strategy: ConflictReplace, wantCount: 2, wantName: "replaced"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			db, err := NewDB(":memory:")
+			if err != nil {
+				t.Fatalf("Failed to create database: %v", err)
+			}
+			defer func() {
+				_ = Close(db)
+			}()
+
+			if _, err := db.Exec(`CREATE TABLE test_data (id INTEGER PRIMARY KEY, name TEXT NOT NULL)`); err != nil {
+				t.Fatalf("Failed to create table: %v", err)
+			}
+			if _, err := db.Exec(`INSERT INTO test_data (id, name) VALUES (1, 'existing')`); err != nil {
+				t.Fatalf("Failed to insert existing row: %v", err)
+			}
+
+			rows := [][]interface{}{{1, "replaced"}, {2, "new"}}
+			err = BatchInsertWithOptions(context.Background(), db, "test_data", []string{"id", "name"}, rows, BatchOptions{
+				BatchSize:  1,
+				OnConflict: tt.strategy,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BatchInsertWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var count int
+			if err := db.QueryRow("SELECT COUNT(*) FROM test_data").Scan(&count); err != nil {
+				t.Fatalf("Failed to count rows: %v", err)
+			}
+			var name string
+			if err := db.QueryRow("SELECT name FROM test_data WHERE id = 1").Scan(&name); err != nil {
+				t.Fatalf("Failed to query row: %v", err)
+			}
+			if count != tt.wantCount || name != tt.wantName {
+				t.Errorf("got %d rows and name %q, want %d rows and name %q", count, name, tt.wantCount, tt.wantName)
+			}
+		})
+	}
+}
+
+// TestParseConflictStrategy tests parsing of conflict strategy names
+func TestParseConflictStrategy(t *testing.T) {
+	tests := map[string]ConflictStrategy{
+		"":        ConflictAbort,
+		"abort":   ConflictAbort,
+		"IGNORE":  ConflictIgnore,
+		"replace": ConflictReplace,
+	}
+	for name, want := range tests {
+		got, err := ParseConflictStrategy(name)
+		if err != nil || got != want {
+			t.Errorf("ParseConflictStrategy(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+
+	if _, err := ParseConflictStrategy("update"); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+	if err := BatchInsertWithOptions(context.Background(), nil, "t", []string{"a"}, [][]interface{}{{1}}, BatchOptions{BatchSize: 1, OnConflict: "update"}); err == nil {
+		t.Error("expected error for invalid OnConflict")
+	}
+}
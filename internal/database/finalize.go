@@ -0,0 +1,171 @@
+// Package database provides SQLite database connection management
+// with post-import optimization (finalize phase).
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// FinalizeOptions configures the post-import finalize phase.
+type FinalizeOptions struct {
+	// Analyze runs ANALYZE to populate sqlite_stat1 for the query planner
+	Analyze bool
+	// Optimize runs PRAGMA optimize
+	Optimize bool
+	// Vacuum rebuilds the database file in place to release free pages
+	Vacuum bool
+	// VacuumInto writes a compacted copy to this path instead of vacuuming in place.
+	// Takes precedence over Vacuum. The target file must not exist.
+	VacuumInto string
+	// IntegrityCheck runs PRAGMA integrity_check and fails if the result is not "ok"
+	IntegrityCheck bool
+	// Checkpoint runs PRAGMA wal_checkpoint(TRUNCATE) to fold the WAL back into
+	// the main database file and truncate it to zero bytes
+	Checkpoint bool
+}
+
+// FinalizeStep describes the outcome of a single finalize step.
+type FinalizeStep struct {
+	Name     string        // Step name (e.g., "analyze", "vacuum")
+	Duration time.Duration // Execution time of the step
+	Result   string        // Human-readable result (e.g., "ok")
+	Err      error         // Error, if the step failed
+}
+
+// Finalize runs the post-import optimization phase on an imported database.
+//
+// Steps are executed in the following order (each only if enabled):
+//  1. ANALYZE - collect table and index statistics (sqlite_stat1)
+//  2. PRAGMA optimize - let SQLite apply further planner optimizations
+//  3. VACUUM / VACUUM INTO - release free pages or write a compacted copy
+//  4. PRAGMA integrity_check - verify the database structure
+//  5. PRAGMA wal_checkpoint(TRUNCATE) - checkpoint and truncate the WAL file
+//
+// The WAL checkpoint runs last because ANALYZE and VACUUM themselves write to
+// the WAL; truncating it at the end leaves a compact database file behind.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - db: Database connection
+//   - opts: Steps to execute
+//
+// Returns:
+//   - []FinalizeStep: Timing and result of every executed step (including a failed one)
+//   - error: The first error encountered; remaining steps are not executed
+//
+// Example:
+//
+//	opts := database.FinalizeOptions{Analyze: true, Optimize: true, Checkpoint: true}
+//	steps, err := database.Finalize(ctx, db, opts)
+//	for _, step := range steps {
+//	    fmt.Printf("%-16s %8v %s\n", step.Name, step.Duration, step.Result)
+//	}
+func Finalize(ctx context.Context, db *sqlx.DB, opts FinalizeOptions) ([]FinalizeStep, error) {
+	type finalizeFunc struct {
+		name string
+		run  func(ctx context.Context, db *sqlx.DB) (string, error)
+	}
+
+	var plan []finalizeFunc
+	if opts.Analyze {
+		plan = append(plan, finalizeFunc{"analyze", execStep("ANALYZE")})
+	}
+	if opts.Optimize {
+		plan = append(plan, finalizeFunc{"optimize", execStep("PRAGMA optimize")})
+	}
+	if opts.VacuumInto != "" {
+		target := opts.VacuumInto
+		plan = append(plan, finalizeFunc{"vacuum_into", func(ctx context.Context, db *sqlx.DB) (string, error) {
+			// Never overwrite an existing file
+			if _, err := os.Stat(target); err == nil {
+				return "", fmt.Errorf("target %s already exists", target)
+			}
+			if _, err := db.ExecContext(ctx, "VACUUM INTO ?", target); err != nil {
+				return "", err
+			}
+			return target, nil
+		}})
+	} else if opts.Vacuum {
+		plan = append(plan, finalizeFunc{"vacuum", execStep("VACUUM")})
+	}
+	if opts.IntegrityCheck {
+		plan = append(plan, finalizeFunc{"integrity_check", integrityCheck})
+	}
+	if opts.Checkpoint {
+		plan = append(plan, finalizeFunc{"wal_checkpoint", walCheckpoint})
+	}
+
+	steps := make([]FinalizeStep, 0, len(plan))
+	for _, p := range plan {
+		select {
+		case <-ctx.Done():
+			return steps, fmt.Errorf("finalize cancelled: %w", ctx.Err())
+		default:
+		}
+
+		start := time.Now()
+		result, err := p.run(ctx, db)
+		step := FinalizeStep{
+			Name:     p.name,
+			Duration: time.Since(start),
+			Result:   result,
+			Err:      err,
+		}
+		steps = append(steps, step)
+
+		if err != nil {
+			return steps, fmt.Errorf("finalize step %s failed: %w", p.name, err)
+		}
+	}
+
+	return steps, nil
+}
+
+// execStep returns a finalize step that executes a single statement.
+func execStep(stmt string) func(ctx context.Context, db *sqlx.DB) (string, error) {
+	return func(ctx context.Context, db *sqlx.DB) (string, error) {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return "", err
+		}
+		return "ok", nil
+	}
+}
+
+// integrityCheck runs PRAGMA integrity_check and reports all problems found.
+func integrityCheck(ctx context.Context, db *sqlx.DB) (string, error) {
+	var messages []string
+	if err := db.SelectContext(ctx, &messages, "PRAGMA integrity_check"); err != nil {
+		return "", err
+	}
+
+	if len(messages) == 1 && messages[0] == "ok" {
+		return "ok", nil
+	}
+
+	result := strings.Join(messages, "; ")
+	return result, fmt.Errorf("integrity check reported %d problem(s): %s", len(messages), result)
+}
+
+// walCheckpoint runs PRAGMA wal_checkpoint(TRUNCATE) and reports the page counts.
+//
+// For databases not in WAL mode SQLite returns log=-1 and checkpointed=-1.
+func walCheckpoint(ctx context.Context, db *sqlx.DB) (string, error) {
+	var busy, logPages, checkpointed int
+	row := db.QueryRowContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)")
+	if err := row.Scan(&busy, &logPages, &checkpointed); err != nil {
+		return "", err
+	}
+	if busy != 0 {
+		return "busy", fmt.Errorf("checkpoint could not complete: database is busy")
+	}
+	if logPages < 0 {
+		return "skipped (not in WAL mode)", nil
+	}
+	return fmt.Sprintf("%d pages checkpointed", checkpointed), nil
+}
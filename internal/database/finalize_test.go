@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFinalize_AllButVacuum tests every step except VACUUM on a file-based database
+func TestFinalize_AllButVacuum(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "finalize.db")
+	db, err := NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer func() { _ = Close(db) }()
+
+	if _, err := db.Exec(`CREATE TABLE items (id INTEGER PRIMARY KEY, groupID INTEGER)`); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	if _, err := db.Exec(`CREATE INDEX idx_items_groupID ON items(groupID)`); err != nil {
+		t.Fatalf("failed to create index: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO items (groupID) VALUES (1), (1), (2)`); err != nil {
+		t.Fatalf("failed to insert rows: %v", err)
+	}
+
+	opts := FinalizeOptions{Analyze: true, Optimize: true, IntegrityCheck: true, Checkpoint: true}
+	steps, err := Finalize(context.Background(), db, opts)
+	if err != nil {
+		t.Fatalf("Finalize failed: %v", err)
+	}
+
+	expected := []string{"analyze", "optimize", "integrity_check", "wal_checkpoint"}
+	if len(steps) != len(expected) {
+		t.Fatalf("expected %d steps, got %d: %+v", len(expected), len(steps), steps)
+	}
+	for i, name := range expected {
+		if steps[i].Name != name {
+			t.Errorf("step %d: expected %s, got %s", i, name, steps[i].Name)
+		}
+		if steps[i].Err != nil {
+			t.Errorf("step %s: unexpected error: %v", name, steps[i].Err)
+		}
+	}
+	if steps[2].Result != "ok" {
+		t.Errorf("expected integrity_check result 'ok', got %q", steps[2].Result)
+	}
+
+	// ANALYZE must have populated sqlite_stat1
+	var statCount int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_stat1").Scan(&statCount); err != nil {
+		t.Fatalf("failed to query sqlite_stat1: %v", err)
+	}
+	if statCount == 0 {
+		t.Error("expected sqlite_stat1 to contain statistics after ANALYZE")
+	}
+
+	// WAL must be truncated after checkpoint
+	if info, err := os.Stat(dbPath + "-wal"); err == nil && info.Size() != 0 {
+		t.Errorf("expected WAL file to be truncated, got %d bytes", info.Size())
+	}
+}
+
+// TestFinalize_VacuumInto tests writing a compacted copy of the database
+func TestFinalize_VacuumInto(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := NewDB(filepath.Join(tmpDir, "source.db"))
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer func() { _ = Close(db) }()
+
+	if _, err := db.Exec(`CREATE TABLE items (id INTEGER PRIMARY KEY)`); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	target := filepath.Join(tmpDir, "compact.db")
+	steps, err := Finalize(context.Background(), db, FinalizeOptions{Vacuum: true, VacuumInto: target})
+	if err != nil {
+		t.Fatalf("Finalize failed: %v", err)
+	}
+
+	if len(steps) != 1 || steps[0].Name != "vacuum_into" {
+		t.Fatalf("expected single vacuum_into step, got %+v", steps)
+	}
+	if _, err := os.Stat(target); err != nil {
+		t.Errorf("expected compacted copy at %s: %v", target, err)
+	}
+}
+
+// TestFinalize_StopsOnError tests that a failing step aborts the phase
+func TestFinalize_StopsOnError(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := NewDB(filepath.Join(tmpDir, "source.db"))
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer func() { _ = Close(db) }()
+
+	// VACUUM INTO fails if the target already exists
+	target := filepath.Join(tmpDir, "exists.db")
+	if err := os.WriteFile(target, []byte("x"), 0644); err != nil {
+		t.Fatalf("failed to create target file: %v", err)
+	}
+
+	steps, err := Finalize(context.Background(), db, FinalizeOptions{
+		Analyze:        true,
+		VacuumInto:     target,
+		IntegrityCheck: true,
+	})
+	if err == nil {
+		t.Fatal("expected error for existing VACUUM INTO target")
+	}
+	if len(steps) != 2 {
+		t.Fatalf("expected 2 steps (analyze + failed vacuum_into), got %d", len(steps))
+	}
+	if steps[1].Err == nil {
+		t.Error("expected failed step to carry its error")
+	}
+}
+
+// TestFinalize_Cancelled tests context cancellation before the first step
+func TestFinalize_Cancelled(t *testing.T) {
+	db := NewTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	steps, err := Finalize(ctx, db, FinalizeOptions{Analyze: true, IntegrityCheck: true})
+	if err == nil {
+		t.Fatal("expected error for cancelled context")
+	}
+	if len(steps) != 0 {
+		t.Errorf("expected no executed steps, got %d", len(steps))
+	}
+}
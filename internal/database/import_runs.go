@@ -0,0 +1,264 @@
+// Package database provides SQLite database connection management
+// with import provenance tracking (_import_runs metadata table).
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ImportRunsTable is the name of the metadata table recording import runs.
+const ImportRunsTable = "_import_runs"
+
+// importRunsSchema creates the _import_runs table. Map-valued fields are
+// stored as JSON text so the table stays readable with the sqlite3 CLI.
+const importRunsSchema = `CREATE TABLE IF NOT EXISTS _import_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    sde_version TEXT,
+    sde_release_date TEXT,
+    sde_build_number INTEGER,
+    tool_version TEXT,
+    tool_commit TEXT,
+    hostname TEXT,
+    flags TEXT,
+    file_checksums TEXT,
+    row_counts TEXT,
+    started_at TEXT NOT NULL,
+    finished_at TEXT NOT NULL
+)`
+
+// ImportRun describes a single import run recorded in the _import_runs table.
+//
+// ImportRun answers the question "which SDE build, tool version and options
+// produced this database?" when only the .db file is available.
+type ImportRun struct {
+	ID             int64             // Auto-assigned run ID
+	SDEVersion     string            // Version from _sde.jsonl
+	SDEReleaseDate string            // Release date from _sde.jsonl
+	SDEBuildNumber int64             // Build number from _sde.jsonl (0 if unknown)
+	ToolVersion    string            // esdedb version
+	ToolCommit     string            // esdedb commit hash
+	Hostname       string            // Host the import ran on
+	Flags          map[string]string // Command-line flags (name → value)
+	FileChecksums  map[string]string // SHA-256 per source file (file name → hex digest)
+	RowCounts      map[string]int64  // Row count per table after import
+	StartedAt      time.Time         // Import start
+	FinishedAt     time.Time         // Import end
+}
+
+// EnsureImportRunsTable creates the _import_runs table if it does not exist.
+func EnsureImportRunsTable(ctx context.Context, db *sqlx.DB) error {
+	if _, err := db.ExecContext(ctx, importRunsSchema); err != nil {
+		return fmt.Errorf("failed to create %s table: %w", ImportRunsTable, err)
+	}
+	return nil
+}
+
+// RecordImportRun stores an import run in the _import_runs table.
+//
+// The table is created if necessary. On success run.ID is set to the new row ID.
+//
+// Example:
+//
+//	run := &database.ImportRun{
+//	    ToolVersion: "1.2.0",
+//	    StartedAt:   start,
+//	    FinishedAt:  time.Now(),
+//	}
+//	if err := database.RecordImportRun(ctx, db, run); err != nil {
+//	    return err
+//	}
+func RecordImportRun(ctx context.Context, db *sqlx.DB, run *ImportRun) error {
+	if run == nil {
+		return fmt.Errorf("import run cannot be nil")
+	}
+	if err := EnsureImportRunsTable(ctx, db); err != nil {
+		return err
+	}
+
+	flags, err := marshalJSONText(run.Flags)
+	if err != nil {
+		return fmt.Errorf("failed to encode flags: %w", err)
+	}
+	checksums, err := marshalJSONText(run.FileChecksums)
+	if err != nil {
+		return fmt.Errorf("failed to encode file checksums: %w", err)
+	}
+	rowCounts, err := marshalJSONText(run.RowCounts)
+	if err != nil {
+		return fmt.Errorf("failed to encode row counts: %w", err)
+	}
+
+	result, err := db.ExecContext(ctx, `INSERT INTO _import_runs (
+        sde_version, sde_release_date, sde_build_number, tool_version, tool_commit, hostname,
+        flags, file_checksums, row_counts, started_at, finished_at
+    ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		run.SDEVersion, run.SDEReleaseDate, nullableInt64(run.SDEBuildNumber), run.ToolVersion, run.ToolCommit, run.Hostname,
+		flags, checksums, rowCounts,
+		run.StartedAt.UTC().Format(time.RFC3339Nano), run.FinishedAt.UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert import run: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get import run ID: %w", err)
+	}
+	run.ID = id
+
+	return nil
+}
+
+// ListImportRuns returns all recorded import runs, newest first.
+//
+// Databases without an _import_runs table (e.g., built by older versions)
+// return an empty slice and no error.
+func ListImportRuns(ctx context.Context, db *sqlx.DB) ([]ImportRun, error) {
+	exists, err := Exists(ctx, db, "SELECT 1 FROM sqlite_master WHERE type='table' AND name=?", ImportRunsTable)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return []ImportRun{}, nil
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT id, sde_version, sde_release_date, sde_build_number, tool_version, tool_commit,
+        hostname, flags, file_checksums, row_counts, started_at, finished_at
+        FROM _import_runs ORDER BY id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query import runs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	runs := []ImportRun{}
+	for rows.Next() {
+		var run ImportRun
+		var sdeVersion, sdeReleaseDate, toolVersion, toolCommit, hostname sql.NullString
+		var sdeBuildNumber sql.NullInt64
+		var flags, checksums, rowCounts sql.NullString
+		var startedAt, finishedAt string
+
+		if err := rows.Scan(&run.ID, &sdeVersion, &sdeReleaseDate, &sdeBuildNumber, &toolVersion, &toolCommit,
+			&hostname, &flags, &checksums, &rowCounts, &startedAt, &finishedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan import run: %w", err)
+		}
+
+		run.SDEVersion = sdeVersion.String
+		run.SDEReleaseDate = sdeReleaseDate.String
+		run.SDEBuildNumber = sdeBuildNumber.Int64
+		run.ToolVersion = toolVersion.String
+		run.ToolCommit = toolCommit.String
+		run.Hostname = hostname.String
+
+		if err := unmarshalJSONText(flags, &run.Flags); err != nil {
+			return nil, fmt.Errorf("import run %d: invalid flags: %w", run.ID, err)
+		}
+		if err := unmarshalJSONText(checksums, &run.FileChecksums); err != nil {
+			return nil, fmt.Errorf("import run %d: invalid file checksums: %w", run.ID, err)
+		}
+		if err := unmarshalJSONText(rowCounts, &run.RowCounts); err != nil {
+			return nil, fmt.Errorf("import run %d: invalid row counts: %w", run.ID, err)
+		}
+
+		if run.StartedAt, err = time.Parse(time.RFC3339Nano, startedAt); err != nil {
+			return nil, fmt.Errorf("import run %d: invalid started_at: %w", run.ID, err)
+		}
+		if run.FinishedAt, err = time.Parse(time.RFC3339Nano, finishedAt); err != nil {
+			return nil, fmt.Errorf("import run %d: invalid finished_at: %w", run.ID, err)
+		}
+
+		runs = append(runs, run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating import runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+// LatestImportedBuildNumber returns the SDE build number of the most recent
+// run in _import_runs that recorded one, including partial runs that did not
+// set user_version. This is the build the database currently holds, also
+// after a forced downgrade.
+//
+// Databases without an _import_runs table or without known builds return 0.
+func LatestImportedBuildNumber(ctx context.Context, db *sqlx.DB) (int64, error) {
+	exists, err := Exists(ctx, db, "SELECT 1 FROM sqlite_master WHERE type='table' AND name=?", ImportRunsTable)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	var build int64
+	err = db.QueryRowContext(ctx,
+		"SELECT sde_build_number FROM _import_runs WHERE sde_build_number > 0 ORDER BY id DESC LIMIT 1").Scan(&build)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to query latest SDE build: %w", err)
+	}
+	return build, nil
+}
+
+// CountTableRows returns the row count of every user table in the database.
+//
+// SQLite internal tables (sqlite_*) and the _import_runs metadata table are excluded.
+func CountTableRows(ctx context.Context, db *sqlx.DB) (map[string]int64, error) {
+	var tables []string
+	err := db.SelectContext(ctx, &tables,
+		"SELECT name FROM sqlite_master WHERE type='table' AND name NOT LIKE 'sqlite_%' AND name != ? ORDER BY name",
+		ImportRunsTable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tables: %w", err)
+	}
+
+	counts := make(map[string]int64, len(tables))
+	for _, table := range tables {
+		var count int64
+		if err := db.QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM "%s"`, table)).Scan(&count); err != nil {
+			return nil, fmt.Errorf("failed to count rows in table %s: %w", table, err)
+		}
+		counts[table] = count
+	}
+
+	return counts, nil
+}
+
+// nullableInt64 maps 0 (unknown) to NULL.
+func nullableInt64(v int64) interface{} {
+	if v == 0 {
+		return nil
+	}
+	return v
+}
+
+// marshalJSONText encodes v as JSON text; nil maps are stored as NULL.
+func marshalJSONText(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if string(data) == "null" {
+		return nil, nil
+	}
+	return string(data), nil
+}
+
+// unmarshalJSONText decodes a nullable JSON text column into v.
+func unmarshalJSONText(s sql.NullString, v interface{}) error {
+	if !s.Valid || s.String == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(s.String), v)
+}
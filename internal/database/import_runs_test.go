@@ -0,0 +1,149 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestRecordImportRun_RoundTrip tests storing and reading back an import run
+func TestRecordImportRun_RoundTrip(t *testing.T) {
+	db := NewTestDB(t)
+	ctx := context.Background()
+
+	started := time.Date(2025, 10, 7, 12, 0, 0, 0, time.UTC)
+	run := &ImportRun{
+		SDEVersion:     "3064089",
+		SDEReleaseDate: "2025-10-07T11:14:31Z",
+		SDEBuildNumber: 3064089,
+		ToolVersion:    "1.2.0",
+		ToolCommit:     "abc1234",
+		Hostname:       "build-01",
+		Flags:          map[string]string{"workers": "8", "skip-errors": "true"},
+		FileChecksums:  map[string]string{"invTypes.jsonl": "deadbeef"},
+		RowCounts:      map[string]int64{"invTypes": 42},
+		StartedAt:      started,
+		FinishedAt:     started.Add(90 * time.Second),
+	}
+
+	if err := RecordImportRun(ctx, db, run); err != nil {
+		t.Fatalf("RecordImportRun failed: %v", err)
+	}
+	if run.ID == 0 {
+		t.Error("expected run ID to be set")
+	}
+
+	runs, err := ListImportRuns(ctx, db)
+	if err != nil {
+		t.Fatalf("ListImportRuns failed: %v", err)
+	}
+	if len(runs) != 1 {
+		t.Fatalf("expected 1 run, got %d", len(runs))
+	}
+
+	got := runs[0]
+	if got.SDEVersion != run.SDEVersion || got.SDEBuildNumber != run.SDEBuildNumber || got.ToolCommit != run.ToolCommit || got.Hostname != run.Hostname {
+		t.Errorf("metadata mismatch: got %+v", got)
+	}
+	if got.Flags["workers"] != "8" {
+		t.Errorf("expected flag workers=8, got %v", got.Flags)
+	}
+	if got.FileChecksums["invTypes.jsonl"] != "deadbeef" {
+		t.Errorf("expected checksum for invTypes.jsonl, got %v", got.FileChecksums)
+	}
+	if got.RowCounts["invTypes"] != 42 {
+		t.Errorf("expected row count 42 for invTypes, got %v", got.RowCounts)
+	}
+	if !got.StartedAt.Equal(run.StartedAt) || !got.FinishedAt.Equal(run.FinishedAt) {
+		t.Errorf("time mismatch: got %v - %v", got.StartedAt, got.FinishedAt)
+	}
+}
+
+// TestListImportRuns_NewestFirst tests ordering of recorded runs
+func TestListImportRuns_NewestFirst(t *testing.T) {
+	db := NewTestDB(t)
+	ctx := context.Background()
+
+	for _, version := range []string{"1", "2", "3"} {
+		run := &ImportRun{SDEVersion: version, StartedAt: time.Now(), FinishedAt: time.Now()}
+		if err := RecordImportRun(ctx, db, run); err != nil {
+			t.Fatalf("RecordImportRun failed: %v", err)
+		}
+	}
+
+	runs, err := ListImportRuns(ctx, db)
+	if err != nil {
+		t.Fatalf("ListImportRuns failed: %v", err)
+	}
+	if len(runs) != 3 || runs[0].SDEVersion != "3" || runs[2].SDEVersion != "1" {
+		t.Errorf("expected runs newest first, got %+v", runs)
+	}
+}
+
+// TestListImportRuns_NoTable tests databases created without provenance tracking
+func TestListImportRuns_NoTable(t *testing.T) {
+	db := NewTestDB(t)
+
+	runs, err := ListImportRuns(context.Background(), db)
+	if err != nil {
+		t.Fatalf("ListImportRuns failed: %v", err)
+	}
+	if len(runs) != 0 {
+		t.Errorf("expected no runs, got %d", len(runs))
+	}
+}
+
+// TestLatestImportedBuildNumber tests the build of the most recent run with a
+// known build
+func TestLatestImportedBuildNumber(t *testing.T) {
+	db := NewTestDB(t)
+	ctx := context.Background()
+
+	build, err := LatestImportedBuildNumber(ctx, db)
+	if err != nil {
+		t.Fatalf("LatestImportedBuildNumber failed: %v", err)
+	}
+	if build != 0 {
+		t.Errorf("expected 0 without _import_runs, got %d", build)
+	}
+
+	// forced downgrade from 3200000 to 3100000, then a run without build
+	for _, b := range []int64{3000000, 3200000, 3100000, 0} {
+		run := &ImportRun{SDEBuildNumber: b, StartedAt: time.Now(), FinishedAt: time.Now()}
+		if err := RecordImportRun(ctx, db, run); err != nil {
+			t.Fatalf("RecordImportRun failed: %v", err)
+		}
+	}
+
+	build, err = LatestImportedBuildNumber(ctx, db)
+	if err != nil {
+		t.Fatalf("LatestImportedBuildNumber failed: %v", err)
+	}
+	if build != 3100000 {
+		t.Errorf("expected 3100000, got %d", build)
+	}
+}
+
+// TestCountTableRows tests per-table row counting
+func TestCountTableRows(t *testing.T) {
+	db := NewTestDB(t)
+	ctx := context.Background()
+
+	if _, err := db.Exec("INSERT INTO invTypes (typeID, typeName) VALUES (34, 'Tritanium'), (35, 'Pyerite')"); err != nil {
+		t.Fatalf("failed to insert rows: %v", err)
+	}
+	if err := EnsureImportRunsTable(ctx, db); err != nil {
+		t.Fatalf("EnsureImportRunsTable failed: %v", err)
+	}
+
+	counts, err := CountTableRows(ctx, db)
+	if err != nil {
+		t.Fatalf("CountTableRows failed: %v", err)
+	}
+	if counts["invTypes"] != 2 {
+		t.Errorf("expected 2 invTypes rows, got %d", counts["invTypes"])
+	}
+	if _, ok := counts[ImportRunsTable]; ok {
+		t.Error("expected _import_runs to be excluded from row counts")
+	}
+}
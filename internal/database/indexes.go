@@ -0,0 +1,327 @@
+// Package database provides SQLite database connection management
+// with deferred secondary index creation for bulk imports.
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SplitIndexStatements separates secondary index statements from the rest of a
+// migration script.
+//
+// Only non-unique "CREATE INDEX" statements are extracted. UNIQUE indexes enforce
+// constraints during insert and therefore stay in the schema part.
+//
+// Parameters:
+//   - script: SQL content of a migration file
+//
+// Returns:
+//   - string: The script without secondary index statements (tables, constraints)
+//   - []string: The extracted CREATE INDEX statements in their original order
+//
+// Example:
+//
+//	schema, indexes := SplitIndexStatements(migrationSQL)
+//	// schema:  "CREATE TABLE IF NOT EXISTS invTypes (...);"
+//	// indexes: ["CREATE INDEX IF NOT EXISTS idx_invTypes_groupID ON invTypes(groupID)"]
+func SplitIndexStatements(script string) (string, []string) {
+	var schema []string
+	var indexes []string
+
+	for _, stmt := range splitStatements(script) {
+		if stmt.isSecondaryIndex() {
+			indexes = append(indexes, stmt.SQL)
+			continue
+		}
+		schema = append(schema, stmt.SQL+";")
+	}
+
+	return strings.Join(schema, "\n\n"), indexes
+}
+
+// sqlStatement is a single statement of a migration script.
+type sqlStatement struct {
+	SQL      string   // Statement text without the terminating ";" and leading comments
+	Keywords []string // First keywords in upper case (e.g., CREATE, UNIQUE, INDEX)
+}
+
+// isSecondaryIndex reports whether the statement is a non-unique CREATE INDEX.
+func (s sqlStatement) isSecondaryIndex() bool {
+	return len(s.Keywords) >= 2 && s.Keywords[0] == "CREATE" && s.Keywords[1] == "INDEX"
+}
+
+// isTrigger reports whether the statement is a CREATE [TEMP] TRIGGER, whose
+// body contains ";" and only ends with "END;".
+func (s sqlStatement) isTrigger() bool {
+	k := s.Keywords
+	if len(k) >= 2 && k[0] == "CREATE" && k[1] == "TRIGGER" {
+		return true
+	}
+	return len(k) >= 3 && k[0] == "CREATE" && (k[1] == "TEMP" || k[1] == "TEMPORARY") && k[2] == "TRIGGER"
+}
+
+// statementKeywords is the number of leading keywords kept per statement
+const statementKeywords = 3
+
+// splitStatements splits a migration script into individual statements.
+//
+// The script is tokenized like sqlite3_complete: ";" inside string literals,
+// quoted identifiers, comments and trigger bodies (up to "END;") does not end
+// a statement. Comments before and after a statement are dropped, comments
+// inside are kept; statements without tokens are dropped.
+func splitStatements(script string) []sqlStatement {
+	var statements []sqlStatement
+	var cur sqlStatement
+	start, end := -1, 0 // Offsets of the first token and after the last token
+	lastWord := ""
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case c == '-' && strings.HasPrefix(script[i:], "--"):
+			if end := strings.IndexByte(script[i:], '\n'); end >= 0 {
+				i += end
+			} else {
+				i = len(script)
+			}
+			continue
+		case c == '/' && strings.HasPrefix(script[i:], "/*"):
+			if end := strings.Index(script[i+2:], "*/"); end >= 0 {
+				i += end + 3
+			} else {
+				i = len(script)
+			}
+			continue
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			continue
+		}
+
+		if start < 0 {
+			start = i
+		}
+		switch {
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			closing := c
+			if c == '[' {
+				closing = ']'
+			}
+			// Doubled quotes ('it''s') are part of the literal
+			for i++; i < len(script); i++ {
+				if script[i] != closing {
+					continue
+				}
+				if closing != ']' && i+1 < len(script) && script[i+1] == closing {
+					i++
+					continue
+				}
+				break
+			}
+			end = min(i+1, len(script))
+			lastWord = ""
+		case isWordByte(c):
+			j := i
+			for j < len(script) && isWordByte(script[j]) {
+				j++
+			}
+			lastWord = strings.ToUpper(script[i:j])
+			if len(cur.Keywords) < statementKeywords {
+				cur.Keywords = append(cur.Keywords, lastWord)
+			}
+			end = j
+			i = j - 1
+		case c == ';':
+			if cur.isTrigger() && lastWord != "END" {
+				end = i + 1
+				lastWord = ""
+				continue
+			}
+			if end > start {
+				cur.SQL = script[start:end]
+				statements = append(statements, cur)
+			}
+			cur, start, lastWord = sqlStatement{}, -1, ""
+		default:
+			end = i + 1
+			lastWord = ""
+		}
+	}
+
+	if start >= 0 && end > start {
+		cur.SQL = script[start:end]
+		statements = append(statements, cur)
+	}
+	return statements
+}
+
+// isWordByte reports whether c can be part of a keyword or unquoted identifier.
+func isWordByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 0x80 ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// indexName returns the name of the index created by a CREATE INDEX
+// statement as written (quoted names stay quoted, schema-qualified names keep
+// the schema), or "" if stmt is not a CREATE INDEX statement.
+//
+// Example:
+//
+//	indexName("CREATE INDEX IF NOT EXISTS idx_invTypes_groupID ON invTypes(groupID)")
+//	// "idx_invTypes_groupID"
+func indexName(stmt string) string {
+	rest := stmt
+	var token string
+	for _, keyword := range []string{"CREATE", "INDEX"} {
+		if token, rest = nextToken(rest); !strings.EqualFold(token, keyword) {
+			return ""
+		}
+	}
+	token, rest = nextToken(rest)
+	if strings.EqualFold(token, "IF") {
+		for _, keyword := range []string{"NOT", "EXISTS"} {
+			if token, rest = nextToken(rest); !strings.EqualFold(token, keyword) {
+				return ""
+			}
+		}
+		token, rest = nextToken(rest)
+	}
+	if strings.EqualFold(token, "ON") {
+		return ""
+	}
+	if rest = strings.TrimLeft(rest, " \t\n\r\f"); strings.HasPrefix(rest, ".") {
+		name, _ := nextToken(rest[1:])
+		return token + "." + name
+	}
+	return token
+}
+
+// nextToken splits off the first keyword, identifier or quoted identifier of
+// s; the rest starts directly after it.
+func nextToken(s string) (token, rest string) {
+	s = strings.TrimLeft(s, " \t\n\r\f")
+	if s == "" {
+		return "", ""
+	}
+	if c := s[0]; c == '"' || c == '`' || c == '[' {
+		closing := c
+		if c == '[' {
+			closing = ']'
+		}
+		for i := 1; i < len(s); i++ {
+			if s[i] != closing {
+				continue
+			}
+			// Doubled quotes ("a""b") are part of the identifier
+			if closing != ']' && i+1 < len(s) && s[i+1] == closing {
+				i++
+				continue
+			}
+			return s[:i+1], s[i+1:]
+		}
+		return s, ""
+	}
+	i := 0
+	for i < len(s) && isWordByte(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// ApplySchemaFromCLI applies all migrations to the database file like
+// ApplyMigrationsFromCLI, but skips secondary indexes.
+//
+// The skipped CREATE INDEX statements are returned so that they can be executed
+// with CreateIndexes after all data has been loaded. SQLite then builds each index
+// once instead of maintaining it row by row during BatchInsert.
+//
+// Secondary indexes that already exist, e.g. in a database from an earlier
+// import, are dropped so that the bulk insert into an existing database does
+// not maintain them either. Until CreateIndexes has run, the database has no
+// secondary indexes; if the import fails before that, the next import with
+// deferred indexes or ApplyMigrationsFromCLI recreates them.
+//
+// It is equivalent to ApplySchemaFromCLIWithOptions(dbPath, DefaultOptions()).
+//
+// Parameters:
+//   - dbPath: Path to the database file
+//
+// Returns:
+//   - []string: Deferred CREATE INDEX statements (in migration order)
+//   - error: Any error encountered during the process
+//
+// Example:
+//
+//	indexes, err := database.ApplySchemaFromCLI("./eve-sde.db")
+//	// ... bulk import ...
+//	err = database.CreateIndexes(ctx, db, indexes)
+func ApplySchemaFromCLI(dbPath string) ([]string, error) {
+	return ApplySchemaFromCLIWithOptions(dbPath, DefaultOptions())
+}
+
+// ApplySchemaFromCLIWithOptions works like ApplySchemaFromCLI but opens the
+// database with the given connection options (see
+// ApplyMigrationsFromCLIWithOptions).
+func ApplySchemaFromCLIWithOptions(dbPath string, opts Options) ([]string, error) {
+	db, err := NewDBWithOptions(dbPath, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database for migrations: %w", err)
+	}
+	defer func() { _ = Close(db) }()
+
+	scripts, err := loadCLIMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var deferred []string
+	for _, script := range scripts {
+		schema, indexes := SplitIndexStatements(script.SQL)
+		if schema != "" {
+			if _, err := db.Exec(schema); err != nil {
+				return nil, fmt.Errorf("failed to execute migration %s: %w", script.Name, err)
+			}
+		}
+		deferred = append(deferred, indexes...)
+	}
+
+	if err := dropIndexes(db, deferred); err != nil {
+		return nil, err
+	}
+	return deferred, nil
+}
+
+// dropIndexes drops the indexes created by the CREATE INDEX statements if
+// they exist.
+func dropIndexes(db *sqlx.DB, statements []string) error {
+	for _, stmt := range statements {
+		name := indexName(stmt)
+		if name == "" {
+			return fmt.Errorf("failed to determine index name (%s)", stmt)
+		}
+		if _, err := db.Exec("DROP INDEX IF EXISTS " + name); err != nil {
+			return fmt.Errorf("failed to drop index %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// CreateIndexes executes previously deferred CREATE INDEX statements.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - db: Database connection
+//   - statements: CREATE INDEX statements (e.g., from ApplySchemaFromCLI)
+//
+// Returns:
+//   - error: The first error encountered; remaining statements are not executed
+func CreateIndexes(ctx context.Context, db *sqlx.DB, statements []string) error {
+	for _, stmt := range statements {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to create index (%s): %w", stmt, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestSplitIndexStatements tests separation of secondary indexes from schema statements
+func TestSplitIndexStatements(t *testing.T) {
+	script := `-- Migration: test
+CREATE TABLE IF NOT EXISTS items (
+    id INTEGER PRIMARY KEY, -- primary key
+    groupID INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS idx_items_groupID ON items(groupID);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_items_unique ON items(id, groupID);
+create index idx_items_lower ON items(groupID);
+`
+
+	schema, indexes := SplitIndexStatements(script)
+
+	if len(indexes) != 2 {
+		t.Fatalf("expected 2 secondary indexes, got %d: %v", len(indexes), indexes)
+	}
+	if !strings.Contains(indexes[0], "idx_items_groupID") {
+		t.Errorf("expected first index idx_items_groupID, got %s", indexes[0])
+	}
+	if !strings.Contains(indexes[1], "idx_items_lower") {
+		t.Errorf("expected second index idx_items_lower, got %s", indexes[1])
+	}
+
+	if !strings.Contains(schema, "CREATE TABLE IF NOT EXISTS items") {
+		t.Errorf("expected schema to contain table definition, got: %s", schema)
+	}
+	if !strings.Contains(schema, "idx_items_unique") {
+		t.Errorf("expected UNIQUE index to stay in schema, got: %s", schema)
+	}
+	if strings.Contains(schema, "idx_items_groupID") {
+		t.Errorf("expected secondary index to be removed from schema, got: %s", schema)
+	}
+}
+
+// TestSplitIndexStatements_Literals tests that ";" and "--" inside literals,
+// comments and trigger bodies do not split statements
+func TestSplitIndexStatements_Literals(t *testing.T) {
+	script := `CREATE TABLE items (
+    id INTEGER PRIMARY KEY,
+    name TEXT DEFAULT 'a--b;c', /* ; -- */
+    "odd;name" TEXT
+);
+CREATE TRIGGER trg_items AFTER INSERT ON items BEGIN
+    UPDATE items SET name = 'x;y' WHERE id = NEW.id;
+    UPDATE items SET "odd;name" = '--' WHERE id = NEW.id;
+END;
+CREATE INDEX idx_items_name ON items(name) -- trailing comment
+;
+`
+
+	schema, indexes := SplitIndexStatements(script)
+
+	if len(indexes) != 1 || !strings.Contains(indexes[0], "idx_items_name") {
+		t.Fatalf("expected only idx_items_name as secondary index, got %v", indexes)
+	}
+	if !strings.Contains(schema, "DEFAULT 'a--b;c'") {
+		t.Errorf("expected default literal to stay intact, got: %s", schema)
+	}
+	if !strings.Contains(schema, "'x;y' WHERE id = NEW.id;\n    UPDATE") {
+		t.Errorf("expected trigger body to stay intact, got: %s", schema)
+	}
+
+	db, err := NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer func() { _ = Close(db) }()
+
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("failed to execute schema: %v\n%s", err, schema)
+	}
+	if _, err := db.Exec(indexes[0]); err != nil {
+		t.Fatalf("failed to create index: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO items (id) VALUES (1)`); err != nil {
+		t.Fatalf("failed to insert row: %v", err)
+	}
+	var name string
+	if err := db.Get(&name, `SELECT name FROM items WHERE id = 1`); err != nil {
+		t.Fatalf("failed to query row: %v", err)
+	}
+	if name != "x;y" {
+		t.Errorf("expected trigger to set name 'x;y', got %q", name)
+	}
+}
+
+// TestApplySchemaFromCLI_DefersIndexes tests that tables are created without secondary indexes
+func TestApplySchemaFromCLI_DefersIndexes(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "deferred.db")
+
+	indexes, err := ApplySchemaFromCLI(dbPath)
+	if err != nil {
+		t.Fatalf("ApplySchemaFromCLI failed: %v", err)
+	}
+	if len(indexes) == 0 {
+		t.Fatal("expected deferred index statements, got none")
+	}
+
+	db, err := NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer func() { _ = Close(db) }()
+
+	var tableCount int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='invTypes'").Scan(&tableCount); err != nil {
+		t.Fatalf("failed to query tables: %v", err)
+	}
+	if tableCount != 1 {
+		t.Error("expected invTypes table to exist")
+	}
+
+	countIndexes := func() int {
+		var n int
+		if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='index' AND name LIKE 'idx_%'").Scan(&n); err != nil {
+			t.Fatalf("failed to count indexes: %v", err)
+		}
+		return n
+	}
+
+	if n := countIndexes(); n != 0 {
+		t.Errorf("expected no secondary indexes before CreateIndexes, got %d", n)
+	}
+
+	if err := CreateIndexes(context.Background(), db, indexes); err != nil {
+		t.Fatalf("CreateIndexes failed: %v", err)
+	}
+
+	if n := countIndexes(); n != len(indexes) {
+		t.Errorf("expected %d indexes after CreateIndexes, got %d", len(indexes), n)
+	}
+}
+
+// TestApplySchemaFromCLI_ExistingDatabase tests that deferring indexes on a
+// database with indexes from an earlier import drops them until CreateIndexes
+func TestApplySchemaFromCLI_ExistingDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "existing.db")
+	if err := ApplyMigrationsFromCLI(dbPath); err != nil {
+		t.Fatalf("ApplyMigrationsFromCLI failed: %v", err)
+	}
+
+	indexes, err := ApplySchemaFromCLI(dbPath)
+	if err != nil {
+		t.Fatalf("ApplySchemaFromCLI failed: %v", err)
+	}
+
+	db, err := NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer func() { _ = Close(db) }()
+
+	countIndexes := func() int {
+		var n int
+		if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='index' AND name LIKE 'idx_%'").Scan(&n); err != nil {
+			t.Fatalf("failed to count indexes: %v", err)
+		}
+		return n
+	}
+
+	if n := countIndexes(); n != 0 {
+		t.Errorf("expected existing secondary indexes to be dropped, got %d", n)
+	}
+	if err := CreateIndexes(context.Background(), db, indexes); err != nil {
+		t.Fatalf("CreateIndexes failed: %v", err)
+	}
+	if n := countIndexes(); n != len(indexes) {
+		t.Errorf("expected %d indexes after CreateIndexes, got %d", len(indexes), n)
+	}
+}
+
+// TestIndexName tests extracting the index name from CREATE INDEX statements
+func TestIndexName(t *testing.T) {
+	tests := map[string]string{
+		"CREATE INDEX IF NOT EXISTS idx_invTypes_groupID ON invTypes(groupID)": "idx_invTypes_groupID",
+		"create index idx_a on a (x)":                                          "idx_a",
+		"CREATE INDEX\n    idx_b\nON b(x)":                                     "idx_b",
+		`CREATE INDEX "idx ""quoted""" ON c(x)`:                                `"idx ""quoted"""`,
+		"CREATE INDEX [idx c] ON c(x)":                                         "[idx c]",
+		"CREATE INDEX main.idx_d ON d(x)":                                      "main.idx_d",
+		"CREATE TABLE t (x)":                                                   "",
+		"CREATE INDEX ON t(x)":                                                 "",
+	}
+	for stmt, expected := range tests {
+		if got := indexName(stmt); got != expected {
+			t.Errorf("indexName(%q) = %q, want %q", stmt, got, expected)
+		}
+	}
+}
+
+// TestCreateIndexes_InvalidStatement tests error reporting for broken index statements
+func TestCreateIndexes_InvalidStatement(t *testing.T) {
+	db := NewTestDB(t)
+
+	err := CreateIndexes(context.Background(), db, []string{
+		"CREATE INDEX idx_missing ON missing_table(col)",
+	})
+	if err == nil {
+		t.Fatal("expected error for index on missing table")
+	}
+	if !strings.Contains(err.Error(), "idx_missing") {
+		t.Errorf("expected error to mention statement, got: %v", err)
+	}
+}
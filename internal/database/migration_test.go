@@ -2637,6 +2637,55 @@ func TestMigration_005_Universe_Idempotence(t *testing.T) {
 	}
 }
 
+// TestMigration_006_SDEMetadata tests that the _sde table of 006_sde_metadata.sql
+// holds a single row: a second insert (re-import) replaces the metadata row
+func TestMigration_006_SDEMetadata(t *testing.T) {
+	db, err := NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("NewDB failed: %v", err)
+	}
+	defer func() {
+		_ = Close(db)
+	}()
+
+	// Read migration file
+	migrationPath := filepath.Join("..", "..", "migrations", "sqlite", "006_sde_metadata.sql")
+	migrationSQL, err := os.ReadFile(migrationPath)
+	if err != nil {
+		t.Fatalf("Failed to read migration file: %v", err)
+	}
+
+	// Execute migration twice (idempotent)
+	for i := 0; i < 2; i++ {
+		if _, err := db.Exec(string(migrationSQL)); err != nil {
+			t.Fatalf("Failed to execute migration (run %d): %v", i+1, err)
+		}
+	}
+
+	// Plain INSERTs as issued by the importer
+	for _, version := range []string{"20250101", "20250201"} {
+		if _, err := db.Exec("INSERT INTO _sde (version, releaseDate) VALUES (?, ?)", version, "2025-01-01"); err != nil {
+			t.Fatalf("Failed to insert metadata %s: %v", version, err)
+		}
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM _sde").Scan(&count); err != nil {
+		t.Fatalf("Failed to count rows: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 metadata row after re-import, got %d", count)
+	}
+
+	var version string
+	if err := db.QueryRow("SELECT version FROM _sde").Scan(&version); err != nil {
+		t.Fatalf("Failed to query version: %v", err)
+	}
+	if version != "20250201" {
+		t.Errorf("Expected version '20250201', got '%s'", version)
+	}
+}
+
 // =============================================================================
 // Integration Tests for All Migrations
 // =============================================================================
@@ -2825,9 +2874,9 @@ func TestMigrationsApply_CorrectOrder(t *testing.T) {
 		}
 	}
 
-	// Verify we have exactly 5 migration files
-	if len(migrationFiles) != 5 {
-		t.Errorf("Expected 5 migration files, got %d", len(migrationFiles))
+	// Verify we have exactly 6 migration files
+	if len(migrationFiles) != 6 {
+		t.Errorf("Expected 6 migration files, got %d", len(migrationFiles))
 	}
 
 	// Verify correct order (should be sorted numerically)
@@ -2837,6 +2886,7 @@ func TestMigrationsApply_CorrectOrder(t *testing.T) {
 		"003_blueprints.sql",
 		"004_dogma.sql",
 		"005_universe.sql",
+		"006_sde_metadata.sql",
 	}
 
 	// Sort the files (as ApplyMigrations does)
@@ -0,0 +1,173 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Default values for Options (see ADR-001).
+const (
+	DefaultJournalMode = "WAL"
+	DefaultSynchronous = "NORMAL"
+	DefaultCacheSizeMB = 64
+	DefaultBusyTimeout = 5 * time.Second
+)
+
+// journalModes lists the valid values for PRAGMA journal_mode.
+var journalModes = []string{"WAL", "DELETE", "TRUNCATE", "PERSIST", "MEMORY", "OFF"}
+
+// synchronousLevels lists the valid values for PRAGMA synchronous.
+var synchronousLevels = []string{"OFF", "NORMAL", "FULL", "EXTRA"}
+
+// JournalModes returns the valid values for Options.JournalMode in upper
+// case; Validate compares them case-insensitively.
+func JournalModes() []string {
+	return append([]string(nil), journalModes...)
+}
+
+// SynchronousLevels returns the valid values for Options.Synchronous in
+// upper case; Validate compares them case-insensitively.
+func SynchronousLevels() []string {
+	return append([]string(nil), synchronousLevels...)
+}
+
+// Options configures how NewDBWithOptions opens a SQLite database.
+//
+// Empty journal mode and synchronous level and a zero cache size use the
+// defaults from DefaultOptions; other numeric fields are applied as given
+// (0 disables mmap, keeps SQLite's page size and disables the busy timeout).
+type Options struct {
+	// JournalMode is the PRAGMA journal_mode (WAL, DELETE, TRUNCATE, PERSIST, MEMORY, OFF).
+	// It is not changed for :memory:, read-only and immutable databases.
+	JournalMode string
+	// Synchronous is the PRAGMA synchronous level (OFF, NORMAL, FULL, EXTRA).
+	Synchronous string
+	// CacheSizeMB is the page cache size in MB (PRAGMA cache_size = -CacheSizeMB*1000, 0 = default).
+	CacheSizeMB int
+	// MmapSizeMB is the memory-mapped I/O size in MiB (PRAGMA mmap_size, 0 = disabled).
+	MmapSizeMB int
+	// BusyTimeout is how long to wait for a locked database (PRAGMA busy_timeout).
+	BusyTimeout time.Duration
+	// PageSize is the PRAGMA page_size in bytes (power of two, 512-65536; 0 = SQLite default).
+	// It only takes effect for new databases or after VACUUM.
+	PageSize int
+	// ReadOnly opens the database with mode=ro; writes fail.
+	ReadOnly bool
+	// Immutable opens the database with immutable=1 (implies ReadOnly). SQLite
+	// then skips all locking and change detection, so the file must not be
+	// modified while it is open.
+	Immutable bool
+}
+
+// DefaultOptions returns the options used by NewDB.
+func DefaultOptions() Options {
+	return Options{
+		JournalMode: DefaultJournalMode,
+		Synchronous: DefaultSynchronous,
+		CacheSizeMB: DefaultCacheSizeMB,
+		BusyTimeout: DefaultBusyTimeout,
+	}
+}
+
+// Validate checks that all options have valid values.
+func (o Options) Validate() error {
+	if o.JournalMode != "" && !containsFold(journalModes, o.JournalMode) {
+		return fmt.Errorf("invalid journal_mode %q (must be: %s)", o.JournalMode, strings.Join(journalModes, ", "))
+	}
+	if o.Synchronous != "" && !containsFold(synchronousLevels, o.Synchronous) {
+		return fmt.Errorf("invalid synchronous %q (must be: %s)", o.Synchronous, strings.Join(synchronousLevels, ", "))
+	}
+	if o.CacheSizeMB < 0 {
+		return fmt.Errorf("invalid cache_size_mb %d (must be >= 0)", o.CacheSizeMB)
+	}
+	if o.MmapSizeMB < 0 {
+		return fmt.Errorf("invalid mmap_size_mb %d (must be >= 0)", o.MmapSizeMB)
+	}
+	if o.BusyTimeout < 0 {
+		return fmt.Errorf("invalid busy_timeout %v (must be >= 0)", o.BusyTimeout)
+	}
+	if o.PageSize != 0 && (o.PageSize < 512 || o.PageSize > 65536 || o.PageSize&(o.PageSize-1) != 0) {
+		return fmt.Errorf("invalid page_size %d (must be a power of two between 512 and 65536)", o.PageSize)
+	}
+	return nil
+}
+
+// readOnly reports whether the database is opened without write access.
+func (o Options) readOnly() bool {
+	return o.ReadOnly || o.Immutable
+}
+
+// withDefaults fills empty fields with the defaults.
+func (o Options) withDefaults() Options {
+	if o.CacheSizeMB == 0 {
+		o.CacheSizeMB = DefaultCacheSizeMB
+	}
+	if o.JournalMode == "" {
+		o.JournalMode = DefaultJournalMode
+	}
+	if o.Synchronous == "" {
+		o.Synchronous = DefaultSynchronous
+	}
+	o.JournalMode = strings.ToUpper(o.JournalMode)
+	o.Synchronous = strings.ToUpper(o.Synchronous)
+	return o
+}
+
+// dsn builds the data source name for path, adding mode=ro and immutable=1
+// as URI parameters when requested.
+func (o Options) dsn(path string) string {
+	if !o.readOnly() {
+		return path
+	}
+
+	params := []string{"mode=ro"}
+	if o.Immutable {
+		params = append(params, "immutable=1")
+	}
+
+	dsn := path
+	if !strings.HasPrefix(dsn, "file:") {
+		dsn = "file:" + dsn
+	}
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	return dsn + sep + strings.Join(params, "&")
+}
+
+// pragmas returns the PRAGMA statements for the options.
+func (o Options) pragmas(path string) []string {
+	var pragmas []string
+
+	// journal_mode and page_size write to the database file. WAL mode can
+	// also cause issues with :memory: databases and the race detector.
+	if path != ":memory:" && !o.readOnly() {
+		if o.PageSize > 0 {
+			// Must be set before journal_mode = WAL to affect new databases
+			pragmas = append(pragmas, fmt.Sprintf("PRAGMA page_size = %d", o.PageSize))
+		}
+		pragmas = append(pragmas, "PRAGMA journal_mode = "+o.JournalMode)
+	}
+
+	pragmas = append(pragmas,
+		"PRAGMA synchronous = "+o.Synchronous,
+		"PRAGMA foreign_keys = ON",
+		fmt.Sprintf("PRAGMA cache_size = %d", -o.CacheSizeMB*1000),
+		"PRAGMA temp_store = MEMORY",
+		fmt.Sprintf("PRAGMA busy_timeout = %d", o.BusyTimeout.Milliseconds()),
+		fmt.Sprintf("PRAGMA mmap_size = %d", int64(o.MmapSizeMB)<<20),
+	)
+	return pragmas
+}
+
+// containsFold reports whether values contains s (case-insensitive).
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package database
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestOptions_Validate tests validation of each option
+func TestOptions_Validate(t *testing.T) {
+	if err := DefaultOptions().Validate(); err != nil {
+		t.Fatalf("default options must be valid: %v", err)
+	}
+	if err := (Options{}).Validate(); err != nil {
+		t.Fatalf("zero options must be valid: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Options)
+		want   string
+	}{
+		{"journal mode", func(o *Options) { o.JournalMode = "FAST" }, "journal_mode"},
+		{"synchronous", func(o *Options) { o.Synchronous = "SOMETIMES" }, "synchronous"},
+		{"cache size", func(o *Options) { o.CacheSizeMB = -1 }, "cache_size_mb"},
+		{"mmap size", func(o *Options) { o.MmapSizeMB = -1 }, "mmap_size_mb"},
+		{"busy timeout", func(o *Options) { o.BusyTimeout = -time.Second }, "busy_timeout"},
+		{"page size too small", func(o *Options) { o.PageSize = 256 }, "page_size"},
+		{"page size not power of two", func(o *Options) { o.PageSize = 3000 }, "page_size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			tt.modify(&opts)
+			err := opts.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error mentioning %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	opts := DefaultOptions()
+	opts.JournalMode = "delete"
+	opts.Synchronous = "full"
+	if err := opts.Validate(); err != nil {
+		t.Errorf("lower-case values must be valid: %v", err)
+	}
+}
+
+// TestNewDBWithOptions_Pragmas tests that the options are applied as PRAGMAs
+func TestNewDBWithOptions_Pragmas(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "options.db")
+	db, err := NewDBWithOptions(dbPath, Options{
+		JournalMode: "delete",
+		Synchronous: "FULL",
+		CacheSizeMB: 16,
+		MmapSizeMB:  1,
+		BusyTimeout: 250 * time.Millisecond,
+		PageSize:    8192,
+	})
+	if err != nil {
+		t.Fatalf("NewDBWithOptions failed: %v", err)
+	}
+	defer func() { _ = Close(db) }()
+
+	expected := map[string]string{
+		"journal_mode": "delete",
+		"synchronous":  "2", // FULL = 2
+		"cache_size":   "-16000",
+		"mmap_size":    "1048576",
+		"busy_timeout": "250",
+		"page_size":    "8192",
+	}
+	for pragma, want := range expected {
+		var got string
+		if err := db.QueryRow("PRAGMA " + pragma).Scan(&got); err != nil {
+			t.Fatalf("failed to query %s: %v", pragma, err)
+		}
+		if got != want {
+			t.Errorf("%s = %s, want %s", pragma, got, want)
+		}
+	}
+}
+
+// TestNewDBWithOptions_ReadOnly tests read-only and immutable open modes
+func TestNewDBWithOptions_ReadOnly(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "readonly.db")
+	db, err := NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("NewDB failed: %v", err)
+	}
+	if _, err := db.Exec("CREATE TABLE t (id INTEGER)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	_ = Close(db)
+
+	for _, opts := range []Options{{ReadOnly: true}, {Immutable: true}} {
+		db, err := NewDBWithOptions(dbPath, opts)
+		if err != nil {
+			t.Fatalf("NewDBWithOptions(%+v) failed: %v", opts, err)
+		}
+		var count int
+		if err := db.QueryRow("SELECT COUNT(*) FROM t").Scan(&count); err != nil {
+			t.Errorf("read failed with %+v: %v", opts, err)
+		}
+		if _, err := db.Exec("INSERT INTO t VALUES (1)"); err == nil {
+			t.Errorf("expected write to fail with %+v", opts)
+		}
+		_ = Close(db)
+	}
+
+	if _, err := NewDBWithOptions(filepath.Join(t.TempDir(), "missing.db"), Options{ReadOnly: true}); err == nil {
+		t.Error("expected read-only open of a missing file to fail")
+	}
+	if _, err := NewDBWithOptions(":memory:", Options{ReadOnly: true}); err == nil {
+		t.Error("expected read-only :memory: database to be rejected")
+	}
+	if _, err := NewDBWithOptions(":memory:", Options{JournalMode: "FAST"}); err == nil {
+		t.Error("expected invalid options to be rejected")
+	}
+}
+
+// TestApplyMigrationsFromCLIWithOptions tests that migrations keep the journal mode of the options
+func TestApplyMigrationsFromCLIWithOptions(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "migrations.db")
+	opts := Options{JournalMode: "DELETE"}
+	if err := ApplyMigrationsFromCLIWithOptions(dbPath, opts); err != nil {
+		t.Fatalf("ApplyMigrationsFromCLIWithOptions failed: %v", err)
+	}
+
+	db, err := NewDBWithOptions(dbPath, Options{ReadOnly: true})
+	if err != nil {
+		t.Fatalf("NewDBWithOptions failed: %v", err)
+	}
+	defer func() { _ = Close(db) }()
+
+	var mode string
+	if err := db.QueryRow("PRAGMA journal_mode").Scan(&mode); err != nil {
+		t.Fatalf("failed to query journal_mode: %v", err)
+	}
+	if mode != "delete" {
+		t.Errorf("journal_mode = %s, want delete", mode)
+	}
+	var tables int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'invTypes'").Scan(&tables); err != nil || tables != 1 {
+		t.Errorf("expected invTypes table after migrations (err: %v)", err)
+	}
+}
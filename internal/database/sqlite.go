@@ -12,6 +12,8 @@ import (
 // NewDB creates and initializes a new SQLite database connection
 // with optimized PRAGMAs for performance according to ADR-001 and ADR-002.
 //
+// It is equivalent to NewDBWithOptions(path, DefaultOptions()).
+//
 // Parameters:
 //   - path: File path to the SQLite database file. Use ":memory:" for in-memory databases.
 //
@@ -19,8 +21,37 @@ import (
 //   - *sqlx.DB: Initialized database connection
 //   - error: Any error encountered during connection setup
 func NewDB(path string) (*sqlx.DB, error) {
+	return NewDBWithOptions(path, DefaultOptions())
+}
+
+// NewDBWithOptions creates and initializes a new SQLite database connection
+// using the given options for journal mode, synchronous level, cache size,
+// mmap size, busy timeout, page size and read-only/immutable mode.
+//
+// Parameters:
+//   - path: File path to the SQLite database file. Use ":memory:" for in-memory databases.
+//   - opts: Connection options; see Options for defaults of empty fields
+//
+// Returns:
+//   - *sqlx.DB: Initialized database connection
+//   - error: Invalid options or any error encountered during connection setup
+//
+// Example:
+//
+//	opts := database.DefaultOptions()
+//	opts.ReadOnly = true
+//	db, err := database.NewDBWithOptions("./eve-sde.db", opts)
+func NewDBWithOptions(path string, opts Options) (*sqlx.DB, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid database options: %w", err)
+	}
+	if opts.readOnly() && path == ":memory:" {
+		return nil, fmt.Errorf("invalid database options: read-only mode requires a database file")
+	}
+	opts = opts.withDefaults()
+
 	// Open database connection
-	db, err := sqlx.Open("sqlite3", path)
+	db, err := sqlx.Open("sqlite3", opts.dsn(path))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -30,7 +61,7 @@ func NewDB(path string) (*sqlx.DB, error) {
 	db.SetMaxIdleConns(1)
 
 	// Apply performance PRAGMAs
-	if err := applyPragmas(db, path); err != nil {
+	if err := applyPragmas(db, opts.pragmas(path)); err != nil {
 		_ = db.Close()
 		return nil, fmt.Errorf("failed to apply PRAGMAs: %w", err)
 	}
@@ -46,28 +77,15 @@ func NewDB(path string) (*sqlx.DB, error) {
 
 // applyPragmas applies SQLite performance optimizations as defined in ADR-001.
 //
-// PRAGMAs applied:
-//   - journal_mode = WAL: Write-Ahead Logging for better concurrency (skipped for :memory: databases)
+// PRAGMAs applied with DefaultOptions:
+//   - journal_mode = WAL: Write-Ahead Logging for better concurrency (skipped for :memory: and read-only databases)
 //   - synchronous = NORMAL: Balance between safety and performance
 //   - foreign_keys = ON: Enforce referential integrity
 //   - cache_size = -64000: 64MB cache for better performance
 //   - temp_store = MEMORY: Store temporary tables in memory
 //   - busy_timeout = 5000: Wait up to 5 seconds if database is locked
-func applyPragmas(db *sqlx.DB, path string) error {
-	pragmas := []string{
-		"PRAGMA synchronous = NORMAL",
-		"PRAGMA foreign_keys = ON",
-		"PRAGMA cache_size = -64000",
-		"PRAGMA temp_store = MEMORY",
-		"PRAGMA busy_timeout = 5000",
-	}
-
-	// Only use WAL mode for file-based databases
-	// WAL mode can cause issues with :memory: databases and race detector
-	if path != ":memory:" {
-		pragmas = append([]string{"PRAGMA journal_mode = WAL"}, pragmas...)
-	}
-
+//   - mmap_size = 0: Memory-mapped I/O disabled
+func applyPragmas(db *sqlx.DB, pragmas []string) error {
 	for _, pragma := range pragmas {
 		if _, err := db.Exec(pragma); err != nil {
 			return fmt.Errorf("failed to execute '%s': %w", pragma, err)
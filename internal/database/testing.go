@@ -117,6 +117,8 @@ func ApplyMigrations(db *sqlx.DB) error {
 // by opening a connection, applying migrations, and closing the connection.
 // This is a convenience function for CLI usage where you already have a db path.
 //
+// It is equivalent to ApplyMigrationsFromCLIWithOptions(dbPath, DefaultOptions()).
+//
 // Parameters:
 //   - dbPath: Path to the database file
 //
@@ -129,13 +131,51 @@ func ApplyMigrations(db *sqlx.DB) error {
 //	    log.Fatalf("Failed to apply migrations: %v", err)
 //	}
 func ApplyMigrationsFromCLI(dbPath string) error {
+	return ApplyMigrationsFromCLIWithOptions(dbPath, DefaultOptions())
+}
+
+// ApplyMigrationsFromCLIWithOptions works like ApplyMigrationsFromCLI but
+// opens the database with the given connection options.
+//
+// Parameters:
+//   - dbPath: Path to the database file
+//   - opts: Connection options (use the same options as the import connection,
+//     otherwise e.g. journal_mode is changed back to the default)
+//
+// Returns:
+//   - error: Any error encountered during the process
+func ApplyMigrationsFromCLIWithOptions(dbPath string, opts Options) error {
 	// Open a temporary connection just for migrations
-	db, err := NewDB(dbPath)
+	db, err := NewDBWithOptions(dbPath, opts)
 	if err != nil {
 		return fmt.Errorf("failed to open database for migrations: %w", err)
 	}
 	defer func() { _ = Close(db) }()
 
+	scripts, err := loadCLIMigrations()
+	if err != nil {
+		return err
+	}
+
+	// Apply each migration in order
+	for _, script := range scripts {
+		if _, err := db.Exec(script.SQL); err != nil {
+			return fmt.Errorf("failed to execute migration %s: %w", script.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// migrationScript is a single migration file loaded from disk.
+type migrationScript struct {
+	Name string // File name (e.g., 001_inv_types.sql)
+	SQL  string // Full SQL content of the file
+}
+
+// loadCLIMigrations locates the migrations directory relative to the working
+// directory and reads all migration files in sorted order.
+func loadCLIMigrations() ([]migrationScript, error) {
 	// Find migrations directory - look for it relative to executable or common locations
 	migrationsDirs := []string{
 		"./migrations/sqlite",                          // Current directory
@@ -153,13 +193,13 @@ func ApplyMigrationsFromCLI(dbPath string) error {
 	}
 
 	if migrationsDir == "" {
-		return fmt.Errorf("could not find migrations directory in any of: %v", migrationsDirs)
+		return nil, fmt.Errorf("could not find migrations directory in any of: %v", migrationsDirs)
 	}
 
 	// Read all migration files
 	entries, err := os.ReadDir(migrationsDir)
 	if err != nil {
-		return fmt.Errorf("failed to read migrations directory: %w", err)
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
 	}
 
 	// Filter and sort SQL files
@@ -172,22 +212,18 @@ func ApplyMigrationsFromCLI(dbPath string) error {
 	sort.Strings(migrationFiles)
 
 	if len(migrationFiles) == 0 {
-		return fmt.Errorf("no migration files found in %s", migrationsDir)
+		return nil, fmt.Errorf("no migration files found in %s", migrationsDir)
 	}
 
-	// Apply each migration in order
+	scripts := make([]migrationScript, 0, len(migrationFiles))
 	for _, filename := range migrationFiles {
 		migrationPath := filepath.Join(migrationsDir, filename)
 		migrationSQL, err := os.ReadFile(migrationPath)
 		if err != nil {
-			return fmt.Errorf("failed to read migration file %s: %w", filename, err)
-		}
-
-		// Execute migration
-		if _, err := db.Exec(string(migrationSQL)); err != nil {
-			return fmt.Errorf("failed to execute migration %s: %w", filename, err)
+			return nil, fmt.Errorf("failed to read migration file %s: %w", filename, err)
 		}
+		scripts = append(scripts, migrationScript{Name: filename, SQL: string(migrationSQL)})
 	}
 
-	return nil
+	return scripts, nil
 }
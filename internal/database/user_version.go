@@ -0,0 +1,38 @@
+// Package database provides SQLite database connection management
+// with SDE build tracking via PRAGMA user_version.
+package database
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// GetUserVersion returns the value of PRAGMA user_version.
+//
+// The importer stores the SDE build number in user_version, so it can be
+// read without any metadata table. A fresh database returns 0.
+func GetUserVersion(ctx context.Context, db *sqlx.DB) (int64, error) {
+	var v int64
+	if err := db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&v); err != nil {
+		return 0, fmt.Errorf("failed to read user_version: %w", err)
+	}
+	return v, nil
+}
+
+// SetUserVersion sets PRAGMA user_version.
+//
+// SQLite stores user_version as a signed 32-bit integer; values outside
+// 0..math.MaxInt32 are rejected.
+func SetUserVersion(ctx context.Context, db *sqlx.DB, v int64) error {
+	if v < 0 || v > math.MaxInt32 {
+		return fmt.Errorf("user_version %d out of range (0..%d)", v, math.MaxInt32)
+	}
+	// PRAGMA does not accept bound parameters
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", v)); err != nil {
+		return fmt.Errorf("failed to set user_version: %w", err)
+	}
+	return nil
+}
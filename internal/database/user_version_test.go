@@ -0,0 +1,39 @@
+package database
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+// TestUserVersion_RoundTrip tests writing and reading PRAGMA user_version
+func TestUserVersion_RoundTrip(t *testing.T) {
+	db := NewTestDB(t)
+	ctx := context.Background()
+
+	v, err := GetUserVersion(ctx, db)
+	if err != nil {
+		t.Fatalf("GetUserVersion failed: %v", err)
+	}
+	if v != 0 {
+		t.Errorf("expected user_version 0 on fresh database, got %d", v)
+	}
+
+	if err := SetUserVersion(ctx, db, 3064089); err != nil {
+		t.Fatalf("SetUserVersion failed: %v", err)
+	}
+	if v, _ = GetUserVersion(ctx, db); v != 3064089 {
+		t.Errorf("expected user_version 3064089, got %d", v)
+	}
+}
+
+// TestSetUserVersion_OutOfRange tests rejection of values that do not fit into 32 bits
+func TestSetUserVersion_OutOfRange(t *testing.T) {
+	db := NewTestDB(t)
+
+	for _, v := range []int64{-1, math.MaxInt32 + 1} {
+		if err := SetUserVersion(context.Background(), db, v); err == nil {
+			t.Errorf("expected error for user_version %d", v)
+		}
+	}
+}
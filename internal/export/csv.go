@@ -0,0 +1,192 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CSVOptions configures the CSV export.
+type CSVOptions struct {
+	// Delimiter separates the fields (0 = ','). Must not be '"', '\r' or '\n'.
+	Delimiter rune
+	// Header writes a first row with the column names
+	Header bool
+	// Null is written for NULL values (default: empty field, like an empty string)
+	Null string
+	// DecimalComma writes REAL values with a comma instead of a dot
+	DecimalComma bool
+	// Gzip compresses the file written by WriteCSVFile
+	Gzip bool
+}
+
+// CSVFileName returns the file name for table: "<table>.csv", or
+// "<table>.csv.gz" with gzip compression.
+func CSVFileName(table string, gzip bool) string {
+	if gzip {
+		return table + ".csv.gz"
+	}
+	return table + ".csv"
+}
+
+// WriteCSVFile writes table to the file path (see WriteCSV), gzip-compressed
+// if opts.Gzip is set.
+//
+// The file is written to a temporary file next to path and renamed when
+// complete, so path never contains a partial export.
+//
+// Returns:
+//   - int64: Number of exported rows (without the header)
+//   - error: Any error encountered; the temporary file is removed
+func WriteCSVFile(ctx context.Context, db sqlx.QueryerContext, table, path string, opts CSVOptions) (int64, error) {
+	var rows int64
+	err := writeFile(path, opts.Gzip, func(w io.Writer) (err error) {
+		rows, err = WriteCSV(ctx, db, table, w, opts)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return rows, nil
+}
+
+// WriteCSV writes all rows of table as RFC 4180 CSV to w.
+//
+// Rows are read one at a time, so arbitrarily large tables can be exported
+// with constant memory. Columns appear in table order; rows in the order
+// returned by SQLite (rowid / primary key order for SDE tables).
+//
+// Returns:
+//   - int64: Number of exported rows (without the header)
+//   - error: Any error encountered; w may contain a partial export
+func WriteCSV(ctx context.Context, db sqlx.QueryerContext, table string, w io.Writer, opts CSVOptions) (int64, error) {
+	cw := newCSVWriter(w, opts.Delimiter)
+
+	rows, err := db.QueryContext(ctx, "SELECT * FROM "+quoteIdent(table))
+	if err != nil {
+		return 0, fmt.Errorf("failed to query table %s: %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	if opts.Header {
+		if err := cw.write(columns); err != nil {
+			return 0, fmt.Errorf("failed to write header of %s: %w", table, err)
+		}
+	}
+
+	values := make([]interface{}, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	record := make([]string, len(columns))
+
+	var count int64
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return count, fmt.Errorf("failed to scan row of %s: %w", table, err)
+		}
+		for i, v := range values {
+			record[i] = formatCSVValue(v, opts)
+		}
+		if err := cw.write(record); err != nil {
+			return count, fmt.Errorf("failed to write row of %s: %w", table, err)
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("failed to read table %s: %w", table, err)
+	}
+
+	if err := cw.flush(); err != nil {
+		return count, fmt.Errorf("failed to write %s: %w", table, err)
+	}
+	return count, nil
+}
+
+// csvWriter writes RFC 4180 records. Unlike encoding/csv it keeps field
+// contents unchanged: line breaks inside quoted fields are not normalized.
+type csvWriter struct {
+	w     *bufio.Writer
+	comma string
+	err   error
+}
+
+// newCSVWriter creates a csvWriter with delimiter (0 = ',')
+func newCSVWriter(w io.Writer, delimiter rune) *csvWriter {
+	if delimiter == 0 {
+		delimiter = ','
+	}
+	return &csvWriter{w: bufio.NewWriter(w), comma: string(delimiter)}
+}
+
+// write writes one record terminated by CRLF. Fields containing the
+// delimiter, a quote or a line break are quoted, quotes are doubled.
+// The first write error is kept and returned by all later calls.
+func (c *csvWriter) write(record []string) error {
+	if c.err != nil {
+		return c.err
+	}
+	for i, field := range record {
+		if i > 0 {
+			_, _ = c.w.WriteString(c.comma)
+		}
+		if !strings.Contains(field, c.comma) && !strings.ContainsAny(field, "\"\r\n") {
+			_, _ = c.w.WriteString(field)
+			continue
+		}
+		_ = c.w.WriteByte('"')
+		_, _ = c.w.WriteString(strings.ReplaceAll(field, `"`, `""`))
+		_ = c.w.WriteByte('"')
+	}
+	_, c.err = c.w.WriteString("\r\n")
+	return c.err
+}
+
+// flush writes buffered records to the underlying writer
+func (c *csvWriter) flush() error {
+	if c.err != nil {
+		return c.err
+	}
+	c.err = c.w.Flush()
+	return c.err
+}
+
+// formatCSVValue formats a value as returned by the SQLite driver
+func formatCSVValue(v interface{}, opts CSVOptions) string {
+	switch v := v.(type) {
+	case nil:
+		return opts.Null
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		s := strconv.FormatFloat(v, 'f', -1, 64)
+		if opts.DecimalComma {
+			s = strings.Replace(s, ".", ",", 1)
+		}
+		return s
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
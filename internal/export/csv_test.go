@@ -0,0 +1,176 @@
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+	"github.com/jmoiron/sqlx"
+)
+
+// newTestDB creates a database with a small invTypes table
+func newTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "export.db"))
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	t.Cleanup(func() { _ = database.Close(db) })
+
+	_, err = db.Exec(`
+		CREATE TABLE invTypes (typeID INTEGER PRIMARY KEY, typeName TEXT NOT NULL, description TEXT, mass REAL);
+		INSERT INTO invTypes VALUES (34, 'Tritanium', 'The "main" ore, common', 0.01);
+		INSERT INTO invTypes VALUES (35, 'Pyerite', '', 1500000);
+		INSERT INTO invTypes VALUES (36, 'Mexallon', 'Line one
+line two', NULL);
+		CREATE TABLE mapRegions (regionID INTEGER PRIMARY KEY, regionName TEXT);
+		CREATE TABLE items (id INTEGER PRIMARY KEY AUTOINCREMENT);`)
+	if err != nil {
+		t.Fatalf("failed to create tables: %v", err)
+	}
+	if err := database.EnsureImportRunsTable(context.Background(), db); err != nil {
+		t.Fatalf("failed to create import runs table: %v", err)
+	}
+	return db
+}
+
+// TestWriteCSV tests RFC 4180 output with the default options
+func TestWriteCSV(t *testing.T) {
+	db := newTestDB(t)
+
+	var buf bytes.Buffer
+	rows, err := WriteCSV(context.Background(), db, "invTypes", &buf, CSVOptions{Header: true})
+	if err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	if rows != 3 {
+		t.Errorf("expected 3 rows, got %d", rows)
+	}
+
+	expected := "typeID,typeName,description,mass\r\n" +
+		"34,Tritanium,\"The \"\"main\"\" ore, common\",0.01\r\n" +
+		"35,Pyerite,,1500000\r\n" +
+		"36,Mexallon,\"Line one\nline two\",\r\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected CSV:\n%q\nexpected:\n%q", buf.String(), expected)
+	}
+}
+
+// TestWriteCSV_Options tests delimiter, decimal comma, NULL representation and header
+func TestWriteCSV_Options(t *testing.T) {
+	db := newTestDB(t)
+
+	var buf bytes.Buffer
+	opts := CSVOptions{Delimiter: ';', Null: `\N`, DecimalComma: true}
+	if _, err := WriteCSV(context.Background(), db, "invTypes", &buf, opts); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+
+	expected := "34;Tritanium;\"The \"\"main\"\" ore, common\";0,01\r\n" +
+		"35;Pyerite;;1500000\r\n" +
+		"36;Mexallon;\"Line one\nline two\";\\N\r\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected CSV:\n%q\nexpected:\n%q", buf.String(), expected)
+	}
+}
+
+// TestWriteCSV_UnknownTable tests the error for a missing table
+func TestWriteCSV_UnknownTable(t *testing.T) {
+	db := newTestDB(t)
+	if _, err := WriteCSV(context.Background(), db, "missing", io.Discard, CSVOptions{}); err == nil {
+		t.Error("expected error for unknown table")
+	}
+}
+
+// TestWriteCSVFile tests plain and gzip files
+func TestWriteCSVFile(t *testing.T) {
+	db := newTestDB(t)
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	var plain bytes.Buffer
+	if _, err := WriteCSV(ctx, db, "invTypes", &plain, CSVOptions{Header: true}); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+
+	path := filepath.Join(dir, CSVFileName("invTypes", false))
+	if rows, err := WriteCSVFile(ctx, db, "invTypes", path, CSVOptions{Header: true}); err != nil || rows != 3 {
+		t.Fatalf("WriteCSVFile failed: %d rows, %v", rows, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+	if !bytes.Equal(data, plain.Bytes()) {
+		t.Errorf("file differs from WriteCSV output:\n%q", data)
+	}
+
+	gzPath := filepath.Join(dir, CSVFileName("invTypes", true))
+	if filepath.Base(gzPath) != "invTypes.csv.gz" {
+		t.Errorf("unexpected gzip file name %s", gzPath)
+	}
+	if _, err := WriteCSVFile(ctx, db, "invTypes", gzPath, CSVOptions{Header: true, Gzip: true}); err != nil {
+		t.Fatalf("WriteCSVFile (gzip) failed: %v", err)
+	}
+	f, err := os.Open(gzPath)
+	if err != nil {
+		t.Fatalf("failed to open gzip file: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("invalid gzip file: %v", err)
+	}
+	data, err = io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("failed to decompress: %v", err)
+	}
+	if !bytes.Equal(data, plain.Bytes()) {
+		t.Errorf("decompressed file differs from WriteCSV output:\n%q", data)
+	}
+
+	// a failed export leaves no (temporary) file behind
+	failPath := filepath.Join(dir, "missing.csv")
+	if _, err := WriteCSVFile(ctx, db, "missing", failPath, CSVOptions{}); err == nil {
+		t.Error("expected error for unknown table")
+	}
+	for _, p := range []string{failPath, failPath + ".tmp"} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Errorf("%s must not exist after a failed export", p)
+		}
+	}
+}
+
+// TestTables tests table listing and filtering
+func TestTables(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	tables, err := Tables(ctx, db, nil)
+	if err != nil {
+		t.Fatalf("Tables failed: %v", err)
+	}
+	// sqlite_sequence (AUTOINCREMENT) and _import_runs are skipped
+	if expected := []string{"invTypes", "items", "mapRegions"}; !reflect.DeepEqual(tables, expected) {
+		t.Errorf("expected %v, got %v", expected, tables)
+	}
+
+	filter, err := parser.NewTableFilter([]string{"universe", "inv*"}, nil)
+	if err != nil {
+		t.Fatalf("failed to create filter: %v", err)
+	}
+	tables, err = Tables(ctx, db, filter)
+	if err != nil {
+		t.Fatalf("Tables failed: %v", err)
+	}
+	if expected := []string{"invTypes", "mapRegions"}; !reflect.DeepEqual(tables, expected) {
+		t.Errorf("expected %v, got %v", expected, tables)
+	}
+}
@@ -0,0 +1,40 @@
+// Package export writes the contents of a built SQLite database to other
+// formats.
+//
+// # CSV
+//
+// WriteCSVFile writes one table as an RFC 4180 CSV file (CRLF line endings,
+// fields quoted only when needed). Rows are streamed from the database, so
+// memory usage does not depend on the table size:
+//
+//	tables, err := export.Tables(ctx, db, nil)
+//	for _, table := range tables {
+//		path := filepath.Join(dir, export.CSVFileName(table, opts.Gzip))
+//		rows, err := export.WriteCSVFile(ctx, db, table, path, opts)
+//		// ...
+//	}
+//
+// Values are written as follows:
+//   - INTEGER: decimal number
+//   - REAL: shortest representation without exponent (e.g. "0.25"), with a
+//     comma instead of the dot if CSVOptions.DecimalComma is set
+//   - TEXT / BLOB: unchanged (JSON columns stay JSON text)
+//   - NULL: CSVOptions.Null (default: empty field)
+//
+// # PostgreSQL
+//
+// WritePostgresFile writes the selected tables as a single .sql file that
+// psql loads in one transaction: CREATE TABLE statements with PostgreSQL
+// types and primary keys, the data as COPY ... FROM stdin blocks, then
+// indexes and foreign keys:
+//
+//	counts, err := export.WritePostgresFile(ctx, db, tables, "eve_sde.sql",
+//		export.PostgresOptions{Schema: "sde"})
+//
+// The generated DDL and data are covered by golden files in
+// testdata/golden/postgres (regenerate with go test -update).
+//
+// Table selection uses the same patterns and groups as the import (see
+// parser.TableFilter). SQLite internal tables and the _import_runs metadata
+// table are never exported.
+package export
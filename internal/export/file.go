@@ -0,0 +1,55 @@
+package export
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+)
+
+// writeFile writes path through write, gzip-compressed if compress is set.
+//
+// The data is written to a temporary file next to path that is renamed when
+// complete, so path never contains a partial export. On error the temporary
+// file is removed.
+func writeFile(path string, compress bool, write func(w io.Writer) error) (err error) {
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", tmpPath, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	buf := bufio.NewWriterSize(f, 256*1024)
+	var w io.Writer = buf
+	var gz *gzip.Writer
+	if compress {
+		gz = gzip.NewWriter(buf)
+		w = gz
+	}
+
+	if err = write(w); err != nil {
+		return err
+	}
+	if gz != nil {
+		if err = gz.Close(); err != nil {
+			return fmt.Errorf("failed to compress %s: %w", path, err)
+		}
+	}
+	if err = buf.Flush(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err = os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to rename %s: %w", tmpPath, err)
+	}
+	return nil
+}
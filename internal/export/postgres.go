@@ -0,0 +1,654 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresOptions configures the PostgreSQL dump.
+type PostgresOptions struct {
+	// Schema creates the tables in this schema (CREATE SCHEMA IF NOT EXISTS
+	// and SET search_path); empty = the default search_path (usually public)
+	Schema string
+	// DropTables drops existing tables (DROP TABLE IF EXISTS ... CASCADE)
+	// before creating them, so the dump can be loaded repeatedly
+	DropTables bool
+}
+
+// TableRows is the number of rows exported from a table.
+type TableRows struct {
+	Table string
+	Rows  int64
+}
+
+// pgColumn is a column of a table in the PostgreSQL dump
+type pgColumn struct {
+	Name    string
+	Type    string // PostgreSQL type, e.g. "BIGINT"
+	NotNull bool
+	Default string // PostgreSQL default expression (empty = none)
+}
+
+// pgTable is the schema of a table in the PostgreSQL dump
+type pgTable struct {
+	Name       string
+	Columns    []pgColumn
+	PrimaryKey []string // primary key columns in key order
+	Statements []string // CREATE INDEX / ADD UNIQUE statements (after the data)
+	ForeignKey []string // ADD FOREIGN KEY statements (after all indexes)
+	Skipped    []string // comments for schema objects without a PostgreSQL equivalent
+	columnType []string // PostgreSQL type per column, for value formatting
+
+	foreignKeys []pgForeignKey // foreign keys to exported tables, see addForeignKeys
+}
+
+// pgForeignKey is a foreign key of a table in the PostgreSQL dump
+type pgForeignKey struct {
+	Columns    []string
+	RefTable   string
+	RefColumns []string // empty = primary key of RefTable
+}
+
+// typeOf returns the PostgreSQL type of the column name (empty if unknown)
+func (t *pgTable) typeOf(name string) string {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c.Type
+		}
+	}
+	return ""
+}
+
+// WritePostgresFile writes a PostgreSQL dump of tables to the file path (see
+// WritePostgresDump). The file is written to a temporary file next to path
+// and renamed when complete.
+func WritePostgresFile(ctx context.Context, db sqlx.QueryerContext, tables []string, path string, opts PostgresOptions) ([]TableRows, error) {
+	var counts []TableRows
+	err := writeFile(path, false, func(w io.Writer) (err error) {
+		counts, err = WritePostgresDump(ctx, db, tables, w, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// WritePostgresDump writes tables as a PostgreSQL dump that psql can load
+// (psql -v ON_ERROR_STOP=1 -f dump.sql).
+//
+// The dump runs in a single transaction and contains, in this order:
+//  1. CREATE TABLE with PostgreSQL types, NOT NULL, defaults and primary keys
+//  2. The data of every table as a COPY ... FROM stdin block (text format)
+//  3. Secondary indexes and UNIQUE constraints (after the data for faster loading)
+//  4. Foreign keys between exported tables
+//
+// Types are derived from the declared SQLite type using SQLite's affinity
+// rules: INTEGER → BIGINT, REAL → DOUBLE PRECISION, TEXT → TEXT,
+// BLOB → BYTEA, NUMERIC → NUMERIC (BOOLEAN, DATE, DATETIME and TIMESTAMP are
+// kept). SQLite does not enforce declared types, so a column holding values
+// its PostgreSQL type cannot represent (e.g. text in an INTEGER column) is
+// exported as TEXT with a comment. Identifiers are quoted, so mixed-case
+// names like "typeID" are preserved. Partial and expression indexes, foreign
+// keys to tables that are not exported and foreign keys between columns of
+// different types are skipped with a comment.
+//
+// Rows are streamed one at a time; every table is read twice, once for the
+// storage classes of its columns and once for COPY.
+//
+// Returns:
+//   - []TableRows: Number of exported rows per table
+//   - error: Any error encountered; w may contain a partial dump
+func WritePostgresDump(ctx context.Context, db sqlx.QueryerContext, tables []string, w io.Writer, opts PostgresOptions) ([]TableRows, error) {
+	exported := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		exported[table] = true
+	}
+
+	schema := make([]*pgTable, 0, len(tables))
+	for _, table := range tables {
+		t, err := readPostgresTable(ctx, db, table, exported)
+		if err != nil {
+			return nil, err
+		}
+		schema = append(schema, t)
+	}
+	addForeignKeys(schema)
+
+	bw := bufio.NewWriter(w)
+	writePostgresHeader(bw, tables, opts)
+
+	// 1. Tables
+	if opts.DropTables {
+		for i := len(schema) - 1; i >= 0; i-- {
+			fmt.Fprintf(bw, "DROP TABLE IF EXISTS %s CASCADE;\n", quoteIdent(schema[i].Name))
+		}
+		fmt.Fprintln(bw)
+	}
+	for _, t := range schema {
+		writeCreateTable(bw, t)
+	}
+
+	// 2. Data
+	counts := make([]TableRows, 0, len(schema))
+	for _, t := range schema {
+		rows, err := writeCopy(ctx, db, bw, t)
+		if err != nil {
+			return counts, err
+		}
+		counts = append(counts, TableRows{Table: t.Name, Rows: rows})
+	}
+
+	// 3. Indexes, 4. foreign keys
+	writeStatements(bw, "Indexes", schema, func(t *pgTable) []string { return t.Statements })
+	writeStatements(bw, "Foreign keys", schema, func(t *pgTable) []string { return t.ForeignKey })
+	writeStatements(bw, "Skipped", schema, func(t *pgTable) []string { return t.Skipped })
+
+	fmt.Fprint(bw, "COMMIT;\n")
+	if err := bw.Flush(); err != nil {
+		return counts, fmt.Errorf("failed to write PostgreSQL dump: %w", err)
+	}
+	return counts, nil
+}
+
+// writePostgresHeader writes the comment header, session settings and BEGIN
+func writePostgresHeader(w *bufio.Writer, tables []string, opts PostgresOptions) {
+	fmt.Fprint(w, "--\n-- PostgreSQL dump generated by EVE SDE Database Builder\n--\n")
+	fmt.Fprintf(w, "-- Tables: %d\n", len(tables))
+	fmt.Fprint(w, "-- Load with: psql -v ON_ERROR_STOP=1 -f <file>\n--\n\n")
+	fmt.Fprint(w, "SET client_encoding = 'UTF8';\n")
+	fmt.Fprint(w, "SET standard_conforming_strings = on;\n\n")
+	fmt.Fprint(w, "BEGIN;\n\n")
+	if opts.Schema != "" {
+		fmt.Fprintf(w, "CREATE SCHEMA IF NOT EXISTS %s;\n", quoteIdent(opts.Schema))
+		fmt.Fprintf(w, "SET search_path TO %s;\n\n", quoteIdent(opts.Schema))
+	}
+}
+
+// writeCreateTable writes the CREATE TABLE statement of t
+func writeCreateTable(w *bufio.Writer, t *pgTable) {
+	fmt.Fprintf(w, "CREATE TABLE %s (\n", quoteIdent(t.Name))
+	for i, c := range t.Columns {
+		fmt.Fprintf(w, "    %s %s", quoteIdent(c.Name), c.Type)
+		if c.NotNull {
+			fmt.Fprint(w, " NOT NULL")
+		}
+		if c.Default != "" {
+			fmt.Fprintf(w, " DEFAULT %s", c.Default)
+		}
+		if i < len(t.Columns)-1 || len(t.PrimaryKey) > 0 {
+			fmt.Fprint(w, ",")
+		}
+		fmt.Fprintln(w)
+	}
+	if len(t.PrimaryKey) > 0 {
+		fmt.Fprintf(w, "    PRIMARY KEY (%s)\n", quoteIdents(t.PrimaryKey))
+	}
+	fmt.Fprint(w, ");\n\n")
+}
+
+// writeStatements writes the statements selected by get for all tables
+// under a section comment (nothing if there are none)
+func writeStatements(w *bufio.Writer, section string, schema []*pgTable, get func(t *pgTable) []string) {
+	var statements []string
+	for _, t := range schema {
+		statements = append(statements, get(t)...)
+	}
+	if len(statements) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "-- %s\n", section)
+	for _, stmt := range statements {
+		fmt.Fprintln(w, stmt)
+	}
+	fmt.Fprintln(w)
+}
+
+// writeCopy writes the rows of t as a COPY ... FROM stdin block
+func writeCopy(ctx context.Context, db sqlx.QueryerContext, w *bufio.Writer, t *pgTable) (int64, error) {
+	names := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		names[i] = c.Name
+	}
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT %s FROM %s", quoteIdents(names), quoteIdent(t.Name)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to query table %s: %w", t.Name, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	fmt.Fprintf(w, "COPY %s (%s) FROM stdin;\n", quoteIdent(t.Name), quoteIdents(names))
+
+	values := make([]interface{}, len(names))
+	dest := make([]interface{}, len(names))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	var line []byte
+	var count int64
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return count, fmt.Errorf("failed to scan row of %s: %w", t.Name, err)
+		}
+		line = line[:0]
+		for i, v := range values {
+			if i > 0 {
+				line = append(line, '\t')
+			}
+			line = append(line, formatCopyValue(v, t.columnType[i])...)
+		}
+		line = append(line, '\n')
+		if _, err := w.Write(line); err != nil {
+			return count, fmt.Errorf("failed to write row of %s: %w", t.Name, err)
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("failed to read table %s: %w", t.Name, err)
+	}
+
+	fmt.Fprint(w, "\\.\n\n")
+	return count, nil
+}
+
+// copyEscaper escapes text for the COPY text format
+var copyEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// formatCopyValue formats a value as returned by the SQLite driver for the
+// COPY text format; pgType is the PostgreSQL type of the column. Values are
+// converted to BYTEA whatever their storage class; for other types
+// readPostgresTable guarantees compatible values (see storesAs).
+func formatCopyValue(v interface{}, pgType string) string {
+	switch v := v.(type) {
+	case nil:
+		return `\N`
+	case int64:
+		switch pgType {
+		case "BOOLEAN":
+			return strconv.FormatBool(v != 0)
+		case "BYTEA":
+			return copyBytea([]byte(strconv.FormatInt(v, 10)))
+		}
+		return strconv.FormatInt(v, 10)
+	case float64:
+		if pgType == "BYTEA" {
+			return copyBytea([]byte(strconv.FormatFloat(v, 'g', -1, 64)))
+		}
+		switch {
+		case math.IsNaN(v):
+			return "NaN"
+		case math.IsInf(v, 1):
+			return "Infinity"
+		case math.IsInf(v, -1):
+			return "-Infinity"
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []byte:
+		if pgType == "BYTEA" {
+			return copyBytea(v)
+		}
+		return copyEscaper.Replace(string(v))
+	case string:
+		if pgType == "BYTEA" {
+			return copyBytea([]byte(v))
+		}
+		return copyEscaper.Replace(v)
+	case time.Time:
+		if pgType == "DATE" {
+			return v.UTC().Format("2006-01-02")
+		}
+		return v.UTC().Format("2006-01-02 15:04:05.999999999")
+	default:
+		return copyEscaper.Replace(fmt.Sprint(v))
+	}
+}
+
+// copyBytea formats b as a BYTEA value in hex format; the backslash is
+// doubled for COPY
+func copyBytea(b []byte) string {
+	return `\\x` + hex.EncodeToString(b)
+}
+
+// sqliteColumn is a row of pragma_table_info
+type sqliteColumn struct {
+	Name    string         `db:"name"`
+	Type    string         `db:"type"`
+	NotNull bool           `db:"notnull"`
+	Default sql.NullString `db:"dflt_value"`
+	PK      int            `db:"pk"`
+}
+
+// sqliteIndex is a row of pragma_index_list
+type sqliteIndex struct {
+	Name    string `db:"name"`
+	Unique  bool   `db:"unique"`
+	Origin  string `db:"origin"` // c = CREATE INDEX, u = UNIQUE constraint, pk = primary key
+	Partial bool   `db:"partial"`
+}
+
+// sqliteIndexColumn is a key column of pragma_index_xinfo
+type sqliteIndexColumn struct {
+	Name sql.NullString `db:"name"` // NULL for expressions
+	Desc bool           `db:"desc"`
+}
+
+// sqliteForeignKey is a row of pragma_foreign_key_list
+type sqliteForeignKey struct {
+	ID    int            `db:"id"`
+	Table string         `db:"table"`
+	From  string         `db:"from"`
+	To    sql.NullString `db:"to"` // NULL = primary key of the referenced table
+}
+
+// readPostgresTable reads the schema of table and translates it to PostgreSQL.
+// exported contains all tables of the dump (for foreign keys).
+func readPostgresTable(ctx context.Context, db sqlx.QueryerContext, table string, exported map[string]bool) (*pgTable, error) {
+	columns, err := database.QueryAll[sqliteColumn](ctx, db,
+		`SELECT name, type, "notnull", dflt_value, pk FROM pragma_table_info(?) ORDER BY cid`, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s does not exist", table)
+	}
+
+	t := &pgTable{Name: table}
+	pk := make([]string, len(columns))
+	for _, c := range columns {
+		pgType := postgresType(c.Type)
+		t.Columns = append(t.Columns, pgColumn{
+			Name:    c.Name,
+			Type:    pgType,
+			NotNull: c.NotNull,
+			Default: postgresDefault(c.Default, pgType),
+		})
+		t.columnType = append(t.columnType, pgType)
+		if c.PK > 0 {
+			pk[c.PK-1] = c.Name
+		}
+	}
+	for _, name := range pk {
+		if name != "" {
+			t.PrimaryKey = append(t.PrimaryKey, name)
+		}
+	}
+
+	if err := checkPostgresStorage(ctx, db, t, columns); err != nil {
+		return nil, err
+	}
+	if err := readPostgresIndexes(ctx, db, t); err != nil {
+		return nil, err
+	}
+	if err := readPostgresForeignKeys(ctx, db, t, exported); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// checkPostgresStorage reads the storage classes (typeof) of every column of
+// t and exports columns holding values their PostgreSQL type cannot represent
+// as TEXT, so COPY does not fail on them.
+func checkPostgresStorage(ctx context.Context, db sqlx.QueryerContext, t *pgTable, columns []sqliteColumn) error {
+	exprs := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		exprs[i] = fmt.Sprintf("group_concat(DISTINCT typeof(%s))", quoteIdent(c.Name))
+	}
+	classes := make([]sql.NullString, len(exprs))
+	dest := make([]interface{}, len(exprs))
+	for i := range classes {
+		dest[i] = &classes[i]
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(exprs, ", "), quoteIdent(t.Name))
+	if err := db.QueryRowxContext(ctx, query).Scan(dest...); err != nil {
+		return fmt.Errorf("failed to read storage classes of %s: %w", t.Name, err)
+	}
+
+	for i, c := range t.Columns {
+		var mismatched []string
+		for _, class := range strings.Split(classes[i].String, ",") {
+			if class != "" && !storesAs(c.Type, class) {
+				mismatched = append(mismatched, class)
+			}
+		}
+		if len(mismatched) == 0 {
+			continue
+		}
+		t.Skipped = append(t.Skipped, fmt.Sprintf("-- column %s.%s: declared %s holds %s values, exported as TEXT",
+			quoteIdent(t.Name), quoteIdent(c.Name), columns[i].Type, strings.Join(mismatched, "/")))
+		t.Columns[i].Type = "TEXT"
+		t.Columns[i].Default = postgresDefault(columns[i].Default, "TEXT")
+		t.columnType[i] = "TEXT"
+	}
+	return nil
+}
+
+// storesAs reports whether formatCopyValue writes values of the SQLite
+// storage class (typeof: null, integer, real, text, blob) in a form that
+// COPY accepts for pgType.
+func storesAs(pgType, class string) bool {
+	switch {
+	case class == "null" || pgType == "TEXT" || pgType == "BYTEA":
+		return true
+	case pgType == "BIGINT" || pgType == "BOOLEAN":
+		return class == "integer"
+	case pgType == "DOUBLE PRECISION" || pgType == "NUMERIC":
+		return class == "integer" || class == "real"
+	case pgType == "DATE" || pgType == "TIMESTAMP":
+		return class == "text"
+	default:
+		return false
+	}
+}
+
+// readPostgresIndexes adds the secondary indexes and UNIQUE constraints of t
+func readPostgresIndexes(ctx context.Context, db sqlx.QueryerContext, t *pgTable) error {
+	indexes, err := database.QueryAll[sqliteIndex](ctx, db,
+		`SELECT name, "unique", origin, partial FROM pragma_index_list(?) ORDER BY name`, t.Name)
+	if err != nil {
+		return fmt.Errorf("failed to read indexes of %s: %w", t.Name, err)
+	}
+
+	for _, idx := range indexes {
+		if idx.Origin == "pk" {
+			continue
+		}
+		if idx.Partial {
+			t.Skipped = append(t.Skipped, fmt.Sprintf("-- partial index %s on %s", quoteIdent(idx.Name), quoteIdent(t.Name)))
+			continue
+		}
+
+		keys, err := database.QueryAll[sqliteIndexColumn](ctx, db,
+			`SELECT name, "desc" FROM pragma_index_xinfo(?) WHERE key = 1 ORDER BY seqno`, idx.Name)
+		if err != nil {
+			return fmt.Errorf("failed to read index %s: %w", idx.Name, err)
+		}
+		var cols []string
+		expression := false
+		for _, k := range keys {
+			if !k.Name.Valid {
+				expression = true
+				break
+			}
+			col := quoteIdent(k.Name.String)
+			if k.Desc {
+				col += " DESC"
+			}
+			cols = append(cols, col)
+		}
+		if expression {
+			t.Skipped = append(t.Skipped, fmt.Sprintf("-- expression index %s on %s", quoteIdent(idx.Name), quoteIdent(t.Name)))
+			continue
+		}
+
+		switch {
+		case idx.Origin == "u":
+			t.Statements = append(t.Statements, fmt.Sprintf("ALTER TABLE %s ADD UNIQUE (%s);", quoteIdent(t.Name), strings.Join(cols, ", ")))
+		case idx.Unique:
+			t.Statements = append(t.Statements, fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s);", quoteIdent(idx.Name), quoteIdent(t.Name), strings.Join(cols, ", ")))
+		default:
+			t.Statements = append(t.Statements, fmt.Sprintf("CREATE INDEX %s ON %s (%s);", quoteIdent(idx.Name), quoteIdent(t.Name), strings.Join(cols, ", ")))
+		}
+	}
+	return nil
+}
+
+// readPostgresForeignKeys reads the foreign keys of t that reference exported
+// tables; addForeignKeys turns them into statements once all tables are read
+func readPostgresForeignKeys(ctx context.Context, db sqlx.QueryerContext, t *pgTable, exported map[string]bool) error {
+	keys, err := database.QueryAll[sqliteForeignKey](ctx, db,
+		`SELECT id, "table", "from", "to" FROM pragma_foreign_key_list(?) ORDER BY id, seq`, t.Name)
+	if err != nil {
+		return fmt.Errorf("failed to read foreign keys of %s: %w", t.Name, err)
+	}
+
+	for i := 0; i < len(keys); {
+		// all columns of a (composite) foreign key
+		j := i
+		var from, to []string
+		for ; j < len(keys) && keys[j].ID == keys[i].ID; j++ {
+			from = append(from, keys[j].From)
+			if keys[j].To.Valid {
+				to = append(to, keys[j].To.String)
+			}
+		}
+		ref := keys[i].Table
+		i = j
+
+		if !exported[ref] {
+			t.Skipped = append(t.Skipped, fmt.Sprintf("-- foreign key %s (%s) references %s, which is not exported",
+				quoteIdent(t.Name), quoteIdents(from), quoteIdent(ref)))
+			continue
+		}
+		fk := pgForeignKey{Columns: from, RefTable: ref}
+		if len(to) == len(from) {
+			fk.RefColumns = to
+		}
+		t.foreignKeys = append(t.foreignKeys, fk)
+	}
+	return nil
+}
+
+// addForeignKeys adds the ADD FOREIGN KEY statements of all tables in schema.
+// Foreign keys whose columns have a different PostgreSQL type than the
+// referenced columns (e.g. after a TEXT fallback) are skipped with a comment,
+// PostgreSQL would reject them.
+func addForeignKeys(schema []*pgTable) {
+	tables := make(map[string]*pgTable, len(schema))
+	for _, t := range schema {
+		tables[t.Name] = t
+	}
+	for _, t := range schema {
+		for _, fk := range t.foreignKeys {
+			ref := tables[fk.RefTable]
+			refColumns := fk.RefColumns
+			if len(refColumns) == 0 {
+				refColumns = ref.PrimaryKey
+			}
+			if len(refColumns) == len(fk.Columns) && !sameTypes(t, fk.Columns, ref, refColumns) {
+				t.Skipped = append(t.Skipped, fmt.Sprintf("-- foreign key %s (%s) references %s (%s) with a different column type",
+					quoteIdent(t.Name), quoteIdents(fk.Columns), quoteIdent(ref.Name), quoteIdents(refColumns)))
+				continue
+			}
+			stmt := fmt.Sprintf("ALTER TABLE %s ADD FOREIGN KEY (%s) REFERENCES %s", quoteIdent(t.Name), quoteIdents(fk.Columns), quoteIdent(ref.Name))
+			if len(fk.RefColumns) > 0 {
+				stmt += " (" + quoteIdents(fk.RefColumns) + ")"
+			}
+			t.ForeignKey = append(t.ForeignKey, stmt+";")
+		}
+	}
+}
+
+// sameTypes reports whether the columns of t and the columns of ref have the
+// same PostgreSQL types, pairwise
+func sameTypes(t *pgTable, columns []string, ref *pgTable, refColumns []string) bool {
+	for i := range columns {
+		if t.typeOf(columns[i]) != ref.typeOf(refColumns[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// postgresType maps a declared SQLite column type to a PostgreSQL type
+// following SQLite's type affinity rules.
+func postgresType(declared string) string {
+	t := strings.ToUpper(strings.TrimSpace(declared))
+	switch {
+	case t == "BOOLEAN" || t == "BOOL":
+		return "BOOLEAN"
+	case t == "DATE":
+		return "DATE"
+	case strings.HasPrefix(t, "DATETIME") || strings.HasPrefix(t, "TIMESTAMP"):
+		return "TIMESTAMP"
+	case strings.Contains(t, "INT"):
+		return "BIGINT"
+	case strings.Contains(t, "CHAR") || strings.Contains(t, "CLOB") || strings.Contains(t, "TEXT"):
+		return "TEXT"
+	case strings.Contains(t, "BLOB"):
+		return "BYTEA"
+	case t == "":
+		// no affinity: any value, keep as text
+		return "TEXT"
+	case strings.Contains(t, "REAL") || strings.Contains(t, "FLOA") || strings.Contains(t, "DOUB"):
+		return "DOUBLE PRECISION"
+	default:
+		return "NUMERIC"
+	}
+}
+
+// numericLiteral matches numeric defaults like 0, -1.5 or 1e3
+var numericLiteral = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+// postgresDefault translates a SQLite default expression. Only literals and
+// CURRENT_* are translated; other expressions are dropped.
+func postgresDefault(def sql.NullString, pgType string) string {
+	if !def.Valid {
+		return ""
+	}
+	v := strings.TrimSpace(def.String)
+	for strings.HasPrefix(v, "(") && strings.HasSuffix(v, ")") {
+		v = strings.TrimSpace(v[1 : len(v)-1])
+	}
+	upper := strings.ToUpper(v)
+
+	switch {
+	case upper == "NULL":
+		return ""
+	case pgType == "BOOLEAN" && (v == "0" || upper == "FALSE"):
+		return "FALSE"
+	case pgType == "BOOLEAN" && (v == "1" || upper == "TRUE"):
+		return "TRUE"
+	case upper == "CURRENT_TIMESTAMP" || upper == "CURRENT_DATE" || upper == "CURRENT_TIME":
+		return upper
+	case numericLiteral.MatchString(v):
+		return v
+	case len(v) >= 2 && v[0] == '\'' && v[len(v)-1] == '\'':
+		return v
+	default:
+		return ""
+	}
+}
+
+// quoteIdents quotes and joins identifiers with ", "
+func quoteIdents(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = quoteIdent(name)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,322 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"flag"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+	"github.com/jmoiron/sqlx"
+)
+
+// update regenerates the golden files:
+//
+//	go test ./internal/export -run Golden -update
+var update = flag.Bool("update", false, "update golden files")
+
+// goldenDir contains the golden files of the PostgreSQL dump
+var goldenDir = filepath.Join("..", "..", "testdata", "golden", "postgres")
+
+// compareGolden compares actual with the golden file name (or updates it with -update)
+func compareGolden(t *testing.T, name string, actual []byte) {
+	t.Helper()
+	path := filepath.Join(goldenDir, name)
+	if *update {
+		if err := os.MkdirAll(goldenDir, 0755); err != nil {
+			t.Fatalf("failed to create golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, actual, 0644); err != nil {
+			t.Fatalf("failed to write golden file: %v", err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("PostgreSQL dump differs from %s (run with -update after intentional changes)\n--- actual ---\n%s", path, actual)
+	}
+}
+
+// TestWritePostgresDump_GoldenSchema tests the DDL generated for the SDE schema (migrations)
+func TestWritePostgresDump_GoldenSchema(t *testing.T) {
+	db := database.NewTestDB(t)
+	ctx := context.Background()
+
+	_, err := db.Exec(`
+		INSERT INTO invTypes (typeID, typeName, groupID, mass, volume, published) VALUES
+			(34, 'Tritanium', 18, 0, 0.01, 1),
+			(35, 'Pyerite', 18, 0, 0.01, 1);
+		INSERT INTO dogmaTypeAttributes (typeID, attributeID, valueInt, valueFloat) VALUES
+			(34, 4, NULL, 1.5e-7);`)
+	if err != nil {
+		t.Fatalf("failed to insert rows: %v", err)
+	}
+
+	tables, err := Tables(ctx, db, nil)
+	if err != nil {
+		t.Fatalf("Tables failed: %v", err)
+	}
+	var buf bytes.Buffer
+	counts, err := WritePostgresDump(ctx, db, tables, &buf, PostgresOptions{})
+	if err != nil {
+		t.Fatalf("WritePostgresDump failed: %v", err)
+	}
+	if len(counts) != len(tables) {
+		t.Errorf("expected row counts for %d tables, got %d", len(tables), len(counts))
+	}
+	compareGolden(t, "schema.golden.sql", buf.Bytes())
+}
+
+// newPostgresTestDB creates a database with the column types, keys, indexes
+// and values the PostgreSQL dump has to translate
+func newPostgresTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "postgres.db"))
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	t.Cleanup(func() { _ = database.Close(db) })
+
+	// owners does not exist: the dump must skip foreign keys to tables it
+	// does not contain
+	_, err = db.Exec(`
+		PRAGMA foreign_keys = OFF;
+		CREATE TABLE items (
+			itemID INTEGER PRIMARY KEY,
+			name TEXT NOT NULL DEFAULT 'unnamed',
+			description TEXT,
+			price REAL DEFAULT 0,
+			published BOOLEAN DEFAULT 1,
+			data BLOB,
+			amount NUMERIC,
+			code VARCHAR(10) UNIQUE,
+			created DATETIME DEFAULT CURRENT_TIMESTAMP,
+			misc
+		);
+		CREATE INDEX idx_items_name ON items (name DESC, price);
+		CREATE UNIQUE INDEX idx_items_lower ON items (lower(name));
+		CREATE INDEX idx_items_published ON items (itemID) WHERE published = 1;
+
+		CREATE TABLE itemTags (
+			itemID INTEGER NOT NULL REFERENCES items (itemID),
+			tag TEXT NOT NULL,
+			ownerID INTEGER REFERENCES owners,
+			PRIMARY KEY (itemID, tag)
+		);
+		CREATE UNIQUE INDEX idx_itemTags_tag ON itemTags (tag, itemID);
+
+		INSERT INTO items VALUES
+			(1, 'Tab	and "quotes"', 'Line one
+line two\with backslash' || char(13), 1500000.0, 1, x'00ff10', 12, 'A-1', '2024-01-02 03:04:05', 5),
+			(2, 'Empty', '', 1.5e-7, 0, NULL, 0.5, NULL, NULL, 'text'),
+			(3, 'Nulls', NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL);
+		INSERT INTO itemTags VALUES (1, 'ore', NULL), (1, 'mineral', 7);`)
+	if err != nil {
+		t.Fatalf("failed to create tables: %v", err)
+	}
+	return db
+}
+
+// TestWritePostgresDump_GoldenData tests types, keys, indexes and COPY escaping
+func TestWritePostgresDump_GoldenData(t *testing.T) {
+	db := newPostgresTestDB(t)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	counts, err := WritePostgresDump(ctx, db, []string{"itemTags", "items"}, &buf, PostgresOptions{Schema: "sde", DropTables: true})
+	if err != nil {
+		t.Fatalf("WritePostgresDump failed: %v", err)
+	}
+	expected := []TableRows{{Table: "itemTags", Rows: 2}, {Table: "items", Rows: 3}}
+	if len(counts) != 2 || counts[0] != expected[0] || counts[1] != expected[1] {
+		t.Errorf("expected %v, got %v", expected, counts)
+	}
+	compareGolden(t, "data.golden.sql", buf.Bytes())
+}
+
+// TestWritePostgresFile tests that the file contains the dump
+func TestWritePostgresFile(t *testing.T) {
+	db := newPostgresTestDB(t)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	if _, err := WritePostgresDump(ctx, db, []string{"items"}, &buf, PostgresOptions{}); err != nil {
+		t.Fatalf("WritePostgresDump failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "dump.sql")
+	if _, err := WritePostgresFile(ctx, db, []string{"items"}, path, PostgresOptions{}); err != nil {
+		t.Fatalf("WritePostgresFile failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read dump: %v", err)
+	}
+	if !bytes.Equal(data, buf.Bytes()) {
+		t.Error("file differs from WritePostgresDump output")
+	}
+
+	if _, err := WritePostgresFile(ctx, db, []string{"missing"}, path+".2", PostgresOptions{}); err == nil {
+		t.Error("expected error for unknown table")
+	}
+}
+
+// TestWritePostgresDump_StorageFallback tests that columns holding values of
+// another type than declared are exported as TEXT and that foreign keys to
+// them are skipped
+func TestWritePostgresDump_StorageFallback(t *testing.T) {
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "storage.db"))
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	t.Cleanup(func() { _ = database.Close(db) })
+
+	_, err = db.Exec(`
+		PRAGMA foreign_keys = OFF;
+		CREATE TABLE groups (groupID INTEGER PRIMARY KEY, weight REAL DEFAULT 0, icon BLOB);
+		CREATE TABLE members (memberID INTEGER PRIMARY KEY, groupID INTEGER REFERENCES groups (groupID));
+		INSERT INTO groups VALUES (1, 1.5, x'00ff'), (2, 'heavy', 'icon.png');
+		INSERT INTO members VALUES (1, 1), (2, 'two');
+	`)
+	if err != nil {
+		t.Fatalf("failed to create tables: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := WritePostgresDump(context.Background(), db, []string{"groups", "members"}, &buf, PostgresOptions{}); err != nil {
+		t.Fatalf("WritePostgresDump failed: %v", err)
+	}
+	dump := buf.String()
+
+	for _, want := range []string{
+		`"groupID" BIGINT`,
+		`"weight" TEXT DEFAULT 0`,
+		`"icon" BYTEA`,
+		`"groupID" TEXT`,
+		"2\theavy\t\\\\x69636f6e2e706e67\n",
+		"2\ttwo\n",
+		`-- column "groups"."weight": declared REAL holds text values, exported as TEXT`,
+		`-- column "members"."groupID": declared INTEGER holds text values, exported as TEXT`,
+		`-- foreign key "members" ("groupID") references "groups" ("groupID") with a different column type`,
+	} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("expected %q in dump:\n%s", want, dump)
+		}
+	}
+	if strings.Contains(dump, "ADD FOREIGN KEY") {
+		t.Errorf("expected no foreign key statement:\n%s", dump)
+	}
+}
+
+// TestStoresAs tests which SQLite storage classes fit a PostgreSQL type
+func TestStoresAs(t *testing.T) {
+	tests := []struct {
+		pgType   string
+		class    string
+		expected bool
+	}{
+		{"BIGINT", "integer", true},
+		{"BIGINT", "text", false},
+		{"BIGINT", "real", false},
+		{"BOOLEAN", "text", false},
+		{"DOUBLE PRECISION", "integer", true},
+		{"NUMERIC", "blob", false},
+		{"TIMESTAMP", "text", true},
+		{"TIMESTAMP", "integer", false},
+		{"TEXT", "blob", true},
+		{"BYTEA", "text", true},
+		{"BIGINT", "null", true},
+	}
+	for _, tt := range tests {
+		if got := storesAs(tt.pgType, tt.class); got != tt.expected {
+			t.Errorf("storesAs(%s, %s) = %v, want %v", tt.pgType, tt.class, got, tt.expected)
+		}
+	}
+}
+
+// TestPostgresType tests the mapping of declared SQLite types
+func TestPostgresType(t *testing.T) {
+	tests := map[string]string{
+		"INTEGER":          "BIGINT",
+		"int":              "BIGINT",
+		"UNSIGNED BIG INT": "BIGINT",
+		"TEXT":             "TEXT",
+		"VARCHAR(255)":     "TEXT",
+		"CLOB":             "TEXT",
+		"":                 "TEXT",
+		"BLOB":             "BYTEA",
+		"REAL":             "DOUBLE PRECISION",
+		"DOUBLE":           "DOUBLE PRECISION",
+		"FLOAT":            "DOUBLE PRECISION",
+		"NUMERIC":          "NUMERIC",
+		"DECIMAL(10,5)":    "NUMERIC",
+		"BOOLEAN":          "BOOLEAN",
+		"DATE":             "DATE",
+		"DATETIME":         "TIMESTAMP",
+	}
+	for declared, expected := range tests {
+		if got := postgresType(declared); got != expected {
+			t.Errorf("postgresType(%q) = %q, want %q", declared, got, expected)
+		}
+	}
+}
+
+// TestPostgresDefault tests the translation of default expressions
+func TestPostgresDefault(t *testing.T) {
+	tests := []struct {
+		def      sql.NullString
+		pgType   string
+		expected string
+	}{
+		{sql.NullString{}, "BIGINT", ""},
+		{sql.NullString{String: "NULL", Valid: true}, "TEXT", ""},
+		{sql.NullString{String: "-1.5", Valid: true}, "DOUBLE PRECISION", "-1.5"},
+		{sql.NullString{String: "(0)", Valid: true}, "BIGINT", "0"},
+		{sql.NullString{String: "'it''s'", Valid: true}, "TEXT", "'it''s'"},
+		{sql.NullString{String: "1", Valid: true}, "BOOLEAN", "TRUE"},
+		{sql.NullString{String: "0", Valid: true}, "BOOLEAN", "FALSE"},
+		{sql.NullString{String: "current_timestamp", Valid: true}, "TIMESTAMP", "CURRENT_TIMESTAMP"},
+		{sql.NullString{String: "(strftime('%s','now'))", Valid: true}, "BIGINT", ""},
+	}
+	for _, tt := range tests {
+		if got := postgresDefault(tt.def, tt.pgType); got != tt.expected {
+			t.Errorf("postgresDefault(%q, %s) = %q, want %q", tt.def.String, tt.pgType, got, tt.expected)
+		}
+	}
+}
+
+// TestFormatCopyValue tests COPY text format escaping
+func TestFormatCopyValue(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		pgType   string
+		expected string
+	}{
+		{nil, "TEXT", `\N`},
+		{int64(42), "BIGINT", "42"},
+		{int64(1), "BOOLEAN", "true"},
+		{1500000.0, "DOUBLE PRECISION", "1.5e+06"},
+		{math.Inf(-1), "DOUBLE PRECISION", "-Infinity"},
+		{"a\tb\nc\rd\\e", "TEXT", `a\tb\nc\rd\\e`},
+		{[]byte{0x00, 0xff}, "BYTEA", `\\x00ff`},
+		{"ab", "BYTEA", `\\x6162`},
+		{int64(7), "BYTEA", `\\x37`},
+		{[]byte("raw\t"), "TEXT", `raw\t`},
+		{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "TIMESTAMP", "2024-01-02 03:04:05"},
+		{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), "DATE", "2024-01-02"},
+	}
+	for _, tt := range tests {
+		if got := formatCopyValue(tt.value, tt.pgType); got != tt.expected {
+			t.Errorf("formatCopyValue(%#v, %s) = %q, want %q", tt.value, tt.pgType, got, tt.expected)
+		}
+	}
+}
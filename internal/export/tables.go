@@ -0,0 +1,38 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+	"github.com/jmoiron/sqlx"
+)
+
+// Tables returns the names of the tables in db that are selected by filter
+// (nil = all tables), sorted by name.
+//
+// SQLite internal tables (sqlite_*) and the _import_runs metadata table are
+// skipped.
+func Tables(ctx context.Context, db sqlx.QueryerContext, filter *parser.TableFilter) ([]string, error) {
+	names, err := database.QueryAll[string](ctx, db,
+		`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite\_%' ESCAPE '\' ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	tables := make([]string, 0, len(names))
+	for _, name := range names {
+		if name == database.ImportRunsTable || !filter.Match(name) {
+			continue
+		}
+		tables = append(tables, name)
+	}
+	return tables, nil
+}
+
+// quoteIdent quotes an SQL identifier (table or column name)
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
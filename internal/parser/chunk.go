@@ -0,0 +1,154 @@
+// Package parser provides JSONL parsing interfaces and implementations
+// for EVE SDE data files.
+package parser
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+)
+
+// Chunk describes a byte range of a JSONL file that starts at the beginning
+// of a line and ends directly after a newline (or at end of file).
+//
+// FirstLine carries the 1-based line number of the first line in the chunk,
+// so errors reported while parsing the chunk refer to line numbers in the
+// original file.
+type Chunk struct {
+	Index     int   // Position of the chunk within the file (0-based)
+	Offset    int64 // Byte offset of the chunk start
+	Length    int64 // Length of the chunk in bytes
+	FirstLine int   // Line number of the first line in the chunk (1-based)
+}
+
+// RangeParser is implemented by parsers that can parse a single chunk of a
+// JSONL file. It allows large files to be parsed by several workers in parallel.
+type RangeParser interface {
+	Parser
+
+	// ParseRange parses the lines inside chunk and returns the records in file order.
+	ParseRange(ctx context.Context, path string, chunk Chunk) ([]interface{}, error)
+}
+
+// SplitJSONL splits a JSONL file into chunks of roughly chunkSize bytes.
+//
+// Chunks are cut only at newline boundaries, so each chunk holds complete lines;
+// a chunk may therefore exceed chunkSize by up to one line. The whole file is
+// scanned once to count lines for Chunk.FirstLine (see ScanJSONL).
+//
+// Files smaller than chunkSize (or chunkSize <= 0) yield a single chunk.
+//
+// Example:
+//
+//	chunks, err := parser.SplitJSONL("dogmaTypeAttributes.jsonl", 32<<20)
+//	for _, c := range chunks {
+//	    records, err := p.ParseRange(ctx, path, c)
+//	}
+func SplitJSONL(path string, chunkSize int64) ([]Chunk, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file %s: %w", path, err)
+	}
+	if chunkSize <= 0 || info.Size() <= chunkSize {
+		return []Chunk{{Index: 0, Offset: 0, Length: info.Size(), FirstLine: 1}}, nil
+	}
+
+	_, chunks, err := ScanJSONL(path, chunkSize)
+	return chunks, err
+}
+
+// ScanJSONL counts the lines of a JSONL file and splits it into chunks like
+// SplitJSONL in the same pass, so a pre-scan that counts lines (e.g. for
+// row-based progress) does not need a second read to find chunk boundaries.
+//
+// A last line without a trailing newline is counted. With chunkSize <= 0 or
+// a file not larger than chunkSize, a single chunk covering the file is returned.
+func ScanJSONL(path string, chunkSize int64) (lines int64, chunks []Chunk, err error) {
+	return ScanJSONLHash(path, chunkSize, nil)
+}
+
+// ScanJSONLHash works like ScanJSONL and additionally writes the file content
+// to h (e.g. sha256.New()) in the same pass, so callers that need a checksum
+// of the file do not have to read it again. A nil h is ignored.
+func ScanJSONLHash(path string, chunkSize int64, h hash.Hash) (lines int64, chunks []Chunk, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to open file %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to stat file %s: %w", path, err)
+	}
+	size := info.Size()
+	split := chunkSize > 0 && size > chunkSize
+
+	current := Chunk{Index: 0, Offset: 0, FirstLine: 1}
+	var pos int64
+	var last byte = '\n'
+
+	buf := make([]byte, 256*1024)
+	for {
+		n, readErr := file.Read(buf)
+		block := buf[:n]
+		if n > 0 {
+			last = block[n-1]
+			if h != nil {
+				_, _ = h.Write(block)
+			}
+		}
+		for len(block) > 0 {
+			if !split {
+				lines += int64(bytes.Count(block, []byte{'\n'}))
+				pos += int64(len(block))
+				break
+			}
+			i := bytes.IndexByte(block, '\n')
+			if i < 0 {
+				pos += int64(len(block))
+				break
+			}
+			pos += int64(i + 1)
+			block = block[i+1:]
+			lines++
+
+			// Cut the chunk after this newline once it is large enough
+			if pos-current.Offset >= chunkSize && pos < size {
+				current.Length = pos - current.Offset
+				chunks = append(chunks, current)
+				current = Chunk{Index: len(chunks), Offset: pos, FirstLine: int(lines) + 1}
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return 0, nil, fmt.Errorf("failed to read file %s: %w", path, readErr)
+		}
+	}
+	if last != '\n' {
+		lines++
+	}
+
+	current.Length = size - current.Offset
+	chunks = append(chunks, current)
+
+	return lines, chunks, nil
+}
+
+// ParseRange implements the RangeParser interface for JSONLParser.
+// Line numbers in error messages are relative to the start of the file.
+func (p *JSONLParser[T]) ParseRange(ctx context.Context, path string, chunk Chunk) ([]interface{}, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	return p.parseReaderAt(ctx, io.NewSectionReader(file, chunk.Offset, chunk.Length), chunk.FirstLine)
+}
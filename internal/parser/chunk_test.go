@@ -0,0 +1,192 @@
+package parser_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+)
+
+// writeJSONLLines writes n TestRow lines to a temporary file and returns its path
+func writeJSONLLines(t *testing.T, n int) string {
+	t.Helper()
+	var sb strings.Builder
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&sb, "{\"id\":%d,\"name\":\"row-%d\"}\n", i, i)
+	}
+	path := filepath.Join(t.TempDir(), "large.jsonl")
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	return path
+}
+
+func TestSplitJSONL_SingleChunkForSmallFile(t *testing.T) {
+	path := writeJSONLLines(t, 10)
+
+	chunks, err := parser.SplitJSONL(path, 1<<20)
+	if err != nil {
+		t.Fatalf("SplitJSONL failed: %v", err)
+	}
+	if len(chunks) != 1 {
+		t.Fatalf("expected 1 chunk, got %d", len(chunks))
+	}
+	if chunks[0].Offset != 0 || chunks[0].FirstLine != 1 {
+		t.Errorf("unexpected chunk: %+v", chunks[0])
+	}
+}
+
+func TestSplitJSONL_ChunksCoverFileAtLineBoundaries(t *testing.T) {
+	path := writeJSONLLines(t, 1000)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read test file: %v", err)
+	}
+
+	chunks, err := parser.SplitJSONL(path, 1024)
+	if err != nil {
+		t.Fatalf("SplitJSONL failed: %v", err)
+	}
+	if len(chunks) < 2 {
+		t.Fatalf("expected multiple chunks, got %d", len(chunks))
+	}
+
+	var offset int64
+	line := 1
+	for i, c := range chunks {
+		if c.Index != i {
+			t.Errorf("chunk %d: expected index %d, got %d", i, i, c.Index)
+		}
+		if c.Offset != offset {
+			t.Errorf("chunk %d: expected offset %d, got %d", i, offset, c.Offset)
+		}
+		if c.FirstLine != line {
+			t.Errorf("chunk %d: expected first line %d, got %d", i, line, c.FirstLine)
+		}
+		part := data[c.Offset : c.Offset+c.Length]
+		if part[len(part)-1] != '\n' {
+			t.Errorf("chunk %d does not end at a newline", i)
+		}
+		offset += c.Length
+		line += strings.Count(string(part), "\n")
+	}
+	if offset != int64(len(data)) {
+		t.Errorf("chunks cover %d bytes, file has %d", offset, len(data))
+	}
+}
+
+func TestJSONLParser_ParseRange_ReassemblesInOrder(t *testing.T) {
+	path := writeJSONLLines(t, 500)
+	p := parser.NewJSONLParser[TestRow]("test_table", []string{"id", "name"})
+
+	chunks, err := parser.SplitJSONL(path, 2048)
+	if err != nil {
+		t.Fatalf("SplitJSONL failed: %v", err)
+	}
+
+	var all []interface{}
+	for _, c := range chunks {
+		records, err := p.ParseRange(context.Background(), path, c)
+		if err != nil {
+			t.Fatalf("ParseRange chunk %d failed: %v", c.Index, err)
+		}
+		all = append(all, records...)
+	}
+
+	if len(all) != 500 {
+		t.Fatalf("expected 500 records, got %d", len(all))
+	}
+	for i, r := range all {
+		if r.(TestRow).ID != i+1 {
+			t.Fatalf("record %d: expected id %d, got %d", i, i+1, r.(TestRow).ID)
+		}
+	}
+}
+
+func TestJSONLParser_ParseRange_ReportsFileLineNumbers(t *testing.T) {
+	path := writeJSONLLines(t, 300)
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(string(data), "\n")
+	lines[249] = "{invalid json" // line 250
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	p := parser.NewJSONLParser[TestRow]("test_table", []string{"id", "name"})
+	chunks, err := parser.SplitJSONL(path, 1024)
+	if err != nil {
+		t.Fatalf("SplitJSONL failed: %v", err)
+	}
+
+	var parseErr error
+	for _, c := range chunks {
+		if _, err := p.ParseRange(context.Background(), path, c); err != nil {
+			parseErr = err
+			break
+		}
+	}
+	if parseErr == nil {
+		t.Fatal("expected parse error")
+	}
+	if !strings.Contains(parseErr.Error(), "line 250:") {
+		t.Errorf("expected error for line 250, got: %v", parseErr)
+	}
+}
+
+func TestScanJSONL_CountsLinesAndMatchesSplit(t *testing.T) {
+	path := writeJSONLLines(t, 500)
+
+	lines, chunks, err := parser.ScanJSONL(path, 1024)
+	if err != nil {
+		t.Fatalf("ScanJSONL failed: %v", err)
+	}
+	if lines != 500 {
+		t.Errorf("expected 500 lines, got %d", lines)
+	}
+	split, err := parser.SplitJSONL(path, 1024)
+	if err != nil {
+		t.Fatalf("SplitJSONL failed: %v", err)
+	}
+	if fmt.Sprint(chunks) != fmt.Sprint(split) {
+		t.Errorf("ScanJSONL chunks differ from SplitJSONL:\n%v\n%v", chunks, split)
+	}
+
+	// Without chunking: single chunk, last line without newline counted
+	noNewline := filepath.Join(t.TempDir(), "tail.jsonl")
+	if err := os.WriteFile(noNewline, []byte("{}\n{}\n{}"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	lines, chunks, err = parser.ScanJSONL(noNewline, 0)
+	if err != nil {
+		t.Fatalf("ScanJSONL failed: %v", err)
+	}
+	if lines != 3 || len(chunks) != 1 || chunks[0].Length != 8 {
+		t.Errorf("expected 3 lines in one 8-byte chunk, got %d lines, %+v", lines, chunks)
+	}
+}
+
+func TestScanJSONLHash_HashesFileContent(t *testing.T) {
+	path := writeJSONLLines(t, 500)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read test file: %v", err)
+	}
+
+	h := sha256.New()
+	lines, chunks, err := parser.ScanJSONLHash(path, 1024, h)
+	if err != nil {
+		t.Fatalf("ScanJSONLHash failed: %v", err)
+	}
+	if lines != 500 || len(chunks) < 2 {
+		t.Errorf("expected 500 lines in several chunks, got %d lines, %d chunks", lines, len(chunks))
+	}
+	if want := sha256.Sum256(content); !bytes.Equal(h.Sum(nil), want[:]) {
+		t.Errorf("expected SHA-256 %x, got %x", want, h.Sum(nil))
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	apperrors "github.com/Sternrassler/EVE-SDE-Database-Builder/internal/errors"
 	"github.com/rs/zerolog/log"
@@ -35,6 +36,19 @@ func (m ErrorMode) String() string {
 	}
 }
 
+// ParseErrorMode parses an error mode name as used in configuration files:
+// "skip" or "fail_fast" (case-insensitive).
+func ParseErrorMode(name string) (ErrorMode, error) {
+	switch strings.ToLower(name) {
+	case "skip":
+		return ErrorModeSkip, nil
+	case "fail_fast":
+		return ErrorModeFailFast, nil
+	default:
+		return 0, fmt.Errorf("invalid error mode %q (must be: skip, fail_fast)", name)
+	}
+}
+
 // ParseResult contains the results of parsing with error handling
 type ParseResult[T any] struct {
 	// Records contains successfully parsed records
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apperrors "github.com/Sternrassler/EVE-SDE-Database-Builder/internal/errors"
+)
+
+// ErrorHandlingParser is implemented by parsers that can parse a file with a
+// configurable ErrorMode instead of failing on the first malformed line
+// (e.g. for tables configured with error_mode = "skip").
+type ErrorHandlingParser interface {
+	Parser
+
+	// ParseFileWithErrorHandling parses the complete file with mode and
+	// maxErrors (see ParseWithErrorHandlingContext). It returns the parsed
+	// records and one skippable error per skipped line. The returned error is
+	// set for fatal problems (file not readable, error threshold exceeded,
+	// context cancelled) and, in ErrorModeFailFast, for the first malformed line.
+	ParseFileWithErrorHandling(ctx context.Context, path string, mode ErrorMode, maxErrors int) ([]interface{}, []error, error)
+}
+
+// Compile-time check that JSONLParser implements ErrorHandlingParser
+var _ ErrorHandlingParser = (*JSONLParser[struct{}])(nil)
+
+// ParseFileWithErrorHandling implements the ErrorHandlingParser interface for JSONLParser.
+func (p *JSONLParser[T]) ParseFileWithErrorHandling(ctx context.Context, path string, mode ErrorMode, maxErrors int) ([]interface{}, []error, error) {
+	result := ParseWithErrorHandlingContext[T](ctx, path, mode, maxErrors)
+
+	records := make([]interface{}, len(result.Records))
+	for i, record := range result.Records {
+		records[i] = record
+	}
+
+	var skipped, fatal []error
+	for _, err := range result.Errors {
+		if apperrors.IsSkippable(err) {
+			skipped = append(skipped, err)
+			continue
+		}
+		fatal = append(fatal, err)
+	}
+	if len(fatal) > 0 {
+		return nil, skipped, fmt.Errorf("%s: %w", path, errors.Join(fatal...))
+	}
+	if mode == ErrorModeFailFast && len(skipped) > 0 {
+		return nil, skipped, fmt.Errorf("%s: %w", path, skipped[0])
+	}
+	return records, skipped, nil
+}
@@ -0,0 +1,71 @@
+package parser_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+)
+
+func TestJSONLParser_ParseFileWithErrorHandling(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rows.jsonl")
+	content := `{"id": 1, "name": "a"}
+{broken
+{"id": 2, "name": "b"}
+not json
+{"id": 3, "name": "c"}
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	p := parser.NewJSONLParser[TestRow]("rows", []string{"id", "name"})
+	ctx := context.Background()
+
+	t.Run("skip", func(t *testing.T) {
+		records, skipped, err := p.ParseFileWithErrorHandling(ctx, path, parser.ErrorModeSkip, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(records) != 3 || len(skipped) != 2 {
+			t.Errorf("expected 3 records and 2 skipped lines, got %d and %d", len(records), len(skipped))
+		}
+		if _, ok := records[0].(TestRow); !ok {
+			t.Errorf("expected TestRow records, got %T", records[0])
+		}
+	})
+
+	t.Run("threshold", func(t *testing.T) {
+		records, skipped, err := p.ParseFileWithErrorHandling(ctx, path, parser.ErrorModeSkip, 2)
+		if err == nil {
+			t.Fatal("expected error when max errors is reached")
+		}
+		if records != nil || len(skipped) != 2 {
+			t.Errorf("expected no records and 2 skipped lines, got %d and %d", len(records), len(skipped))
+		}
+	})
+
+	t.Run("fail fast", func(t *testing.T) {
+		if _, _, err := p.ParseFileWithErrorHandling(ctx, path, parser.ErrorModeFailFast, 0); err == nil {
+			t.Fatal("expected error for first malformed line")
+		}
+	})
+}
+
+func TestParseErrorMode(t *testing.T) {
+	tests := map[string]parser.ErrorMode{
+		"skip":      parser.ErrorModeSkip,
+		"SKIP":      parser.ErrorModeSkip,
+		"fail_fast": parser.ErrorModeFailFast,
+	}
+	for name, want := range tests {
+		got, err := parser.ParseErrorMode(name)
+		if err != nil || got != want {
+			t.Errorf("ParseErrorMode(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	if _, err := parser.ParseErrorMode("ignore"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
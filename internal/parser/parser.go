@@ -56,12 +56,19 @@ func (p *JSONLParser[T]) ParseFile(ctx context.Context, path string) ([]interfac
 // parseReader handles the actual line-by-line JSONL parsing from an io.Reader.
 // This method is extracted to facilitate testing with different input sources.
 func (p *JSONLParser[T]) parseReader(ctx context.Context, r io.Reader) ([]interface{}, error) {
+	return p.parseReaderAt(ctx, r, 1)
+}
+
+// parseReaderAt parses JSONL from r, numbering the first line firstLine.
+// Used by ParseRange so that errors in a chunk report the line number in the file.
+func (p *JSONLParser[T]) parseReaderAt(ctx context.Context, r io.Reader, firstLine int) ([]interface{}, error) {
 	scanner := bufio.NewScanner(r)
 	// Set buffer size for potentially large JSON lines (10MB max line size)
 	scanner.Buffer(make([]byte, 1024*1024), 10*1024*1024)
 
 	var results []interface{}
-	lineNum := 0
+	lineNum := firstLine - 1
+	progress := progressFromContext(ctx)
 
 	for scanner.Scan() {
 		lineNum++
@@ -84,6 +91,9 @@ func (p *JSONLParser[T]) parseReader(ctx context.Context, r io.Reader) ([]interf
 		}
 
 		results = append(results, item)
+		if progress != nil && len(results)%ProgressInterval == 0 {
+			progress(len(results))
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -0,0 +1,32 @@
+package parser
+
+import "context"
+
+// ProgressInterval is the number of records between two progress reports.
+const ProgressInterval = 1000
+
+// ProgressFunc receives the number of records parsed so far.
+type ProgressFunc func(records int)
+
+type progressKey struct{}
+
+// WithProgress returns a context that makes JSONLParser report the number of
+// parsed records to fn every ProgressInterval records.
+//
+// fn is called synchronously from the parsing goroutine and should return quickly.
+//
+// Example:
+//
+//	ctx = parser.WithProgress(ctx, func(records int) {
+//	    log.Printf("%d records parsed", records)
+//	})
+//	items, err := p.ParseFile(ctx, "invTypes.jsonl")
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// progressFromContext returns the ProgressFunc registered with WithProgress, or nil.
+func progressFromContext(ctx context.Context) ProgressFunc {
+	fn, _ := ctx.Value(progressKey{}).(ProgressFunc)
+	return fn
+}
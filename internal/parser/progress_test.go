@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWithProgress_ReportsEveryInterval(t *testing.T) {
+	var sb strings.Builder
+	for i := 0; i < 2*ProgressInterval+5; i++ {
+		fmt.Fprintf(&sb, "{\"id\": %d}\n", i)
+	}
+
+	var reports []int
+	ctx := WithProgress(context.Background(), func(records int) {
+		reports = append(reports, records)
+	})
+
+	p := NewJSONLParser[map[string]interface{}]("test", []string{"id"})
+	items, err := p.parseReader(ctx, strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2*ProgressInterval+5 {
+		t.Fatalf("expected %d items, got %d", 2*ProgressInterval+5, len(items))
+	}
+	if len(reports) != 2 || reports[0] != ProgressInterval || reports[1] != 2*ProgressInterval {
+		t.Errorf("unexpected progress reports: %v", reports)
+	}
+}
+
+func TestWithProgress_NoCallback(t *testing.T) {
+	if progressFromContext(context.Background()) != nil {
+		t.Error("expected no progress func without WithProgress")
+	}
+}
@@ -0,0 +1,119 @@
+// Package parser provides helpers for reading the EVE SDE metadata file (_sde.jsonl).
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SDEMetadataFile is the file name of the SDE metadata file within an SDE directory.
+const SDEMetadataFile = "_sde.jsonl"
+
+// ReadSDEMetadata reads the first record of an SDE metadata file (_sde.jsonl).
+//
+// The metadata file contains a single JSON object describing the SDE build.
+// Empty lines are skipped; an empty file results in an error.
+//
+// Example:
+//
+//	meta, err := parser.ReadSDEMetadata(filepath.Join(sdeDir, parser.SDEMetadataFile))
+//	if err == nil && meta.Version != nil {
+//	    fmt.Println("SDE version:", *meta.Version)
+//	}
+func ReadSDEMetadata(path string) (*SDEMetadata, error) {
+	record, err := readSDERecord(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-decode the raw record into the typed struct
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-encode metadata record: %w", err)
+	}
+	var meta SDEMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata record in %s: %w", path, err)
+	}
+	return &meta, nil
+}
+
+// ReadSDEBuildNumber reads the SDE build number from an SDE metadata file (_sde.jsonl).
+//
+// Official CCP exports carry a numeric "buildNumber" field. Older or synthetic
+// exports only provide "version"; if it is numeric (e.g., "20250101") it is used
+// as build number instead.
+//
+// Returns an error if the file cannot be read or contains no usable build number.
+func ReadSDEBuildNumber(path string) (int64, error) {
+	meta, err := readSDERecord(path)
+	if err != nil {
+		return 0, err
+	}
+
+	if raw, ok := meta["buildNumber"]; ok {
+		if n, ok := parseBuildNumber(raw); ok {
+			return n, nil
+		}
+		return 0, fmt.Errorf("invalid buildNumber in %s: %s", path, raw)
+	}
+	if raw, ok := meta["version"]; ok {
+		if n, ok := parseBuildNumber(raw); ok {
+			return n, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no build number found in %s", path)
+}
+
+// readSDERecord reads the first SDE metadata record as raw JSON fields.
+func readSDERecord(path string) (map[string]json.RawMessage, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var record map[string]json.RawMessage
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, fmt.Errorf("line %d: failed to parse JSON: %w", lineNum, err)
+		}
+		return record, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanner error after line %d: %w", lineNum, err)
+	}
+
+	return nil, fmt.Errorf("no metadata record found in %s", path)
+}
+
+// parseBuildNumber accepts a positive JSON number or a numeric JSON string.
+func parseBuildNumber(raw json.RawMessage) (int64, bool) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		raw = json.RawMessage(s)
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSpace(string(raw)), 10, 64)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
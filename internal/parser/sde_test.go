@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestReadSDEMetadata tests reading version and release date from _sde.jsonl
+func TestReadSDEMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SDEMetadataFile)
+	content := "\n{\"_key\":\"sde\",\"version\":\"3064089\",\"releaseDate\":\"2025-10-07T11:14:31Z\"}\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	meta, err := ReadSDEMetadata(path)
+	if err != nil {
+		t.Fatalf("ReadSDEMetadata failed: %v", err)
+	}
+	if meta.Version == nil || *meta.Version != "3064089" {
+		t.Errorf("expected version 3064089, got %v", meta.Version)
+	}
+	if meta.ReleaseDate == nil || *meta.ReleaseDate != "2025-10-07T11:14:31Z" {
+		t.Errorf("expected releaseDate 2025-10-07T11:14:31Z, got %v", meta.ReleaseDate)
+	}
+}
+
+// TestReadSDEMetadata_Errors tests error handling for missing, empty and invalid files
+func TestReadSDEMetadata_Errors(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	emptyFile := filepath.Join(tmpDir, "empty.jsonl")
+	if err := os.WriteFile(emptyFile, []byte("\n\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	invalidFile := filepath.Join(tmpDir, "invalid.jsonl")
+	if err := os.WriteFile(invalidFile, []byte("{invalid"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"missing file", filepath.Join(tmpDir, "missing.jsonl")},
+		{"empty file", emptyFile},
+		{"invalid json", invalidFile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadSDEMetadata(tt.path); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+// TestReadSDEBuildNumber tests build number detection from buildNumber and version fields
+func TestReadSDEBuildNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int64
+		wantErr bool
+	}{
+		{"build number", `{"_key":"sde","buildNumber":3064089,"releaseDate":"2025-10-07T11:14:31Z"}`, 3064089, false},
+		{"build number wins over version", `{"buildNumber":3064089,"version":"20250101"}`, 3064089, false},
+		{"numeric version string", `{"version":"20250101"}`, 20250101, false},
+		{"non-numeric version", `{"version":"tranquility"}`, 0, true},
+		{"invalid build number", `{"buildNumber":"abc"}`, 0, true},
+		{"no build fields", `{"releaseDate":"2025-10-07T11:14:31Z"}`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), SDEMetadataFile)
+			if err := os.WriteFile(path, []byte(tt.content+"\n"), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			got, err := ReadSDEBuildNumber(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadSDEBuildNumber() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ReadSDEBuildNumber() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
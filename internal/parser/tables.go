@@ -0,0 +1,166 @@
+package parser
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// tableGroups maps named table groups to their tables.
+// The groups follow the categories documented on RegisterParsers.
+var tableGroups = map[string][]string{
+	"inventory": {
+		"invTypes", "invGroups", "invCategories", "invMarketGroups", "invMetaGroups",
+	},
+	"dogma": {
+		"dogmaAttributes", "dogmaEffects", "dogmaTypeAttributes", "dogmaTypeEffects",
+		"dogmaAttributeCategories", "dogmaUnits", "typeDogma", "dynamicItemAttributes",
+	},
+	"universe": {
+		"mapRegions", "mapConstellations", "mapSolarSystems", "mapStargates", "mapPlanets",
+		"mapMoons", "mapStars", "mapAsteroidBelts", "mapLandmarks",
+	},
+	"industry": {
+		"industryBlueprints",
+	},
+	"character": {
+		"chrRaces", "chrFactions", "chrAncestries", "chrBloodlines", "chrAttributes",
+		"chrNPCCharacters", "crpNPCCorporations", "crpNPCCorporationDivisions", "staStations",
+	},
+	"cosmetics": {
+		"skins", "skinLicenses", "skinMaterials",
+	},
+}
+
+// TableGroups returns the named table groups (inventory, dogma, universe,
+// industry, character, cosmetics) and their tables.
+// The returned map is a copy and may be modified by the caller.
+func TableGroups() map[string][]string {
+	groups := make(map[string][]string, len(tableGroups))
+	for name, tables := range tableGroups {
+		groups[name] = append([]string(nil), tables...)
+	}
+	return groups
+}
+
+// TableGroupNames returns the sorted names of all table groups.
+func TableGroupNames() []string {
+	names := make([]string, 0, len(tableGroups))
+	for name := range tableGroups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TableFilter selects tables by include and exclude patterns.
+//
+// A pattern is either the name of a table group (see TableGroups) or a glob
+// pattern as understood by path.Match (e.g. "map*", "inv[TG]*"). Matching is
+// case-sensitive for table names and case-insensitive for group names.
+//
+// A table is selected if it matches at least one include pattern (or no
+// include patterns are given) and no exclude pattern.
+//
+// Example:
+//
+//	filter, err := parser.NewTableFilter([]string{"universe", "inv*"}, []string{"mapMoons"})
+//	filter.Match("mapRegions") // true
+//	filter.Match("mapMoons")   // false (excluded)
+//	filter.Match("skins")      // false (not included)
+type TableFilter struct {
+	include []string
+	exclude []string
+}
+
+// NewTableFilter creates a TableFilter from include and exclude patterns.
+// Group names are expanded to their tables; glob patterns are validated.
+//
+// Returns an error if a pattern is not a valid glob pattern.
+func NewTableFilter(include, exclude []string) (*TableFilter, error) {
+	inc, err := expandTablePatterns(include)
+	if err != nil {
+		return nil, fmt.Errorf("invalid include pattern: %w", err)
+	}
+	exc, err := expandTablePatterns(exclude)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exclude pattern: %w", err)
+	}
+	return &TableFilter{include: inc, exclude: exc}, nil
+}
+
+// IsEmpty reports whether the filter selects every table.
+func (f *TableFilter) IsEmpty() bool {
+	return f == nil || (len(f.include) == 0 && len(f.exclude) == 0)
+}
+
+// Match reports whether table is selected by the filter.
+// A nil filter selects every table.
+func (f *TableFilter) Match(table string) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.include) > 0 && !matchAny(f.include, table) {
+		return false
+	}
+	return !matchAny(f.exclude, table)
+}
+
+// Unmatched returns the include and exclude patterns (after group expansion)
+// that match none of the given tables, e.g. to warn about typos.
+func (f *TableFilter) Unmatched(tables []string) []string {
+	if f == nil {
+		return nil
+	}
+	var unmatched []string
+	for _, pattern := range append(append([]string(nil), f.include...), f.exclude...) {
+		found := false
+		for _, table := range tables {
+			if ok, _ := path.Match(pattern, table); ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unmatched = append(unmatched, pattern)
+		}
+	}
+	return unmatched
+}
+
+// ValidateTablePatterns checks that all patterns are group names or valid glob patterns.
+func ValidateTablePatterns(patterns []string) error {
+	_, err := expandTablePatterns(patterns)
+	return err
+}
+
+// expandTablePatterns replaces group names by their tables and validates globs.
+func expandTablePatterns(patterns []string) ([]string, error) {
+	var expanded []string
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if tables, ok := tableGroups[strings.ToLower(pattern)]; ok {
+			expanded = append(expanded, tables...)
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%q: %w", pattern, err)
+		}
+		expanded = append(expanded, pattern)
+	}
+	return expanded, nil
+}
+
+// matchAny reports whether table matches one of the (validated) patterns.
+func matchAny(patterns []string, table string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, table); ok {
+			return true
+		}
+	}
+	return false
+}
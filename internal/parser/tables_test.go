@@ -0,0 +1,95 @@
+package parser_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+)
+
+func TestTableGroups_ReferenceRegisteredTables(t *testing.T) {
+	tables := make(map[string]bool)
+	for _, p := range parser.RegisterParsers() {
+		tables[p.TableName()] = true
+	}
+
+	for group, members := range parser.TableGroups() {
+		if len(members) == 0 {
+			t.Errorf("group %s is empty", group)
+		}
+		for _, table := range members {
+			if !tables[table] {
+				t.Errorf("group %s references unknown table %s", group, table)
+			}
+		}
+	}
+
+	names := parser.TableGroupNames()
+	want := []string{"character", "cosmetics", "dogma", "industry", "inventory", "universe"}
+	if !sort.StringsAreSorted(names) || len(names) != len(want) {
+		t.Errorf("expected groups %v, got %v", want, names)
+	}
+}
+
+func TestTableFilter_Match(t *testing.T) {
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		table   string
+		want    bool
+	}{
+		{"no patterns selects all", nil, nil, "invTypes", true},
+		{"exact include", []string{"invTypes"}, nil, "invTypes", true},
+		{"exact include other table", []string{"invTypes"}, nil, "invGroups", false},
+		{"glob include", []string{"map*"}, nil, "mapMoons", true},
+		{"group include", []string{"universe"}, nil, "mapSolarSystems", true},
+		{"group name case-insensitive", []string{"Dogma"}, nil, "typeDogma", true},
+		{"group does not include other tables", []string{"universe"}, nil, "invTypes", false},
+		{"exclude wins over include", []string{"universe"}, []string{"mapMoons"}, "mapMoons", false},
+		{"exclude only", nil, []string{"cosmetics"}, "skins", false},
+		{"exclude only keeps others", nil, []string{"cosmetics"}, "invTypes", true},
+		{"glob exclude", nil, []string{"dogma*"}, "dogmaUnits", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parser.NewTableFilter(tt.include, tt.exclude)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := filter.Match(tt.table); got != tt.want {
+				t.Errorf("Match(%q) = %v, want %v", tt.table, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableFilter_InvalidPattern(t *testing.T) {
+	if _, err := parser.NewTableFilter([]string{"inv["}, nil); err == nil {
+		t.Error("expected error for invalid include pattern")
+	}
+	if _, err := parser.NewTableFilter(nil, []string{"[a-"}); err == nil {
+		t.Error("expected error for invalid exclude pattern")
+	}
+	if err := parser.ValidateTablePatterns([]string{"universe", "inv*"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestTableFilter_Unmatched(t *testing.T) {
+	filter, err := parser.NewTableFilter([]string{"invTypes", "invTpyes"}, []string{"map*"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	unmatched := filter.Unmatched([]string{"invTypes", "mapMoons"})
+	if len(unmatched) != 1 || unmatched[0] != "invTpyes" {
+		t.Errorf("expected [invTpyes], got %v", unmatched)
+	}
+
+	var nilFilter *parser.TableFilter
+	if !nilFilter.Match("anything") || !nilFilter.IsEmpty() {
+		t.Error("nil filter must select all tables")
+	}
+}
@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apperrors "github.com/Sternrassler/EVE-SDE-Database-Builder/internal/errors"
+)
+
+// Validation summarizes a validation pass over a JSONL file.
+// Unlike ParseFile, malformed lines do not abort the pass; they are recorded
+// in SkippedLines and Errors.
+type Validation struct {
+	// Records contains all successfully parsed records
+	Records []interface{}
+	// TotalLines is the number of lines read (including empty lines)
+	TotalLines int
+	// SkippedLines contains line numbers of malformed lines
+	SkippedLines []int
+	// InvalidRecords is the number of records rejected by Validator.Validate
+	InvalidRecords int
+	// Errors contains one error per malformed line or invalid record
+	Errors []error
+}
+
+// FileValidator is implemented by parsers that can validate a whole file
+// without failing on the first malformed line (e.g. for dry-run reports).
+type FileValidator interface {
+	Parser
+
+	// ValidateFile parses the complete file in skip mode.
+	// The returned error is only set for fatal problems (file not readable,
+	// context cancelled, scanner failure).
+	ValidateFile(ctx context.Context, path string) (Validation, error)
+}
+
+// Compile-time check that JSONLParser implements FileValidator
+var _ FileValidator = (*JSONLParser[struct{}])(nil)
+
+// ValidateFile implements the FileValidator interface for JSONLParser.
+// Records implementing Validator are additionally checked with Validate();
+// records failing validation are excluded from Records and reported in
+// Errors and InvalidRecords.
+func (p *JSONLParser[T]) ValidateFile(ctx context.Context, path string) (Validation, error) {
+	result := ParseWithErrorHandlingContext[T](ctx, path, ErrorModeSkip, 0)
+
+	v := Validation{
+		Records:      make([]interface{}, 0, len(result.Records)),
+		TotalLines:   result.TotalLines,
+		SkippedLines: result.SkippedLines,
+	}
+	for i, record := range result.Records {
+		if validator, ok := any(record).(Validator); ok {
+			if err := validator.Validate(); err != nil {
+				v.InvalidRecords++
+				v.Errors = append(v.Errors, apperrors.NewValidation(fmt.Sprintf("record %d is invalid", i+1), err))
+				continue
+			}
+		}
+		v.Records = append(v.Records, record)
+	}
+
+	var fatal []error
+	for _, err := range result.Errors {
+		if apperrors.IsSkippable(err) {
+			v.Errors = append(v.Errors, err)
+			continue
+		}
+		fatal = append(fatal, err)
+	}
+	if len(fatal) > 0 {
+		return v, fmt.Errorf("%s: %w", path, errors.Join(fatal...))
+	}
+	return v, nil
+}
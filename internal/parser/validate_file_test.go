@@ -0,0 +1,80 @@
+package parser_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+)
+
+func TestJSONLParser_ValidateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rows.jsonl")
+	content := `{"id": 1, "name": "a"}
+
+{broken
+{"id": 2, "name": "b"}
+not json
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	p := parser.NewJSONLParser[TestRow]("rows", []string{"id", "name"})
+	v, err := p.ValidateFile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(v.Records) != 2 {
+		t.Errorf("expected 2 records, got %d", len(v.Records))
+	}
+	if v.TotalLines != 5 {
+		t.Errorf("expected 5 lines, got %d", v.TotalLines)
+	}
+	if len(v.SkippedLines) != 2 || v.SkippedLines[0] != 3 || v.SkippedLines[1] != 5 {
+		t.Errorf("expected skipped lines [3 5], got %v", v.SkippedLines)
+	}
+	if len(v.Errors) != 2 {
+		t.Errorf("expected 2 line errors, got %d", len(v.Errors))
+	}
+}
+
+func TestJSONLParser_ValidateFile_Missing(t *testing.T) {
+	p := parser.NewJSONLParser[TestRow]("rows", []string{"id", "name"})
+	if _, err := p.ValidateFile(context.Background(), filepath.Join(t.TempDir(), "missing.jsonl")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+// validatedRow rejects records without a name
+type validatedRow struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func (r validatedRow) Validate() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+func TestJSONLParser_ValidateFile_RecordValidation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rows.jsonl")
+	if err := os.WriteFile(path, []byte("{\"id\": 1, \"name\": \"a\"}\n{\"id\": 2}\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	p := parser.NewJSONLParser[validatedRow]("rows", []string{"id", "name"})
+	v, err := p.ValidateFile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(v.Records) != 1 || v.InvalidRecords != 1 || len(v.Errors) != 1 {
+		t.Errorf("expected 1 valid and 1 invalid record, got %d valid, %d invalid, %d errors",
+			len(v.Records), v.InvalidRecords, len(v.Errors))
+	}
+}
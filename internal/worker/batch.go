@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrPoolClosed wird für Jobs gemeldet, die nach Shutdown() eingereicht wurden
+// oder beim erzwungenen Shutdown noch in der Warteschlange lagen.
+var ErrPoolClosed = errors.New("worker pool is shut down")
+
+// Batch ist eine Gruppe von Jobs, deren Ergebnisse als Stream geliefert werden.
+//
+// Batches ermöglichen die Nutzung eines langlebigen Pools: Ein gestarteter
+// Pool kann beliebig viele Batches nacheinander oder gleichzeitig verarbeiten,
+// ohne dass Wait() aufgerufen wird. Jeder Batch hat einen eigenen Result-Channel,
+// der Ergebnisse liefert, sobald einzelne Jobs fertig sind, und geschlossen wird,
+// wenn alle Jobs des Batches abgeschlossen sind.
+//
+// Der Result-Channel ist auf die Batch-Größe gepuffert; Worker blockieren daher
+// nie, auch wenn der Aufrufer die Ergebnisse erst später liest.
+//
+// Beispiel:
+//
+//	pool := worker.NewPool(4)
+//	pool.Start(ctx)
+//	defer pool.Shutdown(shutdownCtx)
+//
+//	batch := pool.SubmitBatch(jobs)
+//	for result := range batch.Results() {
+//	    handle(result) // Ergebnisse in Fertigstellungs-Reihenfolge
+//	}
+type Batch struct {
+	results chan Result
+	pending atomic.Int64
+	size    int
+}
+
+// newBatch erstellt einen Batch für size Jobs.
+func newBatch(size int) *Batch {
+	b := &Batch{
+		results: make(chan Result, size),
+		size:    size,
+	}
+	b.pending.Store(int64(size))
+	if size == 0 {
+		close(b.results)
+	}
+	return b
+}
+
+// Results gibt den Channel zurück, über den die Ergebnisse des Batches
+// geliefert werden. Der Channel wird nach dem letzten Ergebnis geschlossen.
+func (b *Batch) Results() <-chan Result {
+	return b.results
+}
+
+// Size gibt die Anzahl der Jobs im Batch zurück.
+func (b *Batch) Size() int {
+	return b.size
+}
+
+// Wait wartet auf alle noch ausstehenden Ergebnisse des Batches.
+//
+// Bereits über Results() gelesene Ergebnisse sind nicht enthalten.
+//
+// Rückgabewerte:
+//   - []Result: Alle verbleibenden Job-Results (inkl. fehlgeschlagener Jobs)
+//   - []error: Nur die Fehler aus fehlgeschlagenen Jobs
+func (b *Batch) Wait() ([]Result, []error) {
+	var results []Result
+	var errs []error
+	for result := range b.results {
+		results = append(results, result)
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	return results, errs
+}
+
+// deliver liefert ein Ergebnis aus und schließt den Channel nach dem letzten Job.
+// Blockiert nie, da der Channel auf die Batch-Größe gepuffert ist.
+func (b *Batch) deliver(result Result) {
+	b.results <- result
+	if b.pending.Add(-1) == 0 {
+		close(b.results)
+	}
+}
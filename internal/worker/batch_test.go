@@ -0,0 +1,268 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// valueJob returns a job that yields v after an optional delay
+func valueJob(id string, v int, delay time.Duration) Job {
+	return Job{
+		ID: id,
+		Fn: func(ctx context.Context) (interface{}, error) {
+			if delay > 0 {
+				select {
+				case <-time.After(delay):
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return v, nil
+		},
+	}
+}
+
+// TestPool_Wait_ManyResults tests that Wait does not deadlock when more
+// results than the result buffer size are submitted before reading
+func TestPool_Wait_ManyResults(t *testing.T) {
+	pool := NewPool(4)
+	pool.Start(context.Background())
+
+	const n = 500
+	for i := 0; i < n; i++ {
+		pool.Submit(valueJob(fmt.Sprintf("job-%d", i), i, 0))
+	}
+
+	done := make(chan []Result)
+	go func() {
+		results, _ := pool.Wait()
+		done <- results
+	}()
+
+	select {
+	case results := <-done:
+		if len(results) != n {
+			t.Errorf("expected %d results, got %d", n, len(results))
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Wait deadlocked")
+	}
+}
+
+// TestPool_SubmitBatch_MultipleBatches tests a long-lived pool processing
+// several batches in sequence
+func TestPool_SubmitBatch_MultipleBatches(t *testing.T) {
+	pool := NewPool(3)
+	pool.Start(context.Background())
+
+	for b := 0; b < 3; b++ {
+		var jobs []Job
+		for i := 0; i < 150; i++ {
+			jobs = append(jobs, valueJob(fmt.Sprintf("b%d-job-%d", b, i), i, 0))
+		}
+
+		batch := pool.SubmitBatch(jobs)
+		if batch.Size() != len(jobs) {
+			t.Errorf("batch %d: expected size %d, got %d", b, len(jobs), batch.Size())
+		}
+
+		sum := 0
+		count := 0
+		for result := range batch.Results() {
+			if result.Err != nil {
+				t.Errorf("batch %d: unexpected error: %v", b, result.Err)
+				continue
+			}
+			sum += result.Data.(int)
+			count++
+		}
+		if count != len(jobs) {
+			t.Errorf("batch %d: expected %d results, got %d", b, len(jobs), count)
+		}
+		if want := 149 * 150 / 2; sum != want {
+			t.Errorf("batch %d: expected sum %d, got %d", b, want, sum)
+		}
+	}
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Errorf("unexpected shutdown error: %v", err)
+	}
+
+	stats := pool.Stats()
+	var total int64
+	for _, w := range stats.Workers {
+		total += w.Jobs
+	}
+	if total != 450 {
+		t.Errorf("expected 450 executed jobs, got %d", total)
+	}
+}
+
+// TestPool_SubmitBatch_Streaming tests that results are delivered while
+// other jobs of the batch are still running
+func TestPool_SubmitBatch_Streaming(t *testing.T) {
+	pool := NewPool(2)
+	pool.Start(context.Background())
+	defer func() { _ = pool.Shutdown(context.Background()) }()
+
+	release := make(chan struct{})
+	batch := pool.SubmitBatch([]Job{
+		valueJob("fast", 1, 0),
+		{
+			ID: "blocked",
+			Fn: func(ctx context.Context) (interface{}, error) {
+				<-release
+				return 2, nil
+			},
+		},
+	})
+
+	select {
+	case result := <-batch.Results():
+		if result.JobID != "fast" {
+			t.Errorf("expected first result from fast job, got %s", result.JobID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no streamed result while blocked job is running")
+	}
+
+	close(release)
+	results, errs := batch.Wait()
+	if len(results) != 1 || len(errs) != 0 {
+		t.Errorf("expected 1 remaining result without errors, got %d results, %d errors", len(results), len(errs))
+	}
+}
+
+// TestPool_SubmitBatch_Empty tests that an empty batch is closed immediately
+func TestPool_SubmitBatch_Empty(t *testing.T) {
+	pool := NewPool(1)
+	pool.Start(context.Background())
+	defer func() { _ = pool.Shutdown(context.Background()) }()
+
+	results, errs := pool.SubmitBatch(nil).Wait()
+	if len(results) != 0 || len(errs) != 0 {
+		t.Errorf("expected no results, got %d results, %d errors", len(results), len(errs))
+	}
+}
+
+// TestPool_Shutdown_Graceful tests that Shutdown finishes queued jobs
+func TestPool_Shutdown_Graceful(t *testing.T) {
+	pool := NewPool(2)
+	pool.Start(context.Background())
+
+	var jobs []Job
+	for i := 0; i < 10; i++ {
+		jobs = append(jobs, valueJob(fmt.Sprintf("job-%d", i), i, 10*time.Millisecond))
+	}
+	batch := pool.SubmitBatch(jobs)
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+
+	results, errs := batch.Wait()
+	if len(results) != 10 || len(errs) != 0 {
+		t.Errorf("expected 10 successful results, got %d results, %d errors", len(results), len(errs))
+	}
+}
+
+// TestPool_Shutdown_Forced tests that an expired shutdown context cancels
+// running jobs and fails queued jobs so every batch channel is closed
+func TestPool_Shutdown_Forced(t *testing.T) {
+	pool := NewPool(1)
+	pool.Start(context.Background())
+
+	var jobs []Job
+	for i := 0; i < 5; i++ {
+		jobs = append(jobs, valueJob(fmt.Sprintf("job-%d", i), i, time.Hour))
+	}
+	batch := pool.SubmitBatch(jobs)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := pool.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+
+	done := make(chan []error)
+	go func() {
+		_, errs := batch.Wait()
+		done <- errs
+	}()
+
+	select {
+	case errs := <-done:
+		if len(errs) != 5 {
+			t.Errorf("expected 5 failed jobs, got %d", len(errs))
+		}
+		closed := 0
+		for _, err := range errs {
+			if errors.Is(err, ErrPoolClosed) {
+				closed++
+			}
+		}
+		if closed == 0 {
+			t.Error("expected queued jobs to fail with ErrPoolClosed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("batch channel not closed after forced shutdown")
+	}
+}
+
+// TestPool_Shutdown_IgnoresCancellation tests that a forced shutdown returns
+// even if a running job ignores the cancelled context
+func TestPool_Shutdown_IgnoresCancellation(t *testing.T) {
+	pool := NewPool(1)
+	pool.Start(context.Background())
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	batch := pool.SubmitBatch([]Job{{
+		ID: "stuck",
+		Fn: func(context.Context) (interface{}, error) {
+			close(started)
+			<-release
+			return nil, nil
+		},
+	}, valueJob("queued", 1, 0)})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- pool.Shutdown(ctx) }()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected DeadlineExceeded, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Shutdown blocked on a job that ignores cancellation")
+	}
+
+	// The abandoned job delivers its result once it returns on its own
+	close(release)
+	_, errs := batch.Wait()
+	if len(errs) != 1 || !errors.Is(errs[0], ErrPoolClosed) {
+		t.Errorf("expected only the queued job to fail with ErrPoolClosed, got %v", errs)
+	}
+}
+
+// TestPool_SubmitBatch_AfterShutdown tests that batches submitted after
+// Shutdown fail with ErrPoolClosed
+func TestPool_SubmitBatch_AfterShutdown(t *testing.T) {
+	pool := NewPool(1)
+	pool.Start(context.Background())
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+
+	_, errs := pool.SubmitBatch([]Job{valueJob("late", 1, 0)}).Wait()
+	if len(errs) != 1 || !errors.Is(errs[0], ErrPoolClosed) {
+		t.Errorf("expected ErrPoolClosed, got %v", errs)
+	}
+}
@@ -32,3 +32,25 @@ func SetupSignalHandler() context.Context {
 
 	return ctx
 }
+
+type workerIDKey struct{}
+
+// withWorkerID hängt die ID des ausführenden Workers an den Context an.
+func withWorkerID(ctx context.Context, id int) context.Context {
+	return context.WithValue(ctx, workerIDKey{}, id)
+}
+
+// WorkerID gibt die ID (0-basiert) des Workers zurück, der den aktuellen Job
+// ausführt. ok ist false, wenn ctx nicht aus einem Pool-Worker stammt.
+//
+// Beispiel:
+//
+//	job := worker.Job{ID: "types.jsonl", Fn: func(ctx context.Context) (interface{}, error) {
+//	    id, _ := worker.WorkerID(ctx)
+//	    log.Printf("worker %d parst types.jsonl", id)
+//	    return parseFile(ctx, "types.jsonl")
+//	}}
+func WorkerID(ctx context.Context) (id int, ok bool) {
+	id, ok = ctx.Value(workerIDKey{}).(int)
+	return id, ok
+}
@@ -2,6 +2,8 @@ package worker
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"sync/atomic"
 	"syscall"
@@ -223,3 +225,32 @@ func TestSetupSignalHandler_InFlightJobs(t *testing.T) {
 	// At least some jobs should have been notified of cancellation
 	t.Log("In-flight jobs handling cancellation verified")
 }
+
+// TestWorkerID tests that jobs see the ID of the executing worker
+func TestWorkerID(t *testing.T) {
+	if _, ok := WorkerID(context.Background()); ok {
+		t.Error("expected no worker ID outside of a pool")
+	}
+
+	pool := NewPool(3)
+	pool.Start(context.Background())
+	for i := 0; i < 10; i++ {
+		pool.Submit(Job{ID: fmt.Sprintf("job-%d", i), Fn: func(ctx context.Context) (interface{}, error) {
+			id, ok := WorkerID(ctx)
+			if !ok {
+				return nil, errors.New("missing worker ID")
+			}
+			return id, nil
+		}})
+	}
+
+	results, errs := pool.Wait()
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	for _, r := range results {
+		if id := r.Data.(int); id < 0 || id >= 3 {
+			t.Errorf("job %s ran on invalid worker %d", r.JobID, id)
+		}
+	}
+}
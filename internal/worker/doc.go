@@ -65,19 +65,80 @@
 //   - Keine Hard Aborts (kein Thread.Abort wie in VB.NET)
 //   - Jobs können selbst auf Context-Cancellation reagieren
 //
-// # Channel-basierte Kommunikation
+// # Prioritäts-Warteschlange
 //
-// Der Pool verwendet buffered Channels für Backpressure:
-//   - jobs Channel: Verteilt Tasks an Workers
-//   - results Channel: Sammelt Ergebnisse von Workers
-//   - Buffering verhindert Blockierung bei Submit/Collect
+// Submit legt Jobs in eine zentrale Prioritäts-Warteschlange (Job.Priority,
+// höher = früher, FIFO bei Gleichstand). Ein Dispatcher übergibt den jeweils
+// wichtigsten Job über den ungepufferten jobs Channel an den nächsten freien
+// Worker; der results Channel sammelt die Ergebnisse.
+//
+//	pool.Submit(worker.Job{ID: "typeDogma.jsonl", Priority: size, Fn: fn})
+//
+// # Timeout, Panic-Recovery und Retry
+//
+// Jobs können eine maximale Laufzeit pro Versuch und eine Retry-Policy tragen:
+//
+//	pool.Submit(worker.Job{
+//	    ID:      "typeDogma.jsonl",
+//	    Timeout: 10 * time.Minute,
+//	    Retry:   retry.DefaultPolicy(),
+//	    Fn:      fn,
+//	})
+//
+// Ein Panic in Fn beendet nicht den Prozess, sondern wird als Fatal AppError
+// (Kontext "job_id", Cause *PanicError mit Stack Trace) im Result gemeldet.
+// Ein abgelaufenes Timeout ergibt einen Retryable AppError; hängende Jobs
+// blockieren Wait() daher nicht. Retry wiederholt nur Retryable-Fehler.
+//
+// # Import-Events
+//
+// Der Orchestrator meldet Lifecycle-Events an registrierte ImportObserver
+// (OnImportStart, OnPhaseChange, OnFileParsed, OnFileInserted, OnFileFailed,
+// OnImportDone):
+//
+//	orch := worker.NewOrchestrator(db, pool, parsers, worker.WithObserver(obs))
+//
+// # Zeilenbasierter Fortschritt
+//
+// Vor dem Parsen zählt ImportAll die Zeilen aller Dateien (ScanTasks, reines
+// Newline-Zählen) und setzt TotalRows/TotalBytes im ProgressTracker. Im selben
+// Lesedurchgang werden die Chunk-Grenzen großer Dateien bestimmt, die danach
+// ohne erneutes Lesen parallel geparst werden, sowie mit WithChecksums die
+// SHA-256-Checksummen der Dateien (FileScan.SHA256). Die ETA wird dadurch aus
+// Zeilen statt aus Dateien geschätzt; Observer erhalten die Werte per
+// OnScanComplete. Geparste Zeilen zählt der ProgressTracker getrennt von den
+// eingefügten (Progress.ParsedRows), damit der Fortschritt schon in Phase 1
+// sichtbar ist.
+//
+// # Langlebiger Pool mit Streaming-Results
+//
+// Für Dienste, die wiederholt importieren, bleibt ein gestarteter Pool aktiv,
+// solange Jobs per SubmitBatch() eingereicht werden. Jeder Batch liefert seine
+// Results als Stream, während weitere Jobs noch laufen:
+//
+//	pool.Start(ctx)
+//	batch := pool.SubmitBatch(jobs)
+//	for result := range batch.Results() {
+//	    handle(result)
+//	}
+//	err := pool.Shutdown(shutdownCtx) // wartet auf offene Jobs, kehrt bei Ablauf sofort zurück
+//
+// Ein Orchestrator mit bereits gestartetem Pool verwendet automatisch Batches
+// und kann ImportAll() daher mehrfach aufrufen.
+//
+// # Auslastung
+//
+// Stats() liefert Job-Anzahl, Laufzeit und Auslastung je Worker:
+//
+//	stats := pool.Stats()
+//	log.Printf("Durchschnittliche Auslastung: %.0f%%", stats.Utilization*100)
 //
 // # Best Practices
 //
 //   - Verwenden Sie runtime.NumCPU() für optimale Worker-Anzahl
 //   - Setzen Sie angemessene Context-Timeouts für lange Jobs
 //   - Sammeln Sie Results kontinuierlich (non-blocking)
-//   - Nutzen Sie Close() + Wait() für sauberes Shutdown
+//   - Nutzen Sie Wait() (einmalig) bzw. Shutdown() (langlebig) für sauberes Beenden
 //
 // Siehe auch:
 //   - ADR-006: Concurrency & Worker Pool Pattern
@@ -0,0 +1,207 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+)
+
+// FileReport beschreibt das Parse-Ergebnis einer Datei ohne Datenbankzugriff.
+type FileReport struct {
+	File           string        // Quelldatei
+	Table          string        // Geplante Ziel-Tabelle
+	Columns        []string      // Spalten der Ziel-Tabelle
+	Rows           int           // Anzahl gültiger Zeilen
+	Lines          int           // Gelesene Zeilen (nur Dry-Run, inkl. Leerzeilen)
+	SkippedLines   []int         // Zeilennummern fehlerhafter Zeilen (nur Dry-Run)
+	InvalidRecords int           // Von Validate() abgelehnte Records (nur Dry-Run)
+	Bytes          int64         // Dateigröße
+	Duration       time.Duration // Parse-Dauer der Datei
+	Err            error         // Fataler Fehler (Datei nicht lesbar, Spalten passen nicht, ...)
+}
+
+// ParseReport ist das Ergebnis von DryRun() bzw. ParseAll().
+type ParseReport struct {
+	Files     []FileReport   // Ein Eintrag pro zugeordneter Datei, sortiert nach Pfad
+	Skipped   []SkippedTable // Durch den Tabellenfilter ausgeschlossene Dateien
+	Unmatched []string       // JSONL-Dateien ohne passenden Parser
+	Fallbacks []FileMatch    // Über eine Fallback-Regel zugeordnete Dateien
+	Duration  time.Duration  // Gesamtdauer
+}
+
+// SkippedTables gibt die sortierten, eindeutigen Namen der übersprungenen Tabellen zurück.
+func (r *ParseReport) SkippedTables() []string {
+	return skippedTableNames(r.Skipped)
+}
+
+// TotalRows gibt die Summe der gültigen Zeilen aller Dateien zurück.
+func (r *ParseReport) TotalRows() int64 {
+	var total int64
+	for _, f := range r.Files {
+		total += int64(f.Rows)
+	}
+	return total
+}
+
+// TotalBytes gibt die Summe der Dateigrößen zurück.
+func (r *ParseReport) TotalBytes() int64 {
+	var total int64
+	for _, f := range r.Files {
+		total += f.Bytes
+	}
+	return total
+}
+
+// FailedFiles gibt die Anzahl der Dateien mit fatalem Fehler zurück.
+func (r *ParseReport) FailedFiles() int {
+	failed := 0
+	for _, f := range r.Files {
+		if f.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// DryRun führt Discovery, Parser-Zuordnung, vollständiges Parsen und
+// Validierung aus, ohne die Datenbank zu berühren.
+//
+// Fehlerhafte Zeilen brechen das Parsen einer Datei nicht ab, sondern werden
+// als SkippedLines gemeldet (sofern der Parser parser.FileValidator
+// implementiert). Zusätzlich wird geprüft, ob sich die Records in Zeilen mit
+// der erwarteten Spaltenzahl umwandeln lassen.
+//
+// Die Dateien werden parallel im Worker Pool verarbeitet.
+//
+// Beispiel:
+//
+//	orch := worker.NewOrchestrator(nil, worker.NewPool(4), parser.RegisterParsers())
+//	report, err := orch.DryRun(ctx, sdeDir)
+//	for _, f := range report.Files {
+//	    fmt.Printf("%s → %s: %d rows, %d skipped\n", f.File, f.Table, f.Rows, len(f.SkippedLines))
+//	}
+func (o *Orchestrator) DryRun(ctx context.Context, sdeDir string) (*ParseReport, error) {
+	start := time.Now()
+
+	plan, err := o.Discover(sdeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]Job, len(plan.Tasks))
+	for i, task := range plan.Tasks {
+		t := task
+		jobs[i] = Job{
+			ID:      t.File,
+			Timeout: o.jobTimeout,
+			Fn: func(ctx context.Context) (interface{}, error) {
+				return validateTask(ctx, t), nil
+			},
+		}
+	}
+
+	report := &ParseReport{Skipped: plan.Skipped, Unmatched: plan.Unmatched, Fallbacks: plan.Fallbacks}
+	for _, result := range o.runJobs(ctx, jobs) {
+		fr, ok := result.Data.(FileReport)
+		if !ok {
+			fr = FileReport{File: result.JobID, Err: result.Err}
+		}
+		report.Files = append(report.Files, fr)
+	}
+	fillTaskMetadata(report, plan.Tasks)
+	report.Duration = time.Since(start)
+
+	return report, ctx.Err()
+}
+
+// ParseAll führt nur Phase 1 des Imports aus (inkl. Chunking und
+// Priorisierung) und verwirft die Records, z.B. um den Parse-Durchsatz
+// isoliert zu messen. Die Datenbank wird nicht verwendet.
+func (o *Orchestrator) ParseAll(ctx context.Context, sdeDir string) (*ParseReport, error) {
+	start := time.Now()
+
+	plan, err := o.Discover(sdeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	durations := make(map[string]time.Duration, len(plan.Tasks))
+	onParsed := func(file string, rows int, duration time.Duration) {
+		mu.Lock()
+		durations[file] = duration
+		mu.Unlock()
+		o.observers.OnFileParsed(file, rows, duration)
+	}
+
+	report := &ParseReport{Skipped: plan.Skipped, Unmatched: plan.Unmatched, Fallbacks: plan.Fallbacks}
+	for _, result := range o.parsePhase(ctx, plan.Tasks, nil, onParsed) {
+		fr := FileReport{File: result.JobID, Err: result.Err}
+		if data, ok := result.Data.(ParseResultData); ok {
+			fr.File = data.File
+			fr.Rows = len(data.Records)
+		}
+		fr.Duration = durations[fr.File]
+		report.Files = append(report.Files, fr)
+	}
+	fillTaskMetadata(report, plan.Tasks)
+	report.Duration = time.Since(start)
+
+	return report, ctx.Err()
+}
+
+// validateTask parst und validiert die Datei eines Tasks vollständig.
+func validateTask(ctx context.Context, task ParseTask) FileReport {
+	start := time.Now()
+	fr := FileReport{File: task.File}
+
+	var records []interface{}
+	if v, ok := task.Parser.(parser.FileValidator); ok {
+		validation, err := v.ValidateFile(ctx, task.File)
+		records = validation.Records
+		fr.Lines = validation.TotalLines
+		fr.SkippedLines = validation.SkippedLines
+		fr.InvalidRecords = validation.InvalidRecords
+		fr.Err = err
+	} else {
+		records, fr.Err = task.Parser.ParseFile(ctx, task.File)
+	}
+
+	if fr.Err == nil {
+		if _, err := recordsToRows(records, len(task.Parser.Columns())); err != nil {
+			fr.Err = fmt.Errorf("records do not match columns of table %s: %w", task.Parser.TableName(), err)
+		}
+	}
+	if fr.Err == nil {
+		fr.Rows = len(records)
+	}
+	fr.Duration = time.Since(start)
+	return fr
+}
+
+// fillTaskMetadata ergänzt Tabelle, Spalten und Dateigröße aus den Tasks und
+// sortiert die Einträge nach Dateipfad.
+func fillTaskMetadata(report *ParseReport, tasks []ParseTask) {
+	byFile := make(map[string]ParseTask, len(tasks))
+	for _, task := range tasks {
+		byFile[task.File] = task
+	}
+	for i := range report.Files {
+		f := &report.Files[i]
+		if task, ok := byFile[f.File]; ok {
+			f.Table = task.Parser.TableName()
+			f.Columns = task.Parser.Columns()
+		}
+		if info, err := os.Stat(f.File); err == nil {
+			f.Bytes = info.Size()
+		}
+	}
+	sort.Slice(report.Files, func(i, j int) bool {
+		return report.Files[i].File < report.Files[j].File
+	})
+}
@@ -0,0 +1,222 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+)
+
+// writeDryRunTestDir creates a SDE directory with a valid, a partially
+// malformed and an unmatched file
+func writeDryRunTestDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeChunkTestFile(t, dir, "rows.jsonl", 50)
+
+	partial := "{\"id\": 1, \"name\": \"a\"}\n{broken\n\n{\"id\": 2, \"name\": \"b\"}\n"
+	if err := os.WriteFile(filepath.Join(dir, "partial.jsonl"), []byte(partial), 0o644); err != nil {
+		t.Fatalf("failed to write partial file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "unknown.jsonl"), []byte("{}\n"), 0o644); err != nil {
+		t.Fatalf("failed to write unknown file: %v", err)
+	}
+	return dir
+}
+
+func dryRunTestParsers() map[string]parser.Parser {
+	return map[string]parser.Parser{
+		"rows":    parser.NewJSONLParser[chunkTestRow]("rows", []string{"id", "name"}),
+		"partial": parser.NewJSONLParser[chunkTestRow]("partial_rows", []string{"id", "name"}),
+	}
+}
+
+// TestOrchestrator_Discover tests that unmatched files are reported
+func TestOrchestrator_Discover(t *testing.T) {
+	dir := writeDryRunTestDir(t)
+	orch := NewOrchestrator(nil, NewPool(1), dryRunTestParsers())
+
+	plan, err := orch.Discover(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan.Tasks) != 2 {
+		t.Errorf("expected 2 tasks, got %d", len(plan.Tasks))
+	}
+	if len(plan.Unmatched) != 1 || filepath.Base(plan.Unmatched[0]) != "unknown.jsonl" {
+		t.Errorf("expected unknown.jsonl to be unmatched, got %v", plan.Unmatched)
+	}
+}
+
+// TestOrchestrator_Discover_TableFilter tests that excluded tables are skipped
+func TestOrchestrator_Discover_TableFilter(t *testing.T) {
+	dir := writeDryRunTestDir(t)
+	filter, err := parser.NewTableFilter(nil, []string{"partial_*"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	orch := NewOrchestrator(nil, NewPool(1), dryRunTestParsers(), WithTableFilter(filter))
+
+	plan, err := orch.Discover(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan.Tasks) != 1 || plan.Tasks[0].Parser.TableName() != "rows" {
+		t.Errorf("expected only the rows task, got %v", plan.Tasks)
+	}
+	if len(plan.Skipped) != 1 || filepath.Base(plan.Skipped[0].File) != "partial.jsonl" {
+		t.Errorf("expected partial.jsonl to be skipped, got %v", plan.Skipped)
+	}
+	if tables := plan.SkippedTables(); len(tables) != 1 || tables[0] != "partial_rows" {
+		t.Errorf("expected skipped table partial_rows, got %v", tables)
+	}
+
+	report, err := orch.DryRun(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Files) != 1 || len(report.SkippedTables()) != 1 {
+		t.Errorf("expected 1 file and 1 skipped table, got %d and %v", len(report.Files), report.SkippedTables())
+	}
+}
+
+// TestOrchestrator_Discover_MatchRules tests that the matching rule is
+// recorded and fallback matches are reported
+func TestOrchestrator_Discover_MatchRules(t *testing.T) {
+	dir := writeDryRunTestDir(t)
+	writeChunkTestFile(t, dir, "rows_2.jsonl", 5)
+	writeChunkTestFile(t, dir, "rows_backup.jsonl", 5)
+
+	orch := NewOrchestrator(nil, NewPool(1), dryRunTestParsers())
+	plan, err := orch.Discover(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rules := make(map[string]MatchRule)
+	for _, task := range plan.Tasks {
+		rules[filepath.Base(task.File)] = task.Rule
+	}
+	if rules["rows.jsonl"] != MatchBaseName {
+		t.Errorf("expected rows.jsonl to match by base name, got %q", rules["rows.jsonl"])
+	}
+	if rules["rows_backup.jsonl"] != MatchSuffixStripped {
+		t.Errorf("expected rows_backup.jsonl to match by suffix fallback, got %q", rules["rows_backup.jsonl"])
+	}
+
+	if len(plan.Fallbacks) != 2 {
+		t.Fatalf("expected 2 fallback matches, got %v", plan.Fallbacks)
+	}
+	for _, m := range plan.Fallbacks {
+		if m.ParserKey != "rows" || m.Table != "rows" || m.Rule != MatchSuffixStripped {
+			t.Errorf("unexpected fallback match: %+v", m)
+		}
+	}
+	if !plan.HasUnknownFiles() {
+		t.Error("expected plan to report unknown files")
+	}
+
+	full := filepath.Join(dir, "partial.jsonl")
+	orch = NewOrchestrator(nil, NewPool(1), map[string]parser.Parser{
+		full: parser.NewJSONLParser[chunkTestRow]("partial_rows", []string{"id", "name"}),
+	})
+	plan, err = orch.Discover(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan.Tasks) != 1 || plan.Tasks[0].Rule != MatchFullPath {
+		t.Errorf("expected a single full path match, got %v", plan.Tasks)
+	}
+}
+
+// TestOrchestrator_DryRun tests the dry-run report without a database
+func TestOrchestrator_DryRun(t *testing.T) {
+	dir := writeDryRunTestDir(t)
+	orch := NewOrchestrator(nil, NewPool(2), dryRunTestParsers())
+
+	report, err := orch.DryRun(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(report.Files) != 2 {
+		t.Fatalf("expected 2 file reports, got %d", len(report.Files))
+	}
+	if len(report.Unmatched) != 1 {
+		t.Errorf("expected 1 unmatched file, got %v", report.Unmatched)
+	}
+
+	partial := report.Files[0]
+	if filepath.Base(partial.File) != "partial.jsonl" {
+		t.Fatalf("expected files sorted by path, got %s first", partial.File)
+	}
+	if partial.Table != "partial_rows" || len(partial.Columns) != 2 {
+		t.Errorf("unexpected table metadata: %s %v", partial.Table, partial.Columns)
+	}
+	if partial.Rows != 2 || partial.Lines != 4 {
+		t.Errorf("expected 2 rows in 4 lines, got %d rows in %d lines", partial.Rows, partial.Lines)
+	}
+	if len(partial.SkippedLines) != 1 || partial.SkippedLines[0] != 2 {
+		t.Errorf("expected skipped line [2], got %v", partial.SkippedLines)
+	}
+	if partial.Err != nil {
+		t.Errorf("skipped lines must not fail the file: %v", partial.Err)
+	}
+
+	rows := report.Files[1]
+	if rows.Rows != 50 || rows.Bytes == 0 || rows.Err != nil {
+		t.Errorf("unexpected report for rows.jsonl: %+v", rows)
+	}
+	if report.TotalRows() != 52 || report.FailedFiles() != 0 {
+		t.Errorf("expected 52 rows and no failures, got %d rows, %d failures", report.TotalRows(), report.FailedFiles())
+	}
+}
+
+// TestOrchestrator_DryRun_ColumnMismatch tests that records not matching the
+// planned columns fail the file
+func TestOrchestrator_DryRun_ColumnMismatch(t *testing.T) {
+	dir := t.TempDir()
+	writeChunkTestFile(t, dir, "rows.jsonl", 3)
+
+	parsers := map[string]parser.Parser{
+		"rows": parser.NewJSONLParser[chunkTestRow]("rows", []string{"id"}),
+	}
+	report, err := NewOrchestrator(nil, NewPool(1), parsers).DryRun(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.FailedFiles() != 1 || report.Files[0].Rows != 0 {
+		t.Errorf("expected column mismatch to fail the file, got %+v", report.Files[0])
+	}
+}
+
+// TestOrchestrator_ParseAll tests parse-only mode with chunked files
+func TestOrchestrator_ParseAll(t *testing.T) {
+	dir := t.TempDir()
+	writeChunkTestFile(t, dir, "rows.jsonl", 1000)
+	if err := os.WriteFile(filepath.Join(dir, "partial.jsonl"), []byte("{broken\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	orch := NewOrchestrator(nil, NewPool(4), dryRunTestParsers(), WithChunkSize(2048))
+	report, err := orch.ParseAll(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(report.Files) != 2 {
+		t.Fatalf("expected 2 file reports, got %d", len(report.Files))
+	}
+	if report.Files[0].Err == nil {
+		t.Error("expected parse error for partial.jsonl")
+	}
+	rows := report.Files[1]
+	if rows.Rows != 1000 || rows.Table != "rows" || rows.Duration <= 0 {
+		t.Errorf("unexpected report for rows.jsonl: rows=%d table=%s duration=%v", rows.Rows, rows.Table, rows.Duration)
+	}
+	if report.TotalBytes() == 0 {
+		t.Error("expected total bytes to be set")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/worker"
 )
@@ -184,6 +185,33 @@ func Example_customDataProcessingJob() {
 	// Output: Transformed 4 items
 }
 
+// wordCount is a custom JobResult defined outside package worker
+type wordCount struct {
+	Words int
+}
+
+// wordCountJob is a custom JobExecutor defined outside package worker
+type wordCountJob struct {
+	Text string
+}
+
+func (j *wordCountJob) Execute(ctx context.Context) (worker.JobResult, error) {
+	return wordCount{Words: len(strings.Fields(j.Text))}, ctx.Err()
+}
+
+// Example_customJobExecutor demonstrates a typed job with its own result type
+func Example_customJobExecutor() {
+	ctx := context.Background()
+	pool := worker.NewPool(2)
+	pool.Start(ctx)
+
+	pool.Submit(worker.NewJob("count", &wordCountJob{Text: "custom jobs plug in"}))
+
+	results, _ := pool.Wait()
+	fmt.Printf("Counted %d words\n", results[0].Data.(wordCount).Words)
+	// Output: Counted 4 words
+}
+
 // Example_customFileValidationJob demonstrates a custom validation job
 func Example_customFileValidationJob() {
 	// Custom validator job
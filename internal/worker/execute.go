@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	apperrors "github.com/Sternrassler/EVE-SDE-Database-Builder/internal/errors"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/retry"
+)
+
+// PanicError beschreibt einen Panic, der während einer Job-Ausführung
+// abgefangen wurde.
+//
+// PanicError ist die Cause eines Fatal AppError und kann mit errors.As
+// aus dem Job-Ergebnis extrahiert werden, um den Stack Trace auszuwerten.
+//
+// Beispiel:
+//
+//	var panicErr *worker.PanicError
+//	if errors.As(result.Err, &panicErr) {
+//	    log.Printf("job %s panicked: %v\n%s", panicErr.JobID, panicErr.Value, panicErr.Stack)
+//	}
+type PanicError struct {
+	JobID string      // ID des Jobs, der den Panic ausgelöst hat
+	Value interface{} // An panic() übergebener Wert
+	Stack []byte      // Stack Trace zum Zeitpunkt des Panics
+}
+
+// Error implementiert das error Interface (ohne Stack Trace).
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// executeJob führt einen Job mit Timeout, Panic-Recovery und optionalem Retry aus.
+//
+// Ablauf:
+//   - Jeder Versuch läuft mit Job.Timeout (falls gesetzt) in einem eigenen Context
+//   - Panics werden in einen Fatal AppError (Cause: *PanicError) umgewandelt
+//   - Timeouts werden als Retryable AppError gemeldet und mit Job.Retry wiederholt
+//   - Fatal-, Validation- und Skippable-Fehler werden nicht wiederholt
+func executeJob(ctx context.Context, job Job) (interface{}, error) {
+	attempt := func() (interface{}, error) {
+		return runAttempt(ctx, job)
+	}
+
+	if job.Retry == nil {
+		return attempt()
+	}
+	return retry.DoWithResult(ctx, job.Retry, attempt)
+}
+
+// runAttempt führt einen einzelnen Versuch eines Jobs aus.
+//
+// Bei gesetztem Timeout läuft Job.Fn in einer eigenen Goroutine. Reagiert die
+// Funktion nicht auf die Context-Cancellation, wird sie nach Ablauf des
+// Timeouts aufgegeben, damit der Worker (und damit Pool.Wait) nicht hängt.
+// Die aufgegebene Goroutine läuft im Hintergrund zu Ende.
+func runAttempt(ctx context.Context, job Job) (interface{}, error) {
+	if job.Timeout <= 0 {
+		return callRecovered(ctx, job)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, job.Timeout)
+	defer cancel()
+
+	type outcome struct {
+		data interface{}
+		err  error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		data, err := callRecovered(attemptCtx, job)
+		done <- outcome{data: data, err: err}
+	}()
+
+	select {
+	case o := <-done:
+		// Job hat auf den Timeout reagiert und ctx.Err() zurückgegeben
+		if o.err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+			return nil, timeoutError(job)
+		}
+		return o.data, o.err
+	case <-attemptCtx.Done():
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, timeoutError(job)
+	}
+}
+
+// callRecovered ruft Job.Fn auf und wandelt Panics in Fatal AppErrors um.
+func callRecovered(ctx context.Context, job Job) (data interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicErr := &PanicError{JobID: job.ID, Value: r, Stack: debug.Stack()}
+			data = nil
+			err = apperrors.NewFatal(fmt.Sprintf("job %s panicked", job.ID), panicErr).
+				WithContext("job_id", job.ID)
+		}
+	}()
+
+	return job.Fn(ctx)
+}
+
+// timeoutError erstellt den Retryable AppError für einen abgelaufenen Job-Timeout.
+func timeoutError(job Job) error {
+	return apperrors.NewRetryable(fmt.Sprintf("job %s timed out after %v", job.ID, job.Timeout), context.DeadlineExceeded).
+		WithContext("job_id", job.ID)
+}
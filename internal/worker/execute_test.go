@@ -0,0 +1,151 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apperrors "github.com/Sternrassler/EVE-SDE-Database-Builder/internal/errors"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/retry"
+)
+
+// TestPool_PanicRecovery tests that a panicking job becomes a Fatal error instead of crashing
+func TestPool_PanicRecovery(t *testing.T) {
+	pool := NewPool(2)
+	pool.Start(context.Background())
+
+	pool.Submit(Job{
+		ID: "bad.jsonl",
+		Fn: func(ctx context.Context) (interface{}, error) {
+			var m map[string]int
+			m["boom"] = 1 // nil map write panics
+			return nil, nil
+		},
+	})
+	pool.Submit(Job{
+		ID: "good.jsonl",
+		Fn: func(ctx context.Context) (interface{}, error) {
+			return "ok", nil
+		},
+	})
+
+	results, errs := pool.Wait()
+	if len(results) != 2 || len(errs) != 1 {
+		t.Fatalf("expected 2 results and 1 error, got %d results, %d errors", len(results), len(errs))
+	}
+
+	err := errs[0]
+	if !apperrors.IsFatal(err) {
+		t.Errorf("expected fatal error, got %v", err)
+	}
+
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Context["job_id"] != "bad.jsonl" {
+		t.Errorf("expected job_id context, got %v", err)
+	}
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("expected PanicError cause, got %T", err)
+	}
+	if panicErr.JobID != "bad.jsonl" || !strings.Contains(string(panicErr.Stack), "execute_test.go") {
+		t.Errorf("expected stack trace pointing at the job, got job=%s stack=%s", panicErr.JobID, panicErr.Stack)
+	}
+}
+
+// TestPool_JobTimeout tests that a hung job does not block Wait
+func TestPool_JobTimeout(t *testing.T) {
+	pool := NewPool(1)
+	pool.Start(context.Background())
+
+	block := make(chan struct{})
+	defer close(block)
+
+	pool.Submit(Job{
+		ID:      "hung",
+		Timeout: 20 * time.Millisecond,
+		Fn: func(ctx context.Context) (interface{}, error) {
+			<-block // ignores ctx
+			return nil, nil
+		},
+	})
+
+	done := make(chan struct{})
+	var errs []error
+	go func() {
+		_, errs = pool.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Wait blocked on hung job")
+	}
+
+	if len(errs) != 1 || !apperrors.IsRetryable(errs[0]) || !errors.Is(errs[0], context.DeadlineExceeded) {
+		t.Errorf("expected retryable timeout error, got %v", errs)
+	}
+}
+
+// TestPool_JobTimeout_CooperativeJob tests timeout reporting for jobs that honor ctx
+func TestPool_JobTimeout_CooperativeJob(t *testing.T) {
+	_, err := runAttempt(context.Background(), Job{
+		ID:      "slow",
+		Timeout: 10 * time.Millisecond,
+		Fn: func(ctx context.Context) (interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	})
+	if !apperrors.IsRetryable(err) {
+		t.Errorf("expected retryable timeout error, got %v", err)
+	}
+}
+
+// TestPool_JobRetry tests retrying timed-out attempts with a retry policy
+func TestPool_JobRetry(t *testing.T) {
+	var attempts atomic.Int32
+
+	data, err := executeJob(context.Background(), Job{
+		ID:      "flaky",
+		Timeout: 10 * time.Millisecond,
+		Retry:   retry.NewPolicy(3, time.Millisecond, 5*time.Millisecond),
+		Fn: func(ctx context.Context) (interface{}, error) {
+			if attempts.Add(1) < 3 {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return "done", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if data != "done" || attempts.Load() != 3 {
+		t.Errorf("expected 3 attempts with result 'done', got %d attempts, data %v", attempts.Load(), data)
+	}
+}
+
+// TestPool_JobRetry_NoRetryOnPanic tests that fatal panic errors are not retried
+func TestPool_JobRetry_NoRetryOnPanic(t *testing.T) {
+	var attempts atomic.Int32
+
+	_, err := executeJob(context.Background(), Job{
+		ID:    "panics",
+		Retry: retry.NewPolicy(3, time.Millisecond, 5*time.Millisecond),
+		Fn: func(ctx context.Context) (interface{}, error) {
+			attempts.Add(1)
+			panic("broken parser")
+		},
+	})
+	if !apperrors.IsFatal(err) {
+		t.Errorf("expected fatal error, got %v", err)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("expected exactly 1 attempt, got %d", attempts.Load())
+	}
+}
@@ -2,7 +2,9 @@ package worker
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
 	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
 )
 
@@ -32,10 +34,10 @@ type JobExecutor interface {
 
 // JobResult repräsentiert das Ergebnis einer Job-Ausführung.
 //
-// Dies ist ein Marker-Interface für verschiedene Result-Typen.
-// Implementierende Typen (z.B. ParseResult, InsertResult) können
-// spezifische Daten zurückgeben, die vom Aufrufer typsicher
-// ausgewertet werden können.
+// JobResult ist ein offenes Interface: jeder Typ kann Ergebnis eines Jobs
+// sein, auch außerhalb des Packages worker. Die mitgelieferten Typen
+// (ParseResult, InsertResult) wertet der Orchestrator per Type Switch aus;
+// unbekannte Ergebnistypen behandelt er wie ein fehlendes Ergebnis.
 //
 // Beispiel:
 //
@@ -44,16 +46,52 @@ type JobExecutor interface {
 //	    Output         string
 //	}
 //
-//	func (CustomResult) isJobResult() {}
-type JobResult interface {
-	isJobResult()
+//	func (j *CustomJob) Execute(ctx context.Context) (worker.JobResult, error) {
+//	    return CustomResult{ProcessedCount: 1}, nil
+//	}
+type JobResult interface{}
+
+// JobFunc ist ein Adapter, der eine Funktion als JobExecutor nutzbar macht.
+//
+// Beispiel (Post-Processing nach dem Import):
+//
+//	vacuum := worker.JobFunc(func(ctx context.Context) (worker.JobResult, error) {
+//	    err := writer.Do(ctx, func(db *sqlx.DB) error {
+//	        _, err := db.ExecContext(ctx, "ANALYZE")
+//	        return err
+//	    })
+//	    return nil, err
+//	})
+type JobFunc func(ctx context.Context) (JobResult, error)
+
+// Execute ruft f(ctx) auf.
+func (f JobFunc) Execute(ctx context.Context) (JobResult, error) {
+	return f(ctx)
+}
+
+// NewJob erstellt einen Pool-Job aus einem JobExecutor.
+//
+// Das JobResult wird als Result.Data geliefert; Timeout, Retry und Priority
+// können am zurückgegebenen Job gesetzt werden.
+//
+// Beispiel:
+//
+//	pool.Submit(worker.NewJob("types.jsonl", &worker.ParseJob{Parser: p, FilePath: path}))
+func NewJob(id string, exec JobExecutor) Job {
+	return Job{
+		ID: id,
+		Fn: func(ctx context.Context) (interface{}, error) {
+			return exec.Execute(ctx)
+		},
+	}
 }
 
 // ParseJob repräsentiert einen JSONL-Parse-Job.
 //
 // ParseJob wird für die parallele Phase 1 des EVE SDE Imports verwendet,
 // bei der JSONL-Dateien parallel geparst werden. Der Job kapselt einen
-// Parser und den Dateipfad.
+// Parser und den Dateipfad. Ist Chunk gesetzt, wird nur dieser Byte-Bereich
+// geparst (der Parser muss dann parser.RangeParser implementieren).
 //
 // Beispiel:
 //
@@ -65,41 +103,111 @@ type JobResult interface {
 type ParseJob struct {
 	Parser   parser.Parser // Parser-Implementierung für das File-Format
 	FilePath string        // Pfad zur zu parsenden JSONL-Datei
+	Chunk    *parser.Chunk // Optionaler Byte-Bereich (nil = ganze Datei)
+	// ErrorMode parst per parser.ErrorHandlingParser im angegebenen Modus
+	// (nil = ParseFile, Abbruch bei der ersten fehlerhaften Zeile)
+	ErrorMode *parser.ErrorMode
+	MaxErrors int // Fehler-Schwelle für ErrorModeSkip (0 = unbegrenzt)
 }
 
 // Execute führt den Parse-Job aus.
 //
-// Execute ruft die ParseFile-Methode des Parsers auf und gibt ein
-// ParseResult mit den geparsten Items zurück. Context-Cancellation
-// wird respektiert.
+// Execute ruft die ParseFile-Methode (bzw. ParseRange bei gesetztem Chunk,
+// ParseFileWithErrorHandling bei gesetztem ErrorMode) des Parsers auf und
+// gibt ein ParseResult mit den geparsten Items und den Ziel-Metadaten zurück.
+// Auch im Fehlerfall enthält das Result Datei, Tabelle und Chunk-Index.
+// Context-Cancellation wird respektiert.
 func (j *ParseJob) Execute(ctx context.Context) (JobResult, error) {
-	items, err := j.Parser.ParseFile(ctx, j.FilePath)
-	return ParseResult{Items: items}, err
+	result := ParseResult{
+		File:    j.FilePath,
+		Table:   j.Parser.TableName(),
+		Columns: j.Parser.Columns(),
+	}
+
+	var err error
+	if j.ErrorMode != nil {
+		ep, ok := j.Parser.(parser.ErrorHandlingParser)
+		if !ok {
+			return result, fmt.Errorf("parser for table %s does not support error modes", result.Table)
+		}
+		if j.Chunk != nil {
+			return result, fmt.Errorf("error mode %s cannot be combined with chunked parsing", *j.ErrorMode)
+		}
+		result.Items, result.Skipped, err = ep.ParseFileWithErrorHandling(ctx, j.FilePath, *j.ErrorMode, j.MaxErrors)
+	} else if j.Chunk != nil {
+		rp, ok := j.Parser.(parser.RangeParser)
+		if !ok {
+			return result, fmt.Errorf("parser for table %s does not support range parsing", result.Table)
+		}
+		result.Chunk = j.Chunk.Index
+		result.Items, err = rp.ParseRange(ctx, j.FilePath, *j.Chunk)
+	} else {
+		result.Items, err = j.Parser.ParseFile(ctx, j.FilePath)
+	}
+	return result, err
 }
 
 // InsertJob repräsentiert einen Datenbank-Insert-Job.
 //
 // InsertJob wird für die sequentielle Phase 2 des EVE SDE Imports verwendet,
 // bei der geparste Daten in die SQLite-Datenbank eingefügt werden.
-// Diese Phase respektiert das 1-Writer-Constraint von SQLite.
+// Das 1-Writer-Constraint von SQLite wird über den gemeinsamen DBWriter
+// eingehalten; InsertJobs können daher auch parallel ausgeführt werden.
 //
-// Hinweis: Die Implementierung ist derzeit ein Platzhalter für zukünftige
-// Features (siehe TODO).
+// Rows enthält Parser-Records: Structs oder Pointer auf Structs (Felder in
+// Spaltenreihenfolge) oder []interface{} mit den Werten in Spaltenreihenfolge.
+// Maps werden abgelehnt, da sie keine Spaltenreihenfolge haben.
+//
+// Beispiel:
+//
+//	job := &InsertJob{
+//	    Writer:  worker.NewDBWriter(db),
+//	    Table:   "invTypes",
+//	    Columns: p.Columns(),
+//	    Rows:    records,
+//	}
+//	result, err := job.Execute(ctx)
 type InsertJob struct {
-	Table string        // Ziel-Tabelle für Insert
-	Rows  []interface{} // Einzufügende Zeilen
+	Writer    *DBWriter     // Gemeinsamer Single-Writer-Zugang zur Datenbank
+	Table     string        // Ziel-Tabelle für Insert
+	Columns   []string      // Spalten-Namen für Insert
+	Rows      []interface{} // Einzufügende Zeilen
+	BatchSize int           // Zeilen pro INSERT-Statement (0 = DefaultInsertBatchSize)
+	// OnConflict ist die Konfliktstrategie der INSERTs (leer = database.ConflictAbort)
+	OnConflict database.ConflictStrategy
+	// Progress wird nach jedem Batch mit eingefügten und gesamten Zeilen aufgerufen (optional)
+	Progress database.ProgressCallback
 }
 
 // Execute führt den Insert-Job aus.
 //
-// Hinweis: Dies ist eine Platzhalter-Implementierung für Phase 2 (DB-Insert).
-// Die vollständige Implementierung wird in einem späteren Issue ergänzt.
-//
-// TODO: Implementierung der DB-Insert-Logik (sequenzieller Writer)
+// Alle Zeilen werden in einer Transaktion eingefügt; bei einem Fehler wird
+// nichts geschrieben und RowsAffected ist 0.
 func (j *InsertJob) Execute(ctx context.Context) (JobResult, error) {
-	// TODO: Implementierung der DB-Insert-Logik
-	// Dies wird in einem späteren Issue implementiert (sequenzieller Writer)
-	return InsertResult{RowsAffected: len(j.Rows)}, nil
+	if j.Writer == nil {
+		return InsertResult{}, fmt.Errorf("insert job for table %s has no writer", j.Table)
+	}
+	if len(j.Rows) == 0 {
+		return InsertResult{}, nil
+	}
+
+	rows, err := recordsToRows(j.Rows, len(j.Columns))
+	if err != nil {
+		return InsertResult{}, fmt.Errorf("failed to convert records for table %s: %w", j.Table, err)
+	}
+
+	batchSize := j.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultInsertBatchSize
+	}
+	if err := j.Writer.InsertWithOptions(ctx, j.Table, j.Columns, rows, database.BatchOptions{
+		BatchSize:  batchSize,
+		OnConflict: j.OnConflict,
+		Progress:   j.Progress,
+	}); err != nil {
+		return InsertResult{}, err
+	}
+	return InsertResult{RowsAffected: len(rows)}, nil
 }
 
 // ParseResult repräsentiert das Ergebnis eines Parse-Jobs.
@@ -108,12 +216,14 @@ func (j *InsertJob) Execute(ctx context.Context) (JobResult, error) {
 // Die Items sind als []interface{} repräsentiert, um verschiedene
 // Parser-Typen zu unterstützen.
 type ParseResult struct {
-	Items []interface{} // Geparste Items aus der JSONL-Datei
+	File    string        // Quelldatei
+	Table   string        // Ziel-Tabelle des Parsers
+	Columns []string      // Spalten-Namen des Parsers
+	Items   []interface{} // Geparste Items aus der JSONL-Datei
+	Chunk   int           // Chunk-Index bei geteilten Dateien (0 sonst)
+	Skipped []error       // Übersprungene fehlerhafte Zeilen (nur mit ErrorMode)
 }
 
-// isJobResult markiert ParseResult als JobResult-Implementierung.
-func (ParseResult) isJobResult() {}
-
 // InsertResult repräsentiert das Ergebnis eines Insert-Jobs.
 //
 // InsertResult gibt Auskunft über die Anzahl der eingefügten Zeilen
@@ -121,6 +231,3 @@ func (ParseResult) isJobResult() {}
 type InsertResult struct {
 	RowsAffected int // Anzahl der eingefügten Zeilen
 }
-
-// isJobResult markiert InsertResult als JobResult-Implementierung.
-func (InsertResult) isJobResult() {}
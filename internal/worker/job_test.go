@@ -5,6 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+	"github.com/jmoiron/sqlx"
 )
 
 // MockParser implements parser.Parser for testing
@@ -168,19 +172,52 @@ func TestParseJob_ExecuteWithEmptyResult(t *testing.T) {
 	}
 }
 
-// TestInsertJob_Execute tests basic InsertJob execution
+// insertTestRecord is a parser record used by InsertJob tests
+type insertTestRecord struct {
+	ID   int
+	Name string
+}
+
+// newMemoryTestDB creates an in-memory database and executes the given
+// CREATE TABLE statements
+func newMemoryTestDB(t *testing.T, schema ...string) *sqlx.DB {
+	t.Helper()
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, stmt := range schema {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("failed to create test table: %v", err)
+		}
+	}
+	return db
+}
+
+// newInsertTestWriter creates an in-memory database with test_table and a writer for it
+func newInsertTestWriter(t *testing.T) *DBWriter {
+	t.Helper()
+	return NewDBWriter(newMemoryTestDB(t, "CREATE TABLE test_table (id INTEGER PRIMARY KEY, name TEXT)"))
+}
+
+// TestInsertJob_Execute tests that InsertJob writes rows to the database
 func TestInsertJob_Execute(t *testing.T) {
 	ctx := context.Background()
+	writer := newInsertTestWriter(t)
 
 	rows := []interface{}{
-		map[string]interface{}{"id": 1, "name": "Item1"},
-		map[string]interface{}{"id": 2, "name": "Item2"},
-		map[string]interface{}{"id": 3, "name": "Item3"},
+		insertTestRecord{ID: 1, Name: "Item1"},
+		&insertTestRecord{ID: 2, Name: "Item2"},
+		insertTestRecord{ID: 3, Name: "Item3"},
 	}
 
 	job := &InsertJob{
-		Table: "test_table",
-		Rows:  rows,
+		Writer:  writer,
+		Table:   "test_table",
+		Columns: []string{"id", "name"},
+		Rows:    rows,
 	}
 
 	result, err := job.Execute(ctx)
@@ -197,6 +234,14 @@ func TestInsertJob_Execute(t *testing.T) {
 	if insertResult.RowsAffected != len(rows) {
 		t.Errorf("expected %d rows affected, got %d", len(rows), insertResult.RowsAffected)
 	}
+
+	var names []string
+	if err := writer.DB().Select(&names, "SELECT name FROM test_table ORDER BY id"); err != nil {
+		t.Fatalf("failed to query rows: %v", err)
+	}
+	if len(names) != 3 || names[1] != "Item2" {
+		t.Errorf("expected [Item1 Item2 Item3], got %v", names)
+	}
 }
 
 // TestInsertJob_ExecuteWithEmptyRows tests InsertJob with no rows
@@ -204,8 +249,10 @@ func TestInsertJob_ExecuteWithEmptyRows(t *testing.T) {
 	ctx := context.Background()
 
 	job := &InsertJob{
-		Table: "test_table",
-		Rows:  []interface{}{},
+		Writer:  newInsertTestWriter(t),
+		Table:   "test_table",
+		Columns: []string{"id", "name"},
+		Rows:    []interface{}{},
 	}
 
 	result, err := job.Execute(ctx)
@@ -226,21 +273,151 @@ func TestInsertJob_ExecuteWithEmptyRows(t *testing.T) {
 
 // TestInsertJob_ExecuteWithContext tests InsertJob respects context
 func TestInsertJob_ExecuteWithContext(t *testing.T) {
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
 
 	job := &InsertJob{
-		Table: "test_table",
-		Rows:  []interface{}{map[string]interface{}{"id": 1}},
+		Writer:  newInsertTestWriter(t),
+		Table:   "test_table",
+		Columns: []string{"id", "name"},
+		Rows:    []interface{}{insertTestRecord{ID: 1, Name: "Item1"}},
 	}
 
-	result, err := job.Execute(ctx)
+	_, err := job.Execute(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+// TestInsertJob_ExecuteErrors tests InsertJob failure cases
+func TestInsertJob_ExecuteErrors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		job  *InsertJob
+	}{
+		{
+			name: "missing writer",
+			job: &InsertJob{
+				Table:   "test_table",
+				Columns: []string{"id", "name"},
+				Rows:    []interface{}{insertTestRecord{ID: 1}},
+			},
+		},
+		{
+			name: "column count mismatch",
+			job: &InsertJob{
+				Writer:  newInsertTestWriter(t),
+				Table:   "test_table",
+				Columns: []string{"id"},
+				Rows:    []interface{}{insertTestRecord{ID: 1}},
+			},
+		},
+		{
+			name: "unknown table",
+			job: &InsertJob{
+				Writer:  newInsertTestWriter(t),
+				Table:   "missing_table",
+				Columns: []string{"id", "name"},
+				Rows:    []interface{}{insertTestRecord{ID: 1}},
+			},
+		},
+	}
 
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.job.Execute(ctx)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if result.(InsertResult).RowsAffected != 0 {
+				t.Errorf("expected 0 rows affected, got %d", result.(InsertResult).RowsAffected)
+			}
+		})
+	}
+}
+
+// TestInsertJob_ConcurrentWriters tests that InsertJobs sharing a writer can run in the pool
+func TestInsertJob_ConcurrentWriters(t *testing.T) {
+	writer := newInsertTestWriter(t)
+
+	pool := NewPool(4)
+	pool.Start(context.Background())
+	for i := 0; i < 20; i++ {
+		pool.Submit(NewJob(fmt.Sprintf("insert-%d", i), &InsertJob{
+			Writer:  writer,
+			Table:   "test_table",
+			Columns: []string{"id", "name"},
+			Rows:    []interface{}{insertTestRecord{ID: i + 1, Name: fmt.Sprintf("Item%d", i+1)}},
+		}))
+	}
+	results, errs := pool.Wait()
+
+	if len(results) != 20 || len(errs) != 0 {
+		t.Fatalf("expected 20 successful results, got %d results, errors: %v", len(results), errs)
+	}
+
+	var count int
+	if err := writer.DB().Get(&count, "SELECT COUNT(*) FROM test_table"); err != nil {
+		t.Fatalf("failed to count rows: %v", err)
+	}
+	if count != 20 {
+		t.Errorf("expected 20 rows, got %d", count)
+	}
+}
+
+// TestParseJob_ExecuteChunk tests ParseJob with a byte range
+func TestParseJob_ExecuteChunk(t *testing.T) {
+	path := writeChunkTestFile(t, t.TempDir(), "rows.jsonl", 100)
+	p := parser.NewJSONLParser[chunkTestRow]("rows", []string{"id", "name"})
+
+	chunks, err := parser.SplitJSONL(path, 512)
 	if err != nil {
-		t.Fatalf("expected no error, got %v", err)
+		t.Fatalf("failed to split file: %v", err)
+	}
+	if len(chunks) < 2 {
+		t.Fatalf("expected multiple chunks, got %d", len(chunks))
 	}
 
-	if result == nil {
-		t.Fatal("expected non-nil result")
+	job := &ParseJob{Parser: p, FilePath: path, Chunk: &chunks[1]}
+	result, err := job.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pr := result.(ParseResult)
+	if pr.Chunk != 1 || pr.Table != "rows" || pr.File != path {
+		t.Errorf("unexpected result metadata: chunk=%d table=%s file=%s", pr.Chunk, pr.Table, pr.File)
+	}
+	if len(pr.Items) == 0 {
+		t.Error("expected items from chunk")
+	}
+
+	// Parsers without range support cannot parse chunks
+	job = &ParseJob{Parser: &MockParser{tableName: "mock"}, FilePath: path, Chunk: &chunks[0]}
+	if _, err := job.Execute(context.Background()); err == nil {
+		t.Error("expected error for parser without range support")
+	}
+}
+
+// TestNewJob tests the JobExecutor adapter for the pool
+func TestNewJob(t *testing.T) {
+	pool := NewPool(1)
+	pool.Start(context.Background())
+	pool.Submit(NewJob("func-job", JobFunc(func(ctx context.Context) (JobResult, error) {
+		return InsertResult{RowsAffected: 7}, nil
+	})))
+	results, errs := pool.Wait()
+
+	if len(results) != 1 || len(errs) != 0 {
+		t.Fatalf("expected 1 successful result, got %d results, errors: %v", len(results), errs)
+	}
+	if results[0].JobID != "func-job" {
+		t.Errorf("expected job ID func-job, got %s", results[0].JobID)
+	}
+	if res, ok := results[0].Data.(InsertResult); !ok || res.RowsAffected != 7 {
+		t.Errorf("expected InsertResult{7}, got %#v", results[0].Data)
 	}
 }
 
@@ -262,8 +439,9 @@ func TestJobInterface_Compliance(t *testing.T) {
 		{
 			name: "InsertJob implements JobExecutor",
 			job: &InsertJob{
-				Table: "test_table",
-				Rows:  []interface{}{},
+				Writer: newInsertTestWriter(t),
+				Table:  "test_table",
+				Rows:   []interface{}{},
 			},
 		},
 	}
@@ -337,17 +515,33 @@ func Example_parseJob() {
 func Example_insertJob() {
 	ctx := context.Background()
 
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		panic(err)
+	}
+	defer func() { _ = db.Close() }()
+	if _, err := db.Exec("CREATE TABLE types (typeID INTEGER, typeName TEXT)"); err != nil {
+		panic(err)
+	}
+
+	type typeRecord struct {
+		TypeID   int
+		TypeName string
+	}
+
 	// Create sample rows to insert
 	rows := []interface{}{
-		map[string]interface{}{"typeID": 1, "typeName": "Tritanium"},
-		map[string]interface{}{"typeID": 2, "typeName": "Pyerite"},
-		map[string]interface{}{"typeID": 3, "typeName": "Mexallon"},
+		typeRecord{TypeID: 1, TypeName: "Tritanium"},
+		typeRecord{TypeID: 2, TypeName: "Pyerite"},
+		typeRecord{TypeID: 3, TypeName: "Mexallon"},
 	}
 
-	// Create an InsertJob
+	// Create an InsertJob writing through a shared single-writer handle
 	job := &InsertJob{
-		Table: "types",
-		Rows:  rows,
+		Writer:  NewDBWriter(db),
+		Table:   "types",
+		Columns: []string{"typeID", "typeName"},
+		Rows:    rows,
 	}
 
 	// Execute the job
@@ -0,0 +1,234 @@
+package worker
+
+import (
+	"sync"
+	"time"
+)
+
+// Phase bezeichnet eine Phase des Orchestrator-Imports.
+type Phase int
+
+const (
+	// PhaseParse ist die parallele Parse-Phase (Phase 1).
+	PhaseParse Phase = iota + 1
+	// PhaseInsert ist die sequenzielle Insert-Phase (Phase 2).
+	PhaseInsert
+	// PhasePostProcess führt die per WithPostJobs registrierten Jobs aus.
+	PhasePostProcess
+)
+
+// String gibt den Namen der Phase zurück.
+func (p Phase) String() string {
+	switch p {
+	case PhaseParse:
+		return "parse"
+	case PhaseInsert:
+		return "insert"
+	case PhasePostProcess:
+		return "post-process"
+	default:
+		return "unknown"
+	}
+}
+
+// ImportObserver empfängt Lifecycle-Events eines Orchestrator-Imports.
+//
+// Observer werden per WithObserver registriert und ermöglichen einbettenden
+// Anwendungen, auf den Import zu reagieren, statt ProgressTracker-Zähler zu
+// pollen (z.B. Fortschrittsanzeigen, Metriken, Benachrichtigungen).
+//
+// Reihenfolge der Events:
+//
+//	OnImportStart → OnScanComplete → OnPhaseChange(PhaseParse)
+//	→ (OnWorkerActivity | OnFileParsed)*
+//	→ OnPhaseChange(PhaseInsert) → (OnInsertProgress* → OnFileInserted | OnFileFailed)*
+//	→ [OnPhaseChange(PhasePostProcess)] → OnImportDone
+//
+// OnWorkerActivity und OnFileParsed werden aus den Worker-Goroutines
+// aufgerufen und können daher parallel auftreten; Implementierungen müssen Thread-Safe sein und sollten
+// schnell zurückkehren, da sie den jeweiligen Worker blockieren.
+//
+// Eigene Observer können NopObserver einbetten und nur die benötigten
+// Methoden überschreiben:
+//
+//	type failureLogger struct{ worker.NopObserver }
+//
+//	func (failureLogger) OnFileFailed(file string, err error) {
+//	    log.Printf("%s: %v", file, err)
+//	}
+type ImportObserver interface {
+	// OnImportStart wird mit allen zu importierenden Dateien aufgerufen.
+	OnImportStart(sdeDir string, files []string)
+	// OnScanComplete wird nach dem Vorab-Scan mit Zeilen- und Byte-Zahlen je
+	// Datei aufgerufen (Grundlage für zeilenbasierten Fortschritt und ETA).
+	OnScanComplete(scan ScanResult)
+	// OnPhaseChange wird beim Eintritt in eine neue Phase aufgerufen.
+	OnPhaseChange(phase Phase)
+	// OnWorkerActivity meldet Beginn, Zwischenstand (alle
+	// parser.ProgressInterval Records) und Ende eines Parse-Jobs je Worker.
+	OnWorkerActivity(activity WorkerActivity)
+	// OnFileParsed wird aufgerufen, wenn eine Datei (bzw. alle ihre Chunks)
+	// erfolgreich geparst wurde.
+	OnFileParsed(file string, rows int, duration time.Duration)
+	// OnInsertProgress wird in Phase 2 nach jedem Insert-Batch aufgerufen.
+	OnInsertProgress(progress InsertProgress)
+	// OnFileInserted wird aufgerufen, wenn die Zeilen einer Datei eingefügt wurden.
+	OnFileInserted(file string, rows int, duration time.Duration)
+	// OnFileFailed wird aufgerufen, wenn Parsen oder Insert einer Datei fehlschlägt.
+	OnFileFailed(file string, err error)
+	// OnImportDone wird genau einmal nach OnImportStart aufgerufen, auch bei
+	// Abbruch oder Fehler (err != nil).
+	OnImportDone(progress Progress, err error)
+}
+
+// WorkerActivity beschreibt den aktuellen Parse-Job eines Workers.
+type WorkerActivity struct {
+	WorkerID int       // Worker-Index (0-basiert, siehe WorkerID)
+	File     string    // Datei des Jobs
+	Chunk    int       // Chunk-Index bei geteilten Dateien, sonst -1
+	Rows     int       // Bisher geparste Records
+	Started  time.Time // Start des Jobs
+	Done     bool      // Job beendet (erfolgreich oder fehlgeschlagen)
+	Err      error     // Fehler des Jobs (nur bei Done)
+}
+
+// InsertProgress beschreibt den Fortschritt des Inserts einer Datei.
+type InsertProgress struct {
+	File      string // Quelldatei
+	Table     string // Ziel-Tabelle
+	Rows      int    // Bisher eingefügte Zeilen
+	TotalRows int    // Einzufügende Zeilen der Datei
+	Batch     int    // Abgeschlossene Batches
+	Batches   int    // Anzahl Batches
+}
+
+// NopObserver ist ein ImportObserver ohne Verhalten (zum Einbetten).
+type NopObserver struct{}
+
+// OnImportStart implementiert ImportObserver.
+func (NopObserver) OnImportStart(string, []string) {}
+
+// OnScanComplete implementiert ImportObserver.
+func (NopObserver) OnScanComplete(ScanResult) {}
+
+// OnPhaseChange implementiert ImportObserver.
+func (NopObserver) OnPhaseChange(Phase) {}
+
+// OnFileParsed implementiert ImportObserver.
+func (NopObserver) OnFileParsed(string, int, time.Duration) {}
+
+// OnWorkerActivity implementiert ImportObserver.
+func (NopObserver) OnWorkerActivity(WorkerActivity) {}
+
+// OnInsertProgress implementiert ImportObserver.
+func (NopObserver) OnInsertProgress(InsertProgress) {}
+
+// OnFileInserted implementiert ImportObserver.
+func (NopObserver) OnFileInserted(string, int, time.Duration) {}
+
+// OnFileFailed implementiert ImportObserver.
+func (NopObserver) OnFileFailed(string, error) {}
+
+// OnImportDone implementiert ImportObserver.
+func (NopObserver) OnImportDone(Progress, error) {}
+
+// WithObserver registriert Observer für Import-Events.
+//
+// Mehrfach-Aufrufe sind möglich; Events werden in Registrierungs-Reihenfolge
+// an alle Observer verteilt.
+func WithObserver(observers ...ImportObserver) OrchestratorOption {
+	return func(o *Orchestrator) {
+		for _, obs := range observers {
+			if obs != nil {
+				o.observers = append(o.observers, obs)
+			}
+		}
+	}
+}
+
+// observerList verteilt Events an mehrere Observer.
+type observerList []ImportObserver
+
+func (l observerList) OnImportStart(sdeDir string, files []string) {
+	for _, obs := range l {
+		obs.OnImportStart(sdeDir, files)
+	}
+}
+
+func (l observerList) OnScanComplete(scan ScanResult) {
+	for _, obs := range l {
+		obs.OnScanComplete(scan)
+	}
+}
+
+func (l observerList) OnPhaseChange(phase Phase) {
+	for _, obs := range l {
+		obs.OnPhaseChange(phase)
+	}
+}
+
+func (l observerList) OnFileParsed(file string, rows int, duration time.Duration) {
+	for _, obs := range l {
+		obs.OnFileParsed(file, rows, duration)
+	}
+}
+
+func (l observerList) OnWorkerActivity(activity WorkerActivity) {
+	for _, obs := range l {
+		obs.OnWorkerActivity(activity)
+	}
+}
+
+func (l observerList) OnInsertProgress(progress InsertProgress) {
+	for _, obs := range l {
+		obs.OnInsertProgress(progress)
+	}
+}
+
+func (l observerList) OnFileInserted(file string, rows int, duration time.Duration) {
+	for _, obs := range l {
+		obs.OnFileInserted(file, rows, duration)
+	}
+}
+
+func (l observerList) OnFileFailed(file string, err error) {
+	for _, obs := range l {
+		obs.OnFileFailed(file, err)
+	}
+}
+
+func (l observerList) OnImportDone(progress Progress, err error) {
+	for _, obs := range l {
+		obs.OnImportDone(progress, err)
+	}
+}
+
+// parseTiming sammelt die Parse-Ergebnisse der Chunks einer Datei, damit
+// OnFileParsed erst nach dem letzten Chunk (einmal pro Datei) gemeldet wird.
+type parseTiming struct {
+	mu        sync.Mutex
+	remaining int
+	rows      int
+	start     time.Time
+	failed    bool
+}
+
+// done verbucht einen abgeschlossenen Chunk. Rückgabe ok ist true, wenn dies
+// der letzte Chunk war und keiner fehlgeschlagen ist; rows und duration
+// beziehen sich dann auf die ganze Datei (vom Start des ersten Chunks an).
+func (t *parseTiming) done(started time.Time, rows int, err error) (total int, duration time.Duration, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.start.IsZero() || started.Before(t.start) {
+		t.start = started
+	}
+	t.rows += rows
+	t.failed = t.failed || err != nil
+	t.remaining--
+
+	if t.remaining > 0 || t.failed {
+		return 0, 0, false
+	}
+	return t.rows, time.Since(t.start), true
+}
@@ -0,0 +1,242 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+)
+
+// recordingObserver records all events as strings
+type recordingObserver struct {
+	mu     sync.Mutex
+	events []string
+	parsed map[string]int
+	active map[int]int // WorkerID → gestartete, noch nicht beendete Jobs
+	jobs   int         // Anzahl beendeter Jobs
+	badIDs int         // Aktivitäten mit ungültiger Worker-ID
+	done   *Progress
+	err    error
+}
+
+func (r *recordingObserver) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recordingObserver) OnImportStart(sdeDir string, files []string) {
+	r.add(fmt.Sprintf("start:%d", len(files)))
+}
+
+func (r *recordingObserver) OnScanComplete(scan ScanResult) {
+	r.add(fmt.Sprintf("scan:%d", scan.TotalLines))
+}
+
+func (r *recordingObserver) OnPhaseChange(phase Phase) {
+	r.add("phase:" + phase.String())
+}
+
+func (r *recordingObserver) OnFileParsed(file string, rows int, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.parsed == nil {
+		r.parsed = make(map[string]int)
+	}
+	r.parsed[filepath.Base(file)] += rows
+}
+
+func (r *recordingObserver) OnWorkerActivity(activity WorkerActivity) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.active == nil {
+		r.active = make(map[int]int)
+	}
+	if activity.WorkerID < 0 || activity.WorkerID > 1 {
+		r.badIDs++
+	}
+	switch {
+	case activity.Done:
+		r.active[activity.WorkerID]--
+		r.jobs++
+	case activity.Rows == 0:
+		r.active[activity.WorkerID]++
+	}
+}
+
+func (r *recordingObserver) OnInsertProgress(progress InsertProgress) {
+	r.add(fmt.Sprintf("insert-progress:%s:%d/%d:batch %d/%d",
+		progress.Table, progress.Rows, progress.TotalRows, progress.Batch, progress.Batches))
+}
+
+func (r *recordingObserver) OnFileInserted(file string, rows int, duration time.Duration) {
+	r.add(fmt.Sprintf("inserted:%s:%d", filepath.Base(file), rows))
+}
+
+func (r *recordingObserver) OnFileFailed(file string, err error) {
+	r.add("failed:" + filepath.Base(file))
+}
+
+func (r *recordingObserver) OnImportDone(progress Progress, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, "done")
+	r.done = &progress
+	r.err = err
+}
+
+// TestOrchestrator_Observer tests the event sequence of a successful import
+// with a chunked file, a failing file and a post job
+func TestOrchestrator_Observer(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeChunkTestFile(t, tmpDir, "rows.jsonl", 500)
+	if err := os.WriteFile(filepath.Join(tmpDir, "broken.jsonl"), []byte("{not json}\n"), 0o644); err != nil {
+		t.Fatalf("failed to write broken file: %v", err)
+	}
+
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	if _, err := db.Exec("CREATE TABLE rows (id INTEGER, name TEXT)"); err != nil {
+		t.Fatalf("failed to create test table: %v", err)
+	}
+
+	parsers := map[string]parser.Parser{
+		"rows":   parser.NewJSONLParser[chunkTestRow]("rows", []string{"id", "name"}),
+		"broken": parser.NewJSONLParser[chunkTestRow]("rows", []string{"id", "name"}),
+	}
+	obs := &recordingObserver{}
+	post := JobFunc(func(ctx context.Context) (JobResult, error) { return nil, nil })
+	orch := NewOrchestrator(db, NewPool(2), parsers,
+		WithChunkSize(1024), WithObserver(obs, nil), WithPostJobs(post))
+
+	if _, err := orch.ImportAll(context.Background(), tmpDir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		"start:2",
+		"scan:501",
+		"phase:parse",
+		"phase:insert",
+		"failed:broken.jsonl",
+		"insert-progress:rows:500/500:batch 1/1",
+		"inserted:rows.jsonl:500",
+		"phase:post-process",
+		"done",
+	}
+	if fmt.Sprint(obs.events) != fmt.Sprint(want) {
+		t.Errorf("expected events %v, got %v", want, obs.events)
+	}
+
+	// Chunked file is reported once with all rows
+	if len(obs.parsed) != 1 || obs.parsed["rows.jsonl"] != 500 {
+		t.Errorf("expected rows.jsonl parsed once with 500 rows, got %v", obs.parsed)
+	}
+	if obs.done == nil || obs.done.FailedFiles != 1 || obs.done.InsertedRows != 500 {
+		t.Errorf("unexpected final progress: %+v", obs.done)
+	}
+
+	// Every parse job (chunks + broken file) is started and finished on a pool worker
+	if obs.jobs < 3 || obs.badIDs != 0 {
+		t.Errorf("expected at least 3 finished jobs with valid worker IDs, got %d jobs, %d bad IDs", obs.jobs, obs.badIDs)
+	}
+	for id, n := range obs.active {
+		if n != 0 {
+			t.Errorf("worker %d has %d unfinished jobs", id, n)
+		}
+	}
+
+	// Totals from the pre-scan; failed files count as processed bytes
+	if obs.done.TotalRows != 501 || obs.done.TotalBytes == 0 || obs.done.ProcessedBytes != obs.done.TotalBytes {
+		t.Errorf("unexpected row/byte totals: %+v", obs.done)
+	}
+}
+
+// TestOrchestrator_Observer_DoneOnError tests that OnImportDone reports errors
+func TestOrchestrator_Observer_DoneOnError(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeChunkTestFile(t, tmpDir, "rows.jsonl", 5)
+
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	if _, err := db.Exec("CREATE TABLE rows (id INTEGER, name TEXT)"); err != nil {
+		t.Fatalf("failed to create test table: %v", err)
+	}
+
+	errPost := errors.New("post failed")
+	parsers := map[string]parser.Parser{
+		"rows": parser.NewJSONLParser[chunkTestRow]("rows", []string{"id", "name"}),
+	}
+	obs := &recordingObserver{}
+	orch := NewOrchestrator(db, NewPool(1), parsers, WithObserver(obs),
+		WithPostJobs(JobFunc(func(ctx context.Context) (JobResult, error) { return nil, errPost })))
+
+	_, err = orch.ImportAll(context.Background(), tmpDir)
+	if !errors.Is(err, errPost) {
+		t.Fatalf("expected post job error, got %v", err)
+	}
+	if !errors.Is(obs.err, errPost) {
+		t.Errorf("expected OnImportDone to receive post job error, got %v", obs.err)
+	}
+}
+
+// TestPhase_String tests phase names
+func TestPhase_String(t *testing.T) {
+	tests := map[Phase]string{
+		PhaseParse:       "parse",
+		PhaseInsert:      "insert",
+		PhasePostProcess: "post-process",
+		Phase(0):         "unknown",
+	}
+	for phase, want := range tests {
+		if got := phase.String(); got != want {
+			t.Errorf("Phase(%d).String() = %q, want %q", phase, got, want)
+		}
+	}
+}
+
+// TestOrchestrator_ParseJob_TimeoutStopsActivity tests that an abandoned
+// attempt reports no activity once its timeout has fired
+func TestOrchestrator_ParseJob_TimeoutStopsActivity(t *testing.T) {
+	obs := &recordingObserver{}
+	orch := NewOrchestrator(nil, NewPool(1), nil, WithObserver(obs))
+
+	finished := make(chan struct{})
+	task := ParseTask{File: "hung.jsonl", Parser: &MockParser{
+		tableName: "hung",
+		parseFunc: func(ctx context.Context, path string) ([]interface{}, error) {
+			defer close(finished)
+			time.Sleep(50 * time.Millisecond) // ignores ctx
+			return []interface{}{}, nil
+		},
+	}}
+	job := orch.parseJob(task, nil, &parseTiming{remaining: 1}, func(string, int, time.Duration) {})
+	job.Timeout = 10 * time.Millisecond
+
+	if _, err := executeJob(withWorkerID(context.Background(), 0), job); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	<-finished
+	time.Sleep(10 * time.Millisecond) // abandoned attempt returns after the parser
+
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+	if obs.active[0] != 1 || obs.jobs != 0 {
+		t.Errorf("expected only the start activity, got %d active and %d finished jobs", obs.active[0], obs.jobs)
+	}
+}
@@ -6,11 +6,12 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
 
-	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
 	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
 	"github.com/jmoiron/sqlx"
 )
@@ -28,17 +29,23 @@ import (
 //	    progress.PercentFiles, progress.ParsedFiles, progress.TotalFiles,
 //	    progress.RowsPerSecond, progress.ETA)
 type Progress struct {
-	ParsedFiles   int64         // Anzahl vollständig geparster Dateien
-	InsertedFiles int64         // Anzahl erfolgreich eingefügter Dateien
-	FailedFiles   int64         // Anzahl fehlgeschlagener Dateien
-	TotalFiles    int64         // Gesamtzahl der zu verarbeitenden Dateien
-	TotalRows     int64         // Gesamtzahl der zu verarbeitenden Zeilen (wenn bekannt)
-	InsertedRows  int64         // Anzahl eingefügter Zeilen
-	PercentFiles  float64       // Fortschritt in % (basierend auf Dateien)
-	PercentRows   float64       // Fortschritt in % (basierend auf Zeilen)
-	ETA           time.Duration // Geschätzte verbleibende Zeit
-	ElapsedTime   time.Duration // Verstrichene Zeit seit Start
-	RowsPerSecond float64       // Durchsatz (Zeilen/Sekunde)
+	ParsedFiles    int64         // Anzahl vollständig geparster Dateien
+	InsertedFiles  int64         // Anzahl erfolgreich eingefügter Dateien
+	FailedFiles    int64         // Anzahl fehlgeschlagener Dateien
+	TotalFiles     int64         // Gesamtzahl der zu verarbeitenden Dateien
+	TotalRows      int64         // Gesamtzahl der zu verarbeitenden Zeilen (wenn bekannt)
+	InsertedRows   int64         // Anzahl eingefügter Zeilen
+	ParsedRows     int64         // Anzahl in Phase 1 geparster Zeilen
+	TotalBytes     int64         // Gesamtgröße der zu verarbeitenden Dateien (wenn bekannt)
+	ProcessedBytes int64         // Größe der abgeschlossenen Dateien
+	ParsedBytes    int64         // Größe der in Phase 1 geparsten Dateien
+	SkippedLines   int64         // Im parser.ErrorModeSkip übersprungene fehlerhafte Zeilen
+	PercentFiles   float64       // Fortschritt in % (basierend auf Dateien)
+	PercentRows    float64       // Fortschritt in % (basierend auf Zeilen)
+	PercentBytes   float64       // Fortschritt in % (basierend auf Bytes)
+	ETA            time.Duration // Geschätzte verbleibende Zeit
+	ElapsedTime    time.Duration // Verstrichene Zeit seit Start
+	RowsPerSecond  float64       // Durchsatz (Zeilen/Sekunde)
 }
 
 // ProgressTracker verfolgt den Fortschritt des Imports mit atomaren Zählern.
@@ -60,12 +67,17 @@ type Progress struct {
 //	// Fortschritt abrufen
 //	progress := tracker.GetProgressDetailed()
 type ProgressTracker struct {
-	parsedFiles  atomic.Int64
-	insertedRows atomic.Int64
-	failed       atomic.Int64
-	totalFiles   int64
-	totalRows    atomic.Int64
-	startTime    time.Time
+	parsedFiles    atomic.Int64
+	insertedRows   atomic.Int64
+	parsedRows     atomic.Int64
+	parsedBytes    atomic.Int64
+	failed         atomic.Int64
+	totalFiles     int64
+	totalRows      atomic.Int64
+	totalBytes     atomic.Int64
+	processedBytes atomic.Int64
+	skippedLines   atomic.Int64
+	startTime      time.Time
 }
 
 // NewProgressTracker erstellt einen neuen ProgressTracker.
@@ -84,11 +96,24 @@ func NewProgressTracker(total int) *ProgressTracker {
 // SetTotalRows setzt die Gesamtzahl der zu verarbeitenden Zeilen.
 //
 // Diese Methode sollte aufgerufen werden, wenn die erwartete Gesamtzeilenzahl
-// bekannt ist (z.B. nach Parsing-Phase). Ermöglicht präzisere ETA-Berechnung.
+// bekannt ist (z.B. nach dem Vorab-Scan, siehe ScanTasks). Ermöglicht
+// präzisere ETA-Berechnung.
 func (p *ProgressTracker) SetTotalRows(total int64) {
 	p.totalRows.Store(total)
 }
 
+// SetTotalBytes setzt die Gesamtgröße der zu verarbeitenden Dateien.
+func (p *ProgressTracker) SetTotalBytes(total int64) {
+	p.totalBytes.Store(total)
+}
+
+// AddProcessedBytes verbucht die Größe einer abgeschlossenen Datei.
+//
+// Thread-Safe. Fehlgeschlagene Dateien zählen ebenfalls als verarbeitet.
+func (p *ProgressTracker) AddProcessedBytes(count int64) {
+	p.processedBytes.Add(count)
+}
+
 // IncrementParsed erhöht den Parse-Counter.
 //
 // Sollte aufgerufen werden, wenn eine Datei erfolgreich geparst wurde.
@@ -139,6 +164,22 @@ func (p *ProgressTracker) AddInsertedRows(count int64) {
 	p.insertedRows.Add(count)
 }
 
+// AddParsedRows verbucht die Zeilen und die Größe einer in Phase 1
+// geparsten Datei, damit der Fortschritt schon während des Parsens steigt.
+//
+// Thread-Safe.
+func (p *ProgressTracker) AddParsedRows(rows, bytes int64) {
+	p.parsedRows.Add(rows)
+	p.parsedBytes.Add(bytes)
+}
+
+// AddSkippedLines verbucht im parser.ErrorModeSkip übersprungene Zeilen.
+//
+// Thread-Safe.
+func (p *ProgressTracker) AddSkippedLines(count int64) {
+	p.skippedLines.Add(count)
+}
+
 // GetProgress gibt die aktuellen Zähler zurück (für Rückwärtskompatibilität).
 //
 // Deprecated: Verwenden Sie stattdessen GetProgressDetailed() für detaillierte
@@ -165,7 +206,7 @@ func (p *ProgressTracker) GetProgress() (parsed, inserted, failed, total int) {
 // ETA (Estimated Time to Arrival) und Durchsatz (Zeilen/Sekunde).
 //
 // Die ETA wird bevorzugt aus Zeilen-Metriken berechnet (präziser), mit
-// Fallback auf Datei-Metriken.
+// Fallback auf Byte- und zuletzt Datei-Metriken.
 //
 // Thread-Safe.
 func (p *ProgressTracker) GetProgressDetailed() Progress {
@@ -173,6 +214,8 @@ func (p *ProgressTracker) GetProgressDetailed() Progress {
 	insertedRows := p.insertedRows.Load()
 	failedFiles := p.failed.Load()
 	totalRows := p.totalRows.Load()
+	totalBytes := p.totalBytes.Load()
+	processedBytes := p.processedBytes.Load()
 	elapsed := time.Since(p.startTime)
 
 	// Prozentberechnung (Dateien)
@@ -187,6 +230,12 @@ func (p *ProgressTracker) GetProgressDetailed() Progress {
 		percentRows = float64(insertedRows) / float64(totalRows) * 100.0
 	}
 
+	// Prozentberechnung (Bytes)
+	var percentBytes float64
+	if totalBytes > 0 {
+		percentBytes = float64(processedBytes) / float64(totalBytes) * 100.0
+	}
+
 	// Durchsatz berechnen (Zeilen/Sekunde)
 	var rowsPerSecond float64
 	if elapsed.Seconds() > 0 {
@@ -195,11 +244,16 @@ func (p *ProgressTracker) GetProgressDetailed() Progress {
 
 	// ETA berechnen (basierend auf Zeilen, falls verfügbar, sonst Dateien)
 	var eta time.Duration
-	if totalRows > 0 && insertedRows > 0 && rowsPerSecond > 0 {
+	if totalRows > insertedRows && insertedRows > 0 && rowsPerSecond > 0 {
 		// ETA basierend auf Zeilen
 		remainingRows := totalRows - insertedRows
 		etaSeconds := float64(remainingRows) / rowsPerSecond
 		eta = time.Duration(etaSeconds * float64(time.Second))
+	} else if totalBytes > 0 && processedBytes > 0 && elapsed.Seconds() > 0 {
+		// Fallback: ETA basierend auf Bytes
+		bytesPerSecond := float64(processedBytes) / elapsed.Seconds()
+		etaSeconds := float64(totalBytes-processedBytes) / bytesPerSecond
+		eta = time.Duration(etaSeconds * float64(time.Second))
 	} else if p.totalFiles > 0 && parsedFiles > 0 && elapsed.Seconds() > 0 {
 		// Fallback: ETA basierend auf Dateien
 		remainingFiles := p.totalFiles - parsedFiles
@@ -213,17 +267,23 @@ func (p *ProgressTracker) GetProgressDetailed() Progress {
 	insertedFiles := parsedFiles - failedFiles
 
 	return Progress{
-		ParsedFiles:   parsedFiles,
-		InsertedFiles: insertedFiles,
-		FailedFiles:   failedFiles,
-		TotalFiles:    p.totalFiles,
-		TotalRows:     totalRows,
-		InsertedRows:  insertedRows,
-		PercentFiles:  percentFiles,
-		PercentRows:   percentRows,
-		ETA:           eta,
-		ElapsedTime:   elapsed,
-		RowsPerSecond: rowsPerSecond,
+		ParsedFiles:    parsedFiles,
+		InsertedFiles:  insertedFiles,
+		FailedFiles:    failedFiles,
+		TotalFiles:     p.totalFiles,
+		TotalRows:      totalRows,
+		InsertedRows:   insertedRows,
+		ParsedRows:     p.parsedRows.Load(),
+		TotalBytes:     totalBytes,
+		ProcessedBytes: processedBytes,
+		ParsedBytes:    p.parsedBytes.Load(),
+		SkippedLines:   p.skippedLines.Load(),
+		PercentFiles:   percentFiles,
+		PercentRows:    percentRows,
+		PercentBytes:   percentBytes,
+		ETA:            eta,
+		ElapsedTime:    elapsed,
+		RowsPerSecond:  rowsPerSecond,
 	}
 }
 
@@ -245,9 +305,125 @@ func (p *ProgressTracker) GetProgressDetailed() Progress {
 //	orch := worker.NewOrchestrator(db, pool, parsers)
 //	tracker, err := orch.ImportAll(ctx, sdeDir)
 type Orchestrator struct {
-	db      *sqlx.DB
-	pool    *Pool
-	parsers map[string]parser.Parser
+	db         *sqlx.DB
+	pool       *Pool
+	parsers    map[string]parser.Parser
+	writer     *DBWriter
+	postJobs   []JobExecutor
+	observers  observerList
+	chunkSize  int64
+	rowHints   map[string]int64
+	jobTimeout time.Duration
+	tables     *parser.TableFilter
+	checksums  bool
+
+	// Einstellungen je Tabelle (siehe WithTableSettings)
+	tableSettings map[string]TableSettings
+
+	// Ziel-Datenbanken (siehe WithTargets) und deren Insert-Statistik
+	targets     []ImportTarget
+	targetStats []TargetStats
+}
+
+// DefaultChunkSize ist die Standard-Chunk-Größe (32 MiB), ab der große
+// JSONL-Dateien in mehrere parallel geparste Chunks aufgeteilt werden.
+const DefaultChunkSize int64 = 32 << 20
+
+// OrchestratorOption ist eine funktionale Option zur Konfiguration des Orchestrators.
+type OrchestratorOption func(*Orchestrator)
+
+// WithChunkSize setzt die Chunk-Größe in Bytes für das parallele Parsen großer Dateien.
+//
+// Dateien, die größer als size sind und deren Parser parser.RangeParser
+// implementiert, werden an Zeilengrenzen in Chunks geteilt. Jeder Chunk wird
+// als eigener Job im Worker Pool geparst; die Ergebnisse werden anschließend
+// in Datei-Reihenfolge wieder zusammengesetzt.
+//
+// size <= 0 deaktiviert das Chunking.
+func WithChunkSize(size int64) OrchestratorOption {
+	return func(o *Orchestrator) {
+		o.chunkSize = size
+	}
+}
+
+// WithRowCountHints setzt historische Zeilenzahlen je Tabelle (z.B. aus dem
+// letzten Eintrag in _import_runs) für die Job-Priorisierung.
+//
+// Ohne Hints werden Parse-Jobs nach Dateigröße priorisiert (größte zuerst).
+// Mit Hints wird die Priorität in erwarteten Zeilen ausgedrückt; Dateien ohne
+// Hint werden über die durchschnittliche Zeilengröße der übrigen Dateien
+// geschätzt.
+func WithRowCountHints(hints map[string]int64) OrchestratorOption {
+	return func(o *Orchestrator) {
+		o.rowHints = hints
+	}
+}
+
+// WithJobTimeout begrenzt die Laufzeit jedes Parse-Jobs.
+//
+// Ein Job, der das Timeout überschreitet, wird als fehlgeschlagen gewertet
+// (Retryable AppError), statt den Import unbegrenzt zu blockieren.
+// timeout <= 0 deaktiviert das Timeout.
+func WithJobTimeout(timeout time.Duration) OrchestratorOption {
+	return func(o *Orchestrator) {
+		o.jobTimeout = timeout
+	}
+}
+
+// WithChecksums lässt den Vorab-Scan zusätzlich die SHA-256-Checksumme jeder
+// Datei berechnen (FileScan.SHA256, gemeldet über OnScanComplete).
+//
+// Die Checksummen entstehen im selben Lesedurchgang wie die Zeilenzählung;
+// die Dateien werden dafür nicht erneut gelesen.
+func WithChecksums() OrchestratorOption {
+	return func(o *Orchestrator) {
+		o.checksums = true
+	}
+}
+
+// WithWriter setzt den gemeinsamen Single-Writer-Zugang für Phase 2.
+//
+// Ohne diese Option erstellt NewOrchestrator einen eigenen DBWriter für db.
+// Ein gemeinsamer Writer ist nötig, wenn eigene Jobs (siehe WithPostJobs)
+// parallel zum Import schreiben.
+func WithWriter(w *DBWriter) OrchestratorOption {
+	return func(o *Orchestrator) {
+		o.writer = w
+	}
+}
+
+// WithPostJobs hängt eigene Jobs an, die nach erfolgreichem Insert aller
+// Dateien in der angegebenen Reihenfolge ausgeführt werden (z.B. Indizes
+// erstellen, abgeleitete Tabellen befüllen).
+//
+// Schlägt ein Post-Job fehl, bricht ImportAll mit dessen Fehler ab.
+//
+// Beispiel:
+//
+//	writer := worker.NewDBWriter(db)
+//	analyze := worker.JobFunc(func(ctx context.Context) (worker.JobResult, error) {
+//	    return nil, writer.Do(ctx, func(db *sqlx.DB) error {
+//	        _, err := db.ExecContext(ctx, "ANALYZE")
+//	        return err
+//	    })
+//	})
+//	orch := worker.NewOrchestrator(db, pool, parsers,
+//	    worker.WithWriter(writer), worker.WithPostJobs(analyze))
+func WithPostJobs(jobs ...JobExecutor) OrchestratorOption {
+	return func(o *Orchestrator) {
+		o.postJobs = append(o.postJobs, jobs...)
+	}
+}
+
+// WithTableFilter beschränkt den Import auf die vom Filter ausgewählten
+// Tabellen (siehe parser.NewTableFilter). Dateien ausgeschlossener Tabellen
+// werden weder geparst noch importiert und in ImportPlan.Skipped gemeldet.
+//
+// Ein nil-Filter wählt alle Tabellen aus.
+func WithTableFilter(filter *parser.TableFilter) OrchestratorOption {
+	return func(o *Orchestrator) {
+		o.tables = filter
+	}
 }
 
 // NewOrchestrator erstellt einen neuen Orchestrator.
@@ -256,15 +432,24 @@ type Orchestrator struct {
 //   - db: SQLite-Datenbankverbindung (für Phase 2: Insert)
 //   - pool: Worker Pool (für Phase 1: Parsing)
 //   - parsers: Map von Parser-Name zu Parser-Implementierung
+//   - opts: Optionale Konfiguration (z.B. WithChunkSize)
 //
 // Der Pool sollte bereits mit Start(ctx) gestartet sein, bevor ImportAll()
 // aufgerufen wird.
-func NewOrchestrator(db *sqlx.DB, pool *Pool, parsers map[string]parser.Parser) *Orchestrator {
-	return &Orchestrator{
-		db:      db,
-		pool:    pool,
-		parsers: parsers,
+func NewOrchestrator(db *sqlx.DB, pool *Pool, parsers map[string]parser.Parser, opts ...OrchestratorOption) *Orchestrator {
+	o := &Orchestrator{
+		db:        db,
+		pool:      pool,
+		parsers:   parsers,
+		chunkSize: DefaultChunkSize,
+	}
+	for _, opt := range opts {
+		opt(o)
 	}
+	if o.writer == nil {
+		o.writer = NewDBWriter(db)
+	}
+	return o
 }
 
 // ParseTask repräsentiert eine Parse-Aufgabe mit Dateinamen.
@@ -274,6 +459,26 @@ func NewOrchestrator(db *sqlx.DB, pool *Pool, parsers map[string]parser.Parser)
 type ParseTask struct {
 	File   string        // Pfad zur JSONL-Datei
 	Parser parser.Parser // Zugeordneter Parser
+	Rule   MatchRule     // Regel, über die Datei und Parser zugeordnet wurden
+}
+
+// MatchRule beschreibt, über welche Regel Discover eine Datei einem Parser
+// zugeordnet hat.
+type MatchRule string
+
+const (
+	// MatchFullPath: Parser-Key entspricht dem vollständigen Dateipfad
+	MatchFullPath MatchRule = "full_path"
+	// MatchBaseName: Parser-Key entspricht dem Dateinamen ohne Endung (Regelfall)
+	MatchBaseName MatchRule = "base_name"
+	// MatchSuffixStripped: Parser-Key entspricht dem Dateinamen ohne "_"-Suffix
+	// (invTypes_1 → invTypes); Fallback, der auch unerwartete Dateien zuordnen kann
+	MatchSuffixStripped MatchRule = "suffix_stripped"
+)
+
+// IsFallback meldet, ob die Zuordnung über eine Fallback-Regel erfolgte.
+func (r MatchRule) IsFallback() bool {
+	return r == MatchSuffixStripped
 }
 
 // ParseResultData repräsentiert das Ergebnis eines Parse-Vorgangs.
@@ -286,10 +491,15 @@ type ParseResultData struct {
 	Columns []string      // Spalten-Namen für Insert
 	Records []interface{} // Geparste Records
 	Err     error         // Parse-Fehler (falls aufgetreten)
+	Chunk   int           // Chunk-Index bei geteilten Dateien (0 sonst)
+	Skipped []error       // Übersprungene fehlerhafte Zeilen (siehe TableSettings.ErrorMode)
 }
 
 // ImportAll führt 2-Phase Import aus: Parse parallel → Insert sequentiell
-func (o *Orchestrator) ImportAll(ctx context.Context, sdeDir string) (*ProgressTracker, error) {
+//
+// Registrierte Observer (siehe WithObserver) erhalten die Lifecycle-Events
+// des Imports; OnImportDone wird auch bei Fehlern und Abbruch gemeldet.
+func (o *Orchestrator) ImportAll(ctx context.Context, sdeDir string) (progress *ProgressTracker, err error) {
 	// Discover JSONL files und erstelle Tasks
 	tasks, err := o.createParseTasks(sdeDir)
 	if err != nil {
@@ -301,38 +511,38 @@ func (o *Orchestrator) ImportAll(ctx context.Context, sdeDir string) (*ProgressT
 	}
 
 	// Progress Tracker initialisieren
-	progress := NewProgressTracker(len(tasks))
+	progress = NewProgressTracker(len(tasks))
 
-	// === Phase 1: Parallel Parsing ===
-	// Worker Pool starten
-	o.pool.Start(ctx)
-
-	// Parse-Jobs submiten
-	for _, task := range tasks {
-		t := task // Capture loop variable
-		job := Job{
-			ID: t.File,
-			Fn: func(ctx context.Context) (interface{}, error) {
-				records, err := t.Parser.ParseFile(ctx, t.File)
-				if err != nil {
-					return nil, err
-				}
-				return ParseResultData{
-					File:    t.File,
-					Table:   t.Parser.TableName(),
-					Columns: t.Parser.Columns(),
-					Records: records,
-					Err:     nil,
-				}, nil
-			},
-		}
-		o.pool.Submit(job)
+	files := make([]string, len(tasks))
+	for i, task := range tasks {
+		files[i] = task.File
+	}
+	o.observers.OnImportStart(sdeDir, files)
+	defer func() {
+		o.observers.OnImportDone(progress.GetProgressDetailed(), err)
+	}()
+
+	// Vorab-Scan: Zeilen und Bytes für zeilenbasierten Fortschritt und ETA;
+	// im selben Lesedurchgang werden die Chunk-Grenzen großer Dateien und
+	// (mit WithChecksums) die Checksummen bestimmt
+	scan, err := scanTasks(ctx, tasks, o.pool.workers, o.chunkSizeFor, o.checksums)
+	if err != nil {
+		return progress, err
 	}
+	progress.SetTotalRows(scan.TotalLines)
+	progress.SetTotalBytes(scan.TotalBytes)
+	o.observers.OnScanComplete(scan)
 
-	// Warte auf alle Parse-Jobs
-	results, _ := o.pool.Wait()
+	o.observers.OnPhaseChange(PhaseParse)
+
+	// === Phase 1: Parallel Parsing ===
+	results := o.parsePhase(ctx, tasks, &scan, func(file string, rows int, duration time.Duration) {
+		progress.AddParsedRows(int64(rows), scan.Files[file].Bytes)
+		o.observers.OnFileParsed(file, rows, duration)
+	})
 
 	// === Phase 2: Sequential Insert ===
+	o.observers.OnPhaseChange(PhaseInsert)
 	// Process results sequentially for SQLite single-writer constraint
 	for _, result := range results {
 		// Check context cancellation
@@ -343,9 +553,11 @@ func (o *Orchestrator) ImportAll(ctx context.Context, sdeDir string) (*ProgressT
 		}
 
 		progress.IncrementParsed()
+		progress.AddProcessedBytes(scan.Files[result.JobID].Bytes)
 
 		if result.Err != nil {
 			progress.IncrementFailed()
+			o.observers.OnFileFailed(result.JobID, result.Err)
 			continue // Skip failed parse results
 		}
 
@@ -353,85 +565,527 @@ func (o *Orchestrator) ImportAll(ctx context.Context, sdeDir string) (*ProgressT
 		parseResult, ok := result.Data.(ParseResultData)
 		if !ok {
 			progress.IncrementFailed()
+			o.observers.OnFileFailed(result.JobID, fmt.Errorf("unexpected parse result %T", result.Data))
 			continue
 		}
 
-		// Convert []interface{} to [][]interface{} for BatchInsert
-		rows, err := o.convertToRows(parseResult.Records, len(parseResult.Columns))
-		if err != nil {
+		// Insert über den gemeinsamen Single-Writer (bzw. in alle Ziele)
+		insertStart := time.Now()
+		rows, insertErr := o.insertResult(ctx, parseResult)
+		if insertErr != nil {
 			progress.IncrementFailed()
+			o.observers.OnFileFailed(parseResult.File, insertErr)
 			continue
 		}
 
-		// Perform batch insert
-		err = database.BatchInsert(ctx, o.db, parseResult.Table, parseResult.Columns, rows, 1000)
-		if err != nil {
-			progress.IncrementFailed()
+		// Track successful insert
+		progress.AddInsertedRows(int64(rows))
+		progress.AddSkippedLines(int64(len(parseResult.Skipped)))
+		o.observers.OnFileInserted(parseResult.File, rows, time.Since(insertStart))
+	}
+
+	// === Post-Processing: eigene Jobs in Reihenfolge ===
+	if len(o.postJobs) > 0 {
+		o.observers.OnPhaseChange(PhasePostProcess)
+	}
+	for i, job := range o.postJobs {
+		if _, err := job.Execute(ctx); err != nil {
+			return progress, fmt.Errorf("post-processing job %d failed: %w", i+1, err)
+		}
+	}
+
+	return progress, nil
+}
+
+// insertProgress erstellt den Batch-Callback, der OnInsertProgress meldet.
+func (o *Orchestrator) insertProgress(file, table string, batchSize int) func(current, total int) {
+	if len(o.observers) == 0 {
+		return nil
+	}
+	return func(current, total int) {
+		o.observers.OnInsertProgress(InsertProgress{
+			File:      file,
+			Table:     table,
+			Rows:      current,
+			TotalRows: total,
+			Batch:     (current + batchSize - 1) / batchSize,
+			Batches:   (total + batchSize - 1) / batchSize,
+		})
+	}
+}
+
+// parsePhase führt Phase 1 (paralleles Parsing) für alle Tasks aus.
+//
+// Große Dateien werden in Chunks geteilt, Jobs nach Priorität eingereicht
+// und die Chunk-Ergebnisse wieder zu einem Result pro Datei zusammengesetzt.
+// Die Chunk-Grenzen stammen aus scan (siehe scanTasks); ohne scan werden sie
+// per splitTask ermittelt. onParsed wird einmal pro erfolgreich geparster
+// Datei aufgerufen.
+func (o *Orchestrator) parsePhase(ctx context.Context, tasks []ParseTask, scan *ScanResult, onParsed func(file string, rows int, duration time.Duration)) []Result {
+	// Job-Prioritäten (größte Dateien zuerst, damit sie die Gesamtlaufzeit
+	// nicht verlängern, wenn sie als letzte starten)
+	sizes, priorities := o.taskPriorities(tasks)
+
+	// Große Dateien in Chunks teilen (Datei → Anzahl Chunks)
+	chunked := make(map[string]int)
+	var jobs []Job
+	for _, task := range tasks {
+		var chunks []parser.Chunk
+		if scan != nil {
+			chunks = scan.Files[task.File].Chunks
+		} else {
+			chunks = o.splitTask(task)
+		}
+		if len(chunks) > 1 {
+			chunked[task.File] = len(chunks)
+			timing := &parseTiming{remaining: len(chunks)}
+			for i := range chunks {
+				c := chunks[i]
+				job := o.parseJob(task, &c, timing, onParsed)
+				// Chunks erhalten ihren Anteil an der Priorität der Datei
+				if size := sizes[task.File]; size > 0 {
+					job.Priority = priorities[task.File] * c.Length / size
+				}
+				jobs = append(jobs, job)
+			}
 			continue
 		}
 
-		// Track successful insert
-		progress.AddInsertedRows(int64(len(parseResult.Records)))
+		job := o.parseJob(task, nil, &parseTiming{remaining: 1}, onParsed)
+		job.Priority = priorities[task.File]
+		jobs = append(jobs, job)
 	}
 
-	return progress, nil
+	// Parse-Jobs in Prioritäts-Reihenfolge submiten
+	for i := range jobs {
+		jobs[i].Timeout = o.jobTimeout
+	}
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].Priority > jobs[j].Priority
+	})
+	// Warte auf alle Parse-Jobs
+	results := o.runJobs(ctx, jobs)
+
+	// Chunk-Ergebnisse je Datei in Reihenfolge zusammensetzen
+	if len(chunked) > 0 {
+		results = mergeChunkResults(tasks, results, chunked)
+	}
+
+	return results
+}
+
+// runJobs führt jobs im Worker Pool aus und wartet auf alle Results.
+//
+// Ein bereits laufender (langlebiger) Pool wird per Batch wiederverwendet,
+// sonst wird der Pool für diesen Lauf gestartet und danach geschlossen.
+func (o *Orchestrator) runJobs(ctx context.Context, jobs []Job) []Result {
+	if o.pool.started.Load() {
+		results, _ := o.pool.SubmitBatch(jobs).Wait()
+		return results
+	}
+
+	o.pool.Start(ctx)
+	for _, job := range jobs {
+		o.pool.Submit(job)
+	}
+	results, _ := o.pool.Wait()
+	return results
+}
+
+// taskPriorities berechnet die Priorität jedes Tasks.
+//
+// Ohne Row-Count-Hints entspricht die Priorität der Dateigröße in Bytes. Mit
+// Hints ist sie die erwartete Zeilenzahl der Ziel-Tabelle; für Tabellen ohne
+// Hint wird sie aus der Dateigröße und der durchschnittlichen Zeilengröße der
+// Dateien mit Hint geschätzt.
+//
+// Rückgabewerte:
+//   - sizes: Dateigröße je Datei (0, wenn nicht ermittelbar)
+//   - priorities: Priorität je Datei
+func (o *Orchestrator) taskPriorities(tasks []ParseTask) (sizes, priorities map[string]int64) {
+	sizes = make(map[string]int64, len(tasks))
+	priorities = make(map[string]int64, len(tasks))
+
+	var hintedBytes, hintedRows int64
+	for _, task := range tasks {
+		if info, err := os.Stat(task.File); err == nil {
+			sizes[task.File] = info.Size()
+		}
+		if rows, ok := o.rowHints[task.Parser.TableName()]; ok && rows > 0 {
+			hintedBytes += sizes[task.File]
+			hintedRows += rows
+		}
+	}
+
+	for _, task := range tasks {
+		size := sizes[task.File]
+		switch {
+		case len(o.rowHints) == 0:
+			priorities[task.File] = size
+		case o.rowHints[task.Parser.TableName()] > 0:
+			priorities[task.File] = o.rowHints[task.Parser.TableName()]
+		case hintedRows > 0 && hintedBytes > 0:
+			priorities[task.File] = size * hintedRows / hintedBytes
+		default:
+			priorities[task.File] = size
+		}
+	}
+
+	return sizes, priorities
+}
+
+// chunkSizeFor liefert die Chunk-Größe, mit der die Datei eines Tasks geteilt
+// wird: o.chunkSize, sofern Chunking aktiv ist, der Parser parser.RangeParser
+// implementiert und für die Tabelle kein TableSettings.ErrorMode gesetzt ist,
+// sonst 0 (Datei wird als Ganzes geparst).
+func (o *Orchestrator) chunkSizeFor(task ParseTask) int64 {
+	if o.chunkSize <= 0 {
+		return 0
+	}
+	// Fehler-Modi und -Schwellen gelten pro Datei
+	if o.tableSettings[task.Parser.TableName()].ErrorMode != nil {
+		return 0
+	}
+	if _, ok := task.Parser.(parser.RangeParser); !ok {
+		return 0
+	}
+	return o.chunkSize
+}
+
+// splitTask teilt die Datei eines Tasks in Chunks, sofern chunkSizeFor eine
+// Chunk-Größe liefert und die Datei sie überschreitet. Andernfalls (oder bei
+// Fehlern) wird nil zurückgegeben und die Datei als Ganzes geparst.
+func (o *Orchestrator) splitTask(task ParseTask) []parser.Chunk {
+	size := o.chunkSizeFor(task)
+	if size <= 0 {
+		return nil
+	}
+	info, err := os.Stat(task.File)
+	if err != nil || info.Size() <= size {
+		return nil
+	}
+
+	chunks, err := parser.SplitJSONL(task.File, size)
+	if err != nil {
+		return nil
+	}
+	return chunks
+}
+
+// parseJob erstellt den Pool-Job für einen Task aus einem ParseJob.
+//
+// Ist chunk gesetzt, wird nur dieser Bereich geparst und die Job-ID per
+// chunkJobID gebildet. Auch im Fehlerfall wird ParseResultData (mit
+// Chunk-Index) zurückgegeben, damit mergeChunkResults den Fehler der Datei
+// zuordnen kann.
+//
+// timing fasst die Chunks einer Datei zusammen, damit onParsed genau
+// einmal pro Datei aufgerufen wird. Beginn, Zwischenstände und Ende des Jobs
+// werden per OnWorkerActivity mit der ID des ausführenden Workers gemeldet.
+// Nach Ablauf des Job-Timeouts (oder Abbruch) meldet ein aufgegebener
+// Versuch keine Aktivität mehr, da der Worker bereits den nächsten Job
+// unter derselben WorkerID ausführt.
+func (o *Orchestrator) parseJob(task ParseTask, chunk *parser.Chunk, timing *parseTiming, onParsed func(string, int, time.Duration)) Job {
+	settings := o.tableSettings[task.Parser.TableName()]
+	pj := &ParseJob{
+		Parser:    task.Parser,
+		FilePath:  task.File,
+		Chunk:     chunk,
+		ErrorMode: settings.ErrorMode,
+		MaxErrors: settings.MaxErrors,
+	}
+
+	id := task.File
+	if chunk != nil {
+		id = chunkJobID(task.File, chunk.Index)
+	}
+
+	return Job{
+		ID: id,
+		Fn: func(ctx context.Context) (interface{}, error) {
+			started := time.Now()
+			activity := WorkerActivity{File: task.File, Chunk: -1, Started: started}
+			activity.WorkerID, _ = WorkerID(ctx)
+			if chunk != nil {
+				activity.Chunk = chunk.Index
+			}
+			report := func(a WorkerActivity) {
+				if ctx.Err() == nil {
+					o.observers.OnWorkerActivity(a)
+				}
+			}
+			if len(o.observers) > 0 {
+				report(activity)
+				ctx = parser.WithProgress(ctx, func(records int) {
+					current := activity
+					current.Rows = records
+					report(current)
+				})
+			}
+
+			res, err := pj.Execute(ctx)
+			pr, err := parseResultOf(res, err, task, chunk)
+			activity.Rows, activity.Done, activity.Err = len(pr.Items), true, err
+			report(activity)
+			if rows, duration, ok := timing.done(started, len(pr.Items), err); ok {
+				onParsed(task.File, rows, duration)
+			}
+			if err != nil && chunk == nil {
+				return nil, err
+			}
+			return ParseResultData{
+				File:    pr.File,
+				Table:   pr.Table,
+				Columns: pr.Columns,
+				Records: pr.Items,
+				Err:     err,
+				Chunk:   pr.Chunk,
+				Skipped: pr.Skipped,
+			}, err
+		},
+	}
+}
+
+// parseResultOf liefert das ParseResult eines ParseJob-Ergebnisses.
+//
+// Ist res kein ParseResult (z.B. nil auf einem Fehlerpfad), gilt die Datei
+// als fehlgeschlagen: geliefert werden ein leeres Ergebnis für task bzw.
+// chunk und der Fehler des Jobs oder, ohne Fehler, ein Fehler über den
+// unerwarteten Ergebnistyp.
+func parseResultOf(res JobResult, err error, task ParseTask, chunk *parser.Chunk) (ParseResult, error) {
+	if pr, ok := res.(ParseResult); ok {
+		return pr, err
+	}
+	pr := ParseResult{File: task.File, Table: task.Parser.TableName(), Columns: task.Parser.Columns()}
+	if chunk != nil {
+		pr.Chunk = chunk.Index
+	}
+	if err == nil {
+		err = fmt.Errorf("parse job for %s returned unexpected result %T", task.File, res)
+	}
+	return pr, err
+}
+
+// mergeChunkResults fasst die Chunk-Ergebnisse geteilter Dateien zu einem
+// Ergebnis pro Datei zusammen.
+//
+// Records werden in Chunk-Reihenfolge (= Datei-Reihenfolge) zusammengesetzt.
+// Schlägt ein Chunk fehl, gilt die ganze Datei als fehlgeschlagen; gemeldet
+// wird der Fehler des ersten fehlerhaften Chunks, damit die Zeilennummer
+// deterministisch ist. Ergebnisse ungeteilter Dateien bleiben unverändert.
+func mergeChunkResults(tasks []ParseTask, results []Result, chunked map[string]int) []Result {
+	parts := make(map[string][]*ParseResultData, len(chunked))
+	for file, n := range chunked {
+		parts[file] = make([]*ParseResultData, n)
+	}
+
+	merged := make([]Result, 0, len(tasks))
+	for _, result := range results {
+		data, ok := result.Data.(ParseResultData)
+		if !ok {
+			// Panics und Timeouts liefern keine Daten: Chunk über die Job-ID zuordnen
+			if file, index, isChunk := parseChunkJobID(result.JobID); isChunk {
+				if slots, found := parts[file]; found && index < len(slots) && result.Err != nil {
+					slots[index] = &ParseResultData{File: file, Chunk: index, Err: result.Err}
+					continue
+				}
+			}
+			merged = append(merged, result)
+			continue
+		}
+		slots, isChunked := parts[data.File]
+		if !isChunked {
+			merged = append(merged, result)
+			continue
+		}
+		if data.Chunk >= 0 && data.Chunk < len(slots) {
+			d := data
+			slots[data.Chunk] = &d
+		}
+	}
+
+	for _, task := range tasks {
+		slots, ok := parts[task.File]
+		if !ok {
+			continue
+		}
+
+		combined := ParseResultData{
+			File:    task.File,
+			Table:   task.Parser.TableName(),
+			Columns: task.Parser.Columns(),
+		}
+		for i, part := range slots {
+			if part == nil {
+				combined.Err = fmt.Errorf("%s: chunk %d produced no result", task.File, i)
+				break
+			}
+			if part.Err != nil {
+				combined.Err = part.Err
+				break
+			}
+			combined.Records = append(combined.Records, part.Records...)
+			combined.Skipped = append(combined.Skipped, part.Skipped...)
+		}
+		if combined.Err != nil {
+			combined.Records = nil
+		}
+
+		merged = append(merged, Result{JobID: task.File, Data: combined, Err: combined.Err})
+	}
+
+	return merged
+}
+
+// chunkJobID erstellt die Job-ID eines Chunks ("<datei>#<index>").
+func chunkJobID(file string, index int) string {
+	return fmt.Sprintf("%s#%d", file, index)
+}
+
+// parseChunkJobID zerlegt eine mit chunkJobID erstellte Job-ID.
+func parseChunkJobID(id string) (file string, index int, ok bool) {
+	pos := strings.LastIndex(id, "#")
+	if pos < 0 {
+		return "", 0, false
+	}
+	index, err := strconv.Atoi(id[pos+1:])
+	if err != nil || index < 0 {
+		return "", 0, false
+	}
+	return id[:pos], index, true
+}
+
+// ImportPlan ist das Ergebnis der Discovery: zugeordnete Parse-Tasks,
+// per Tabellenfilter übersprungene Dateien und JSONL-Dateien, für die kein
+// Parser registriert ist.
+type ImportPlan struct {
+	Tasks     []ParseTask    // Dateien mit passendem Parser
+	Skipped   []SkippedTable // Durch WithTableFilter ausgeschlossene Dateien
+	Unmatched []string       // JSONL-Dateien ohne passenden Parser
+	Fallbacks []FileMatch    // Über eine Fallback-Regel zugeordnete Dateien
+}
+
+// FileMatch beschreibt die Zuordnung einer Datei zu einem Parser.
+type FileMatch struct {
+	File      string    // Quelldatei
+	ParserKey string    // Key des Parsers in der Parser-Map
+	Table     string    // Ziel-Tabelle des Parsers
+	Rule      MatchRule // Verwendete Zuordnungsregel
+}
+
+// HasUnknownFiles meldet, ob Dateien ohne Parser oder über eine
+// Fallback-Regel zugeordnete Dateien gefunden wurden.
+func (p *ImportPlan) HasUnknownFiles() bool {
+	return len(p.Unmatched) > 0 || len(p.Fallbacks) > 0
+}
+
+// SkippedTable beschreibt eine Datei, die wegen des Tabellenfilters nicht
+// importiert wird.
+type SkippedTable struct {
+	Table string // Ziel-Tabelle des Parsers
+	File  string // Quelldatei
+}
+
+// SkippedTables gibt die sortierten, eindeutigen Namen der übersprungenen Tabellen zurück.
+func (p *ImportPlan) SkippedTables() []string {
+	return skippedTableNames(p.Skipped)
+}
+
+// skippedTableNames extrahiert die sortierten, eindeutigen Tabellennamen.
+func skippedTableNames(skipped []SkippedTable) []string {
+	seen := make(map[string]bool, len(skipped))
+	var tables []string
+	for _, s := range skipped {
+		if !seen[s.Table] {
+			seen[s.Table] = true
+			tables = append(tables, s.Table)
+		}
+	}
+	sort.Strings(tables)
+	return tables
 }
 
 // createParseTasks erstellt Parse-Tasks für alle JSONL-Dateien im SDE-Verzeichnis
 func (o *Orchestrator) createParseTasks(sdeDir string) ([]ParseTask, error) {
+	plan, err := o.Discover(sdeDir)
+	if err != nil {
+		return nil, err
+	}
+	return plan.Tasks, nil
+}
+
+// Discover ordnet alle JSONL-Dateien im SDE-Verzeichnis den registrierten
+// Parsern zu, ohne sie zu lesen.
+//
+// Zuordnung (in dieser Reihenfolge, siehe MatchRule): vollständiger Pfad,
+// Dateiname ohne Endung, Dateiname ohne "_"-Suffix (invTypes_1 → invTypes).
+// Dateien ohne passenden Parser werden in ImportPlan.Unmatched gemeldet,
+// über den Suffix-Fallback zugeordnete Dateien zusätzlich in
+// ImportPlan.Fallbacks, Dateien vom Tabellenfilter ausgeschlossener Tabellen
+// in ImportPlan.Skipped.
+func (o *Orchestrator) Discover(sdeDir string) (*ImportPlan, error) {
 	files, err := DiscoverJSONLFiles(sdeDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to discover JSONL files: %w", err)
 	}
 
-	var tasks []ParseTask
-
-	// Match discovered files with registered parsers
+	plan := &ImportPlan{}
 	for _, file := range files {
-		// Extract base filename without extension for parser matching
-		baseName := filepath.Base(file)
-		baseNameNoExt := strings.TrimSuffix(baseName, ".jsonl")
-
-		// Try exact match first (for full path keys - test compatibility)
-		if p, ok := o.parsers[file]; ok {
-			tasks = append(tasks, ParseTask{
-				File:   file,
-				Parser: p,
-			})
+		key, rule, ok := o.matchParser(file)
+		if !ok {
+			plan.Unmatched = append(plan.Unmatched, file)
 			continue
 		}
 
-		// Try base name without extension (for standard naming)
-		if p, ok := o.parsers[baseNameNoExt]; ok {
-			tasks = append(tasks, ParseTask{
-				File:   file,
-				Parser: p,
-			})
+		task := ParseTask{File: file, Parser: o.parsers[key], Rule: rule}
+		table := task.Parser.TableName()
+		if rule.IsFallback() {
+			plan.Fallbacks = append(plan.Fallbacks, FileMatch{File: file, ParserKey: key, Table: table, Rule: rule})
+		}
+		if !o.selectsTable(table) {
+			plan.Skipped = append(plan.Skipped, SkippedTable{Table: table, File: file})
 			continue
 		}
+		plan.Tasks = append(plan.Tasks, task)
+	}
 
-		// Try with suffix stripped (e.g., invTypes_1 -> invTypes)
-		// This handles test data like invTypes_1.jsonl, invTypes_2.jsonl
-		if idx := strings.LastIndex(baseNameNoExt, "_"); idx > 0 {
-			prefix := baseNameNoExt[:idx]
-			if p, ok := o.parsers[prefix]; ok {
-				tasks = append(tasks, ParseTask{
-					File:   file,
-					Parser: p,
-				})
-				continue
-			}
-		}
+	return plan, nil
+}
+
+// matchParser sucht den Parser-Key für file und die dabei verwendete Regel.
+func (o *Orchestrator) matchParser(file string) (string, MatchRule, bool) {
+	// Try exact match first (for full path keys - test compatibility)
+	if _, ok := o.parsers[file]; ok {
+		return file, MatchFullPath, true
+	}
+
+	// Try base name without extension (for standard naming)
+	baseName := strings.TrimSuffix(filepath.Base(file), ".jsonl")
+	if _, ok := o.parsers[baseName]; ok {
+		return baseName, MatchBaseName, true
+	}
 
-		// Silently skip files without matching parser
+	// Try with suffix stripped (e.g., invTypes_1 -> invTypes)
+	// This handles test data like invTypes_1.jsonl, invTypes_2.jsonl
+	if idx := strings.LastIndex(baseName, "_"); idx > 0 {
+		prefix := baseName[:idx]
+		if _, ok := o.parsers[prefix]; ok {
+			return prefix, MatchSuffixStripped, true
+		}
 	}
 
-	return tasks, nil
+	return "", "", false
 }
 
 // convertToRows konvertiert []interface{} in [][]interface{} für BatchInsert
-// Es unterstützt Structs (via Reflection) und Maps (für Tests)
+// Es unterstützt Structs (via Reflection) und []interface{} in Spaltenreihenfolge;
+// Maps werden abgelehnt, da sie keine Spaltenreihenfolge haben.
 func (o *Orchestrator) convertToRows(records []interface{}, columnCount int) ([][]interface{}, error) {
+	return recordsToRows(records, columnCount)
+}
+
+// recordsToRows konvertiert Parser-Records in Datenbank-Zeilen (siehe convertToRows).
+func recordsToRows(records []interface{}, columnCount int) ([][]interface{}, error) {
 	if len(records) == 0 {
 		return [][]interface{}{}, nil
 	}
@@ -478,17 +1132,20 @@ func (o *Orchestrator) convertToRows(records []interface{}, columnCount int) ([]
 				row = append(row, fieldValue)
 			}
 
-		case reflect.Map:
-			// For map[string]interface{}, create a placeholder row
-			// Maps don't have guaranteed order, so this is primarily for testing
-			row = make([]interface{}, columnCount)
-			// Fill with nil values - real implementation would need column order
-			for j := 0; j < columnCount; j++ {
-				row[j] = nil
+		case reflect.Slice:
+			// []interface{} already holds the values in column order
+			values, ok := record.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("record %d is a %T, expected []interface{}", i, record)
 			}
+			row = values
+
+		case reflect.Map:
+			// Maps have no column order
+			return nil, fmt.Errorf("record %d is a map, expected a struct or []interface{} in column order", i)
 
 		default:
-			return nil, fmt.Errorf("record %d is not a struct or map, got %v", i, val.Kind())
+			return nil, fmt.Errorf("record %d is not a struct or []interface{}, got %v", i, val.Kind())
 		}
 
 		// Verify column count matches
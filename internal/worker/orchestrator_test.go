@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -211,6 +212,28 @@ func TestOrchestrator_ConvertToRows_Empty(t *testing.T) {
 	}
 }
 
+// TestRecordsToRows_SlicesAndMaps tests that []interface{} records are used in
+// column order and maps are rejected instead of becoming NULL rows
+func TestRecordsToRows_SlicesAndMaps(t *testing.T) {
+	rows, err := recordsToRows([]interface{}{[]interface{}{1, "rec1"}}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 1 || rows[0][0] != 1 || rows[0][1] != "rec1" {
+		t.Errorf("expected [[1 rec1]], got %v", rows)
+	}
+
+	if _, err := recordsToRows([]interface{}{[]interface{}{1}}, 2); err == nil {
+		t.Error("expected error for slice with wrong length")
+	}
+	if _, err := recordsToRows([]interface{}{[]string{"a", "b"}}, 2); err == nil {
+		t.Error("expected error for non-interface slice")
+	}
+	if _, err := recordsToRows([]interface{}{map[string]interface{}{"id": 1, "name": "rec1"}}, 2); err == nil {
+		t.Error("expected error for map record")
+	}
+}
+
 // TestOrchestrator_ImportAll_EmptyParsers tests import with no parsers
 func TestOrchestrator_ImportAll_EmptyParsers(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -572,14 +595,20 @@ func Example_orchestratorBasicUsage() {
 	// Setup worker pool with 4 workers
 	pool := NewPool(4)
 
+	// Records are structs with fields in column order
+	type typeRecord struct {
+		TypeID   int
+		TypeName string
+	}
+
 	// Register parsers for different file types
 	parsers := map[string]parser.Parser{
 		"types": &MockParser{
 			tableName: "types",
 			columns:   []string{"typeID", "typeName"},
 			returnItems: []interface{}{
-				map[string]interface{}{"typeID": 1, "typeName": "Tritanium"},
-				map[string]interface{}{"typeID": 2, "typeName": "Pyerite"},
+				typeRecord{TypeID: 1, TypeName: "Tritanium"},
+				typeRecord{TypeID: 2, TypeName: "Pyerite"},
 			},
 		},
 	}
@@ -783,3 +812,338 @@ func TestDiscoverJSONLFiles_FileInsteadOfDirectory(t *testing.T) {
 		t.Error("Expected error when path is a file, not a directory")
 	}
 }
+
+// chunkTestRow is a minimal record type for chunked import tests
+type chunkTestRow struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// writeChunkTestFile writes n chunkTestRow lines into dir/name
+func writeChunkTestFile(t *testing.T, dir, name string, n int) string {
+	t.Helper()
+	var content []byte
+	for i := 1; i <= n; i++ {
+		content = append(content, fmt.Sprintf("{\"id\":%d,\"name\":\"row-%d\"}\n", i, i)...)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// TestOrchestrator_ImportAll_ChunkedFile tests parallel parsing of a large file in chunks
+func TestOrchestrator_ImportAll_ChunkedFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeChunkTestFile(t, tmpDir, "rows.jsonl", 2000)
+
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	if _, err := db.Exec("CREATE TABLE rows (id INTEGER, name TEXT)"); err != nil {
+		t.Fatalf("failed to create test table: %v", err)
+	}
+
+	parsers := map[string]parser.Parser{
+		"rows": parser.NewJSONLParser[chunkTestRow]("rows", []string{"id", "name"}),
+	}
+	orch := NewOrchestrator(db, NewPool(4), parsers, WithChunkSize(4096))
+
+	progress, err := orch.ImportAll(context.Background(), tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parsed, _, failed, total := progress.GetProgress()
+	if parsed != 1 || failed != 0 || total != 1 {
+		t.Errorf("expected 1 parsed file without failures, got parsed=%d failed=%d total=%d", parsed, failed, total)
+	}
+
+	// Parse-phase progress is counted separately from inserted rows
+	detailed := progress.GetProgressDetailed()
+	if detailed.ParsedRows != 2000 || detailed.ParsedBytes != detailed.TotalBytes {
+		t.Errorf("expected 2000 parsed rows and %d parsed bytes, got %d rows, %d bytes",
+			detailed.TotalBytes, detailed.ParsedRows, detailed.ParsedBytes)
+	}
+
+	// Records must be inserted in file order
+	var ids []int
+	if err := db.Select(&ids, "SELECT id FROM rows ORDER BY rowid"); err != nil {
+		t.Fatalf("failed to query rows: %v", err)
+	}
+	if len(ids) != 2000 {
+		t.Fatalf("expected 2000 rows, got %d", len(ids))
+	}
+	for i, id := range ids {
+		if id != i+1 {
+			t.Fatalf("row %d: expected id %d, got %d", i, i+1, id)
+		}
+	}
+}
+
+// TestOrchestrator_ImportAll_ChunkedFileError tests that a failing chunk fails the whole file
+func TestOrchestrator_ImportAll_ChunkedFileError(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := writeChunkTestFile(t, tmpDir, "rows.jsonl", 2000)
+
+	// Append a broken line (lands in the last chunk)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	_, _ = f.WriteString("{broken\n")
+	_ = f.Close()
+	writeChunkTestFile(t, tmpDir, "other.jsonl", 1)
+
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	if _, err := db.Exec("CREATE TABLE rows (id INTEGER, name TEXT)"); err != nil {
+		t.Fatalf("failed to create test table: %v", err)
+	}
+
+	parsers := map[string]parser.Parser{
+		"rows": parser.NewJSONLParser[chunkTestRow]("rows", []string{"id", "name"}),
+	}
+	orch := NewOrchestrator(db, NewPool(4), parsers, WithChunkSize(4096))
+
+	progress, err := orch.ImportAll(context.Background(), tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parsed, _, failed, _ := progress.GetProgress()
+	if parsed != 1 || failed != 1 {
+		t.Errorf("expected the chunked file to fail as a whole, got parsed=%d failed=%d", parsed, failed)
+	}
+
+	var count int
+	if err := db.Get(&count, "SELECT COUNT(*) FROM rows"); err != nil {
+		t.Fatalf("failed to count rows: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected no rows from a failed file, got %d", count)
+	}
+}
+
+// TestMergeChunkResults tests reassembly order and error selection
+func TestMergeChunkResults(t *testing.T) {
+	p := &MockParser{tableName: "t", columns: []string{"id"}}
+	tasks := []ParseTask{{File: "a.jsonl", Parser: p}, {File: "b.jsonl", Parser: p}}
+
+	errLine7 := errors.New("line 7: failed to parse JSON")
+	errLine9 := errors.New("line 9: failed to parse JSON")
+	results := []Result{
+		{JobID: "a.jsonl#1", Data: ParseResultData{File: "a.jsonl", Chunk: 1, Records: []interface{}{3, 4}}},
+		{JobID: "b.jsonl#1", Data: ParseResultData{File: "b.jsonl", Chunk: 1, Err: errLine9}, Err: errLine9},
+		{JobID: "a.jsonl#0", Data: ParseResultData{File: "a.jsonl", Chunk: 0, Records: []interface{}{1, 2}}},
+		{JobID: "b.jsonl#0", Data: ParseResultData{File: "b.jsonl", Chunk: 0, Err: errLine7}, Err: errLine7},
+	}
+
+	merged := mergeChunkResults(tasks, results, map[string]int{"a.jsonl": 2, "b.jsonl": 2})
+	if len(merged) != 2 {
+		t.Fatalf("expected 2 merged results, got %d", len(merged))
+	}
+
+	a := merged[0].Data.(ParseResultData)
+	if merged[0].Err != nil || fmt.Sprint(a.Records) != "[1 2 3 4]" {
+		t.Errorf("expected records [1 2 3 4] without error, got %v (err: %v)", a.Records, merged[0].Err)
+	}
+	if !errors.Is(merged[1].Err, errLine7) {
+		t.Errorf("expected error of first failing chunk, got %v", merged[1].Err)
+	}
+}
+
+// TestParseResultOf tests that unexpected job results fail the file instead of panicking
+func TestParseResultOf(t *testing.T) {
+	p := &MockParser{tableName: "t", columns: []string{"id"}}
+	task := ParseTask{File: "a.jsonl", Parser: p}
+
+	pr, err := parseResultOf(ParseResult{File: "a.jsonl", Items: []interface{}{1}}, nil, task, nil)
+	if err != nil || len(pr.Items) != 1 {
+		t.Errorf("expected ParseResult to pass through, got %+v (err: %v)", pr, err)
+	}
+
+	pr, err = parseResultOf(nil, nil, task, &parser.Chunk{Index: 2})
+	if err == nil || !strings.Contains(err.Error(), "a.jsonl") {
+		t.Errorf("expected error for nil result, got %v", err)
+	}
+	if pr.File != "a.jsonl" || pr.Table != "t" || pr.Chunk != 2 || pr.Items != nil {
+		t.Errorf("expected empty result for the task, got %+v", pr)
+	}
+
+	errParse := errors.New("line 1: failed to parse JSON")
+	if _, err := parseResultOf(InsertResult{}, errParse, task, nil); !errors.Is(err, errParse) {
+		t.Errorf("expected job error to be kept, got %v", err)
+	}
+}
+
+// TestOrchestrator_TaskPriorities tests size-based and row-count-based priorities
+func TestOrchestrator_TaskPriorities(t *testing.T) {
+	tmpDir := t.TempDir()
+	small := writeChunkTestFile(t, tmpDir, "small.jsonl", 10)
+	large := writeChunkTestFile(t, tmpDir, "large.jsonl", 1000)
+	unhinted := writeChunkTestFile(t, tmpDir, "unhinted.jsonl", 100)
+
+	tasks := []ParseTask{
+		{File: small, Parser: &MockParser{tableName: "small"}},
+		{File: large, Parser: &MockParser{tableName: "large"}},
+		{File: unhinted, Parser: &MockParser{tableName: "unhinted"}},
+	}
+
+	// Without hints: priority = file size
+	orch := NewOrchestrator(nil, NewPool(1), nil)
+	sizes, priorities := orch.taskPriorities(tasks)
+	if priorities[large] != sizes[large] || priorities[large] <= priorities[small] {
+		t.Errorf("expected size-based priorities with large first, got %v", priorities)
+	}
+
+	// With hints: historical row counts win over file size
+	orch = NewOrchestrator(nil, NewPool(1), nil, WithRowCountHints(map[string]int64{
+		"small": 50000,
+		"large": 1000,
+	}))
+	_, priorities = orch.taskPriorities(tasks)
+	if priorities[small] != 50000 || priorities[large] != 1000 {
+		t.Errorf("expected hinted priorities, got %v", priorities)
+	}
+	if priorities[unhinted] <= 0 {
+		t.Errorf("expected estimated priority for file without hint, got %d", priorities[unhinted])
+	}
+}
+
+// TestOrchestrator_ImportAll_ParserPanic tests that a panicking parser only fails its own file
+func TestOrchestrator_ImportAll_ParserPanic(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeChunkTestFile(t, tmpDir, "rows.jsonl", 10)
+	writeChunkTestFile(t, tmpDir, "bad.jsonl", 1)
+
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	if _, err := db.Exec("CREATE TABLE rows (id INTEGER, name TEXT)"); err != nil {
+		t.Fatalf("failed to create test table: %v", err)
+	}
+
+	parsers := map[string]parser.Parser{
+		"rows": parser.NewJSONLParser[chunkTestRow]("rows", []string{"id", "name"}),
+		"bad": &MockParser{
+			tableName: "bad",
+			parseFunc: func(ctx context.Context, path string) ([]interface{}, error) {
+				panic("parser bug")
+			},
+		},
+	}
+	orch := NewOrchestrator(db, NewPool(2), parsers)
+
+	progress, err := orch.ImportAll(context.Background(), tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parsed, _, failed, _ := progress.GetProgress()
+	if parsed != 2 || failed != 1 {
+		t.Errorf("expected 2 parsed files with 1 failure, got parsed=%d failed=%d", parsed, failed)
+	}
+	if rows := progress.GetProgressDetailed().InsertedRows; rows != 10 {
+		t.Errorf("expected 10 inserted rows from the healthy file, got %d", rows)
+	}
+}
+
+// TestOrchestrator_ImportAll_ReusesRunningPool tests repeated imports on a long-lived pool
+func TestOrchestrator_ImportAll_ReusesRunningPool(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeChunkTestFile(t, tmpDir, "rows.jsonl", 200)
+
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	if _, err := db.Exec("CREATE TABLE rows (id INTEGER, name TEXT)"); err != nil {
+		t.Fatalf("failed to create test table: %v", err)
+	}
+
+	pool := NewPool(2)
+	pool.Start(context.Background())
+	defer func() { _ = pool.Shutdown(context.Background()) }()
+
+	parsers := map[string]parser.Parser{
+		"rows": parser.NewJSONLParser[chunkTestRow]("rows", []string{"id", "name"}),
+	}
+	orch := NewOrchestrator(db, pool, parsers, WithChunkSize(1024))
+
+	for i := 0; i < 2; i++ {
+		if _, err := orch.ImportAll(context.Background(), tmpDir); err != nil {
+			t.Fatalf("import %d: unexpected error: %v", i+1, err)
+		}
+	}
+
+	var count int
+	if err := db.Get(&count, "SELECT COUNT(*) FROM rows"); err != nil {
+		t.Fatalf("failed to count rows: %v", err)
+	}
+	if count != 400 {
+		t.Errorf("expected 400 rows after two imports, got %d", count)
+	}
+}
+
+// TestOrchestrator_ImportAll_PostJobs tests that custom jobs run after the insert phase
+func TestOrchestrator_ImportAll_PostJobs(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeChunkTestFile(t, tmpDir, "rows.jsonl", 10)
+
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	if _, err := db.Exec("CREATE TABLE rows (id INTEGER, name TEXT)"); err != nil {
+		t.Fatalf("failed to create test table: %v", err)
+	}
+
+	writer := NewDBWriter(db)
+	var rowCount int
+	var order []string
+	countRows := JobFunc(func(ctx context.Context) (JobResult, error) {
+		order = append(order, "count")
+		return nil, db.GetContext(ctx, &rowCount, "SELECT COUNT(*) FROM rows")
+	})
+	fail := JobFunc(func(ctx context.Context) (JobResult, error) {
+		order = append(order, "fail")
+		return nil, errors.New("post-processing failed")
+	})
+	never := JobFunc(func(ctx context.Context) (JobResult, error) {
+		order = append(order, "never")
+		return nil, nil
+	})
+
+	parsers := map[string]parser.Parser{
+		"rows": parser.NewJSONLParser[chunkTestRow]("rows", []string{"id", "name"}),
+	}
+	orch := NewOrchestrator(db, NewPool(2), parsers,
+		WithWriter(writer), WithPostJobs(countRows, fail, never))
+
+	_, err = orch.ImportAll(context.Background(), tmpDir)
+	if err == nil || !strings.Contains(err.Error(), "post-processing job 2 failed") {
+		t.Fatalf("expected post-processing error, got %v", err)
+	}
+	if rowCount != 10 {
+		t.Errorf("expected post job to see 10 inserted rows, got %d", rowCount)
+	}
+	if len(order) != 2 || order[0] != "count" || order[1] != "fail" {
+		t.Errorf("expected post jobs [count fail], got %v", order)
+	}
+}
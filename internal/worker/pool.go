@@ -3,6 +3,10 @@ package worker
 import (
 	"context"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/retry"
 )
 
 // Job repräsentiert eine zu verarbeitende Aufgabe im Worker Pool.
@@ -11,17 +15,40 @@ import (
 // Die Funktion erhält einen Context für Cancellation und gibt ein Ergebnis
 // sowie einen optionalen Fehler zurück.
 //
+// Priority steuert die Ausführungsreihenfolge: Jobs mit höherer Priorität
+// werden zuerst an freie Worker vergeben, bei gleicher Priorität gilt die
+// Submit-Reihenfolge. Der Orchestrator verwendet z.B. die Dateigröße, damit
+// die größten Dateien zuerst starten und die Gesamtlaufzeit nicht verlängern.
+//
+// Optional begrenzt Timeout die Laufzeit eines Versuchs und Retry wiederholt
+// Versuche mit Retryable-Fehlern (inkl. Timeouts). Panics in Fn werden
+// abgefangen und als Fatal AppError (Cause: *PanicError mit Stack Trace)
+// im Result gemeldet, statt den Prozess zu beenden.
+//
+// Ein Versuch, der nach Ablauf von Timeout nicht auf die Context-Cancellation
+// reagiert, wird aufgegeben und läuft im Hintergrund zu Ende, während der
+// Worker bereits den nächsten Job ausführt. Mit Timeout können daher mehr
+// Jobs gleichzeitig laufen (und Speicher belegen), als der Pool Worker hat.
+//
 // Beispiel:
 //
 //	job := worker.Job{
-//	    ID: "parse-types",
+//	    ID:       "parse-types",
+//	    Priority: fileSize,
+//	    Timeout:  5 * time.Minute,
+//	    Retry:    retry.DefaultPolicy(),
 //	    Fn: func(ctx context.Context) (interface{}, error) {
 //	        return parseFile(ctx, "types.jsonl")
 //	    },
 //	}
 type Job struct {
-	ID string                                     // Eindeutige Job-Identifikation
-	Fn func(context.Context) (interface{}, error) // Auszuführende Funktion
+	ID       string                                     // Eindeutige Job-Identifikation
+	Fn       func(context.Context) (interface{}, error) // Auszuführende Funktion
+	Priority int64                                      // Priorität (höher = früher, Standard 0)
+	Timeout  time.Duration                              // Maximale Laufzeit pro Versuch (0 = unbegrenzt; aufgegebene Versuche laufen weiter, siehe Job)
+	Retry    *retry.Policy                              // Retry-Policy für Retryable-Fehler (nil = kein Retry)
+
+	batch *Batch // Ziel-Batch (nur bei SubmitBatch)
 }
 
 // Result repräsentiert das Ergebnis einer Job-Ausführung.
@@ -38,11 +65,17 @@ type Result struct {
 // Pool ist ein Worker Pool für parallele Job-Verarbeitung.
 //
 // Der Pool verwaltet eine konfigurierbare Anzahl von Worker-Goroutines,
-// die Jobs aus einer Warteschlange verarbeiten. Buffered Channels sorgen
-// für Backpressure und vermeiden Blockierung.
+// die Jobs aus einer zentralen Prioritäts-Warteschlange verarbeiten. Ein
+// Dispatcher übergibt jeweils den Job mit der höchsten Priorität an den
+// nächsten freien Worker, sodass kein Worker untätig ist, solange Jobs warten.
+//
+// Mit Stats() lässt sich die Auslastung je Worker abfragen (z.B. zum
+// Tuning der Worker-Anzahl).
 //
-// Ein Pool wird mit NewPool() erstellt, mit Start() gestartet und mit
-// Wait() auf Completion gewartet.
+// Ein Pool kann auf zwei Arten verwendet werden:
+//   - Einmalig: NewPool() → Start() → Submit() → Wait(). Wait() schließt den Pool.
+//   - Langlebig: NewPool() → Start() → beliebig viele SubmitBatch() → Shutdown().
+//     Ergebnisse werden pro Batch als Stream geliefert (siehe Batch).
 //
 // Beispiel:
 //
@@ -52,9 +85,42 @@ type Result struct {
 //	results, errors := pool.Wait()
 type Pool struct {
 	workers int
+	queue   *jobQueue
 	jobs    chan Job
 	results chan Result
 	wg      sync.WaitGroup
+
+	// dispatched wird geschlossen, wenn der Dispatcher beendet ist
+	dispatched chan struct{}
+
+	started  atomic.Bool
+	cancel   context.CancelFunc // beendet Dispatcher und Worker (erzwungener Shutdown)
+	counters []workerCounters
+	mu       sync.Mutex // schützt start/stop
+	start    time.Time
+	stop     time.Time
+}
+
+// workerCounters enthält die Laufzeit-Zähler eines Workers.
+type workerCounters struct {
+	jobs atomic.Int64
+	busy atomic.Int64 // Nanosekunden
+}
+
+// WorkerStats beschreibt die Auslastung eines einzelnen Workers.
+type WorkerStats struct {
+	ID          int           // Worker-Index (0-basiert)
+	Jobs        int64         // Anzahl ausgeführter Jobs
+	Busy        time.Duration // Summe der Job-Laufzeiten
+	Utilization float64       // Busy / Elapsed (0.0 - 1.0)
+}
+
+// PoolStats beschreibt die Auslastung des gesamten Pools.
+type PoolStats struct {
+	Workers     []WorkerStats // Statistiken je Worker
+	Elapsed     time.Duration // Zeit seit Start() (bis Wait() abgeschlossen ist)
+	Queued      int           // Anzahl noch wartender Jobs
+	Utilization float64       // Durchschnittliche Auslastung aller Worker (0.0 - 1.0)
 }
 
 // NewPool erstellt einen neuen Worker Pool mit der angegebenen Anzahl von Workers.
@@ -74,9 +140,13 @@ func NewPool(workers int) *Pool {
 	}
 
 	return &Pool{
-		workers: workers,
-		jobs:    make(chan Job, workers*2),
-		results: make(chan Result, 100), // Large buffer to prevent blocking
+		workers:  workers,
+		queue:    newJobQueue(),
+		jobs:     make(chan Job),         // Ungepuffert: Übergabe erst, wenn ein Worker frei ist
+		results:  make(chan Result, 100), // Large buffer to prevent blocking
+		counters: make([]workerCounters, workers),
+
+		dispatched: make(chan struct{}),
 	}
 }
 
@@ -93,15 +163,66 @@ func NewPool(workers int) *Pool {
 //	defer cancel()
 //	pool.Start(ctx)
 func (p *Pool) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	p.mu.Lock()
+	p.start = time.Now()
+	p.cancel = cancel
+	p.mu.Unlock()
+	p.started.Store(true)
+
+	// Dispatcher zählt zur WaitGroup, damit Shutdown() erst danach die
+	// Warteschlange leert
+	p.wg.Add(1)
+	go p.dispatch(ctx)
+
 	for i := 0; i < p.workers; i++ {
 		p.wg.Add(1)
 		go p.worker(ctx, i)
 	}
 }
 
+// dispatch übergibt Jobs in Prioritäts-Reihenfolge an freie Worker.
+//
+// Wartet der Dispatcher auf einen freien Worker und trifft währenddessen ein
+// neuer Job ein, wird der gehaltene Job zurückgelegt und neu ausgewählt, damit
+// ein später eingereichter Job mit höherer Priorität nicht warten muss.
+// Nach Wait() und leerer Warteschlange wird der Job-Channel geschlossen.
+func (p *Pool) dispatch(ctx context.Context) {
+	defer p.wg.Done()
+	defer close(p.dispatched)
+	defer close(p.jobs)
+
+	for {
+		item, ok, closed := p.queue.pop()
+		if closed {
+			return
+		}
+		if !ok {
+			select {
+			case <-p.queue.notify:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case p.jobs <- item.job:
+		case <-p.queue.notify:
+			p.queue.requeue(item)
+		case <-ctx.Done():
+			// Job zurücklegen, damit Shutdown() ihn dem Batch melden kann
+			p.queue.requeue(item)
+			return
+		}
+	}
+}
+
 // worker verarbeitet Jobs aus dem Channel
 func (p *Pool) worker(ctx context.Context, id int) {
 	defer p.wg.Done()
+	jobCtx := withWorkerID(ctx, id)
 
 	for {
 		select {
@@ -115,12 +236,23 @@ func (p *Pool) worker(ctx context.Context, id int) {
 				return
 			}
 
-			// Job ausführen
-			data, err := job.Fn(ctx)
+			// Job ausführen (mit Laufzeit-Messung für Stats)
+			started := time.Now()
+			data, err := executeJob(jobCtx, job)
+			p.counters[id].jobs.Add(1)
+			p.counters[id].busy.Add(int64(time.Since(started)))
+
+			result := Result{JobID: job.ID, Data: data, Err: err}
+
+			// Batch-Jobs liefern in den Batch-Channel (blockiert nie)
+			if job.batch != nil {
+				job.batch.deliver(result)
+				continue
+			}
 
 			// Result in Channel schreiben (non-blocking bei Context-Cancel)
 			select {
-			case p.results <- Result{JobID: job.ID, Data: data, Err: err}:
+			case p.results <- result:
 			case <-ctx.Done():
 				return
 			}
@@ -130,10 +262,10 @@ func (p *Pool) worker(ctx context.Context, id int) {
 
 // Submit fügt einen Job zur Warteschlange hinzu.
 //
-// Jobs werden asynchron verarbeitet. Submit blockiert, wenn der interne
-// Job-Channel voll ist (Backpressure).
+// Jobs werden asynchron in Prioritäts-Reihenfolge (Job.Priority) verarbeitet.
+// Submit blockiert nicht; Jobs können auch vor Start() eingereicht werden.
 //
-// Submit sollte nicht nach Wait() aufgerufen werden.
+// Submit sollte nicht nach Wait() aufgerufen werden; solche Jobs werden verworfen.
 //
 // Beispiel:
 //
@@ -144,12 +276,14 @@ func (p *Pool) worker(ctx context.Context, id int) {
 //	    },
 //	})
 func (p *Pool) Submit(job Job) {
-	p.jobs <- job
+	p.queue.push(job)
 }
 
 // Wait wartet bis alle Worker fertig sind und gibt Results und Errors zurück.
 //
-// Wait schließt den Job-Channel, wartet auf alle Worker und sammelt alle Results.
+// Wait schließt die Warteschlange, wartet auf alle Worker und sammelt alle Results.
+// Results werden bereits während der Verarbeitung eingesammelt, sodass der
+// Result-Buffer die Anzahl der Jobs nicht begrenzt.
 // Fehler werden zusätzlich in einem separaten Slice zurückgegeben.
 //
 // Wait sollte nur einmal pro Pool aufgerufen werden, nachdem alle Jobs submitted wurden.
@@ -165,9 +299,25 @@ func (p *Pool) Submit(job Job) {
 //	    log.Printf("Fehler bei %d/%d Jobs", len(errors), len(results))
 //	}
 func (p *Pool) Wait() ([]Result, []error) {
-	close(p.jobs)
-	p.wg.Wait()
-	close(p.results)
+	p.queue.close()
+	if !p.started.Load() {
+		// Ohne Start() gibt es keinen Dispatcher, der den Job-Channel schließt
+		close(p.jobs)
+	}
+
+	// Results-Channel schließen, sobald alle Worker beendet sind
+	go func() {
+		p.wg.Wait()
+
+		p.mu.Lock()
+		p.stop = time.Now()
+		if p.cancel != nil {
+			p.cancel()
+		}
+		p.mu.Unlock()
+
+		close(p.results)
+	}()
 
 	var results []Result
 	var errors []error
@@ -181,3 +331,165 @@ func (p *Pool) Wait() ([]Result, []error) {
 
 	return results, errors
 }
+
+// SubmitBatch reicht eine Gruppe von Jobs ein und gibt einen Batch zurück,
+// über den die Ergebnisse als Stream abgerufen werden können.
+//
+// SubmitBatch ist für langlebige Pools gedacht: Der Pool bleibt nach dem
+// Batch aktiv und kann weitere Batches verarbeiten, bis Shutdown() aufgerufen
+// wird. Batch-Ergebnisse erscheinen nicht in Wait().
+//
+// Nach Shutdown() eingereichte Batches liefern für jeden Job ErrPoolClosed.
+//
+// Beispiel:
+//
+//	batch := pool.SubmitBatch([]worker.Job{jobA, jobB})
+//	for result := range batch.Results() {
+//	    if result.Err != nil {
+//	        log.Printf("job %s failed: %v", result.JobID, result.Err)
+//	    }
+//	}
+func (p *Pool) SubmitBatch(jobs []Job) *Batch {
+	batch := newBatch(len(jobs))
+	for _, job := range jobs {
+		job.batch = batch
+		if !p.queue.push(job) {
+			batch.deliver(Result{JobID: job.ID, Err: ErrPoolClosed})
+		}
+	}
+	return batch
+}
+
+// Shutdown beendet einen langlebigen Pool.
+//
+// Shutdown nimmt keine neuen Jobs mehr an und wartet, bis alle bereits
+// eingereichten Jobs abgearbeitet sind. Läuft ctx vorher ab, werden die Worker
+// abgebrochen (laufende Jobs erhalten einen gecancelten Context) und noch
+// wartende Batch-Jobs mit ErrPoolClosed beendet.
+//
+// Nach Ablauf von ctx wartet Shutdown nicht auf laufende Jobs: Ein Job, der
+// den Context ignoriert, läuft in seiner Goroutine weiter, bis er von selbst
+// endet, und liefert sein Ergebnis erst dann an seinen Batch. Der Channel
+// dieses Batches bleibt so lange offen.
+//
+// Rückgabewert:
+//   - error: nil bei vollständigem Abarbeiten, sonst ctx.Err()
+//
+// Beispiel:
+//
+//	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	if err := pool.Shutdown(shutdownCtx); err != nil {
+//	    log.Printf("forced shutdown: %v", err)
+//	}
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.queue.close()
+	if !p.started.Load() {
+		p.failQueued()
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+		p.mu.Lock()
+		p.cancel()
+		p.mu.Unlock()
+		// Nur auf den Dispatcher warten (endet sofort und legt einen
+		// gehaltenen Job zurück), nicht auf Worker mit laufenden Jobs
+		<-p.dispatched
+	}
+
+	p.failQueued()
+
+	p.mu.Lock()
+	if p.stop.IsZero() {
+		p.stop = time.Now()
+	}
+	p.cancel()
+	p.mu.Unlock()
+
+	return err
+}
+
+// failQueued beendet alle noch wartenden Batch-Jobs mit ErrPoolClosed.
+func (p *Pool) failQueued() {
+	for {
+		item, ok, _ := p.queue.pop()
+		if !ok {
+			return
+		}
+		if item.job.batch != nil {
+			item.job.batch.deliver(Result{JobID: item.job.ID, Err: ErrPoolClosed})
+		}
+	}
+}
+
+// Stats gibt die aktuelle Auslastung des Pools und seiner Worker zurück.
+//
+// Die Auslastung eines Workers ist der Anteil der Zeit seit Start(), in der
+// er einen Job ausgeführt hat. Nach Wait() beziehen sich die Werte auf die
+// gesamte Laufzeit des Pools. Niedrige Werte deuten darauf hin, dass weniger
+// Worker ausreichen; Werte nahe 1.0 bei allen Workern, dass mehr Worker helfen
+// könnten.
+//
+// Stats ist Thread-Safe und kann während der Verarbeitung aufgerufen werden.
+//
+// Beispiel:
+//
+//	stats := pool.Stats()
+//	for _, w := range stats.Workers {
+//	    log.Printf("worker %d: %d jobs, %.0f%% busy", w.ID, w.Jobs, w.Utilization*100)
+//	}
+func (p *Pool) Stats() PoolStats {
+	p.mu.Lock()
+	start, stop := p.start, p.stop
+	p.mu.Unlock()
+
+	var elapsed time.Duration
+	switch {
+	case start.IsZero():
+		elapsed = 0
+	case stop.IsZero():
+		elapsed = time.Since(start)
+	default:
+		elapsed = stop.Sub(start)
+	}
+
+	stats := PoolStats{
+		Workers: make([]WorkerStats, p.workers),
+		Elapsed: elapsed,
+		Queued:  p.queue.len(),
+	}
+
+	var total float64
+	for i := range p.counters {
+		busy := time.Duration(p.counters[i].busy.Load())
+		ws := WorkerStats{
+			ID:   i,
+			Jobs: p.counters[i].jobs.Load(),
+			Busy: busy,
+		}
+		if elapsed > 0 {
+			ws.Utilization = float64(busy) / float64(elapsed)
+			if ws.Utilization > 1 {
+				ws.Utilization = 1
+			}
+		}
+		total += ws.Utilization
+		stats.Workers[i] = ws
+	}
+	if p.workers > 0 {
+		stats.Utilization = total / float64(p.workers)
+	}
+
+	return stats
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -502,3 +503,85 @@ func TestPool_ResultOrder(t *testing.T) {
 		}
 	}
 }
+
+// TestPool_PriorityOrder tests that queued jobs run highest priority first (FIFO on ties)
+func TestPool_PriorityOrder(t *testing.T) {
+	pool := NewPool(1)
+
+	var mu sync.Mutex
+	var order []string
+	record := func(id string) func(context.Context) (interface{}, error) {
+		return func(ctx context.Context) (interface{}, error) {
+			mu.Lock()
+			order = append(order, id)
+			mu.Unlock()
+			return nil, nil
+		}
+	}
+
+	// Submit before Start so all jobs are queued when the worker starts
+	pool.Submit(Job{ID: "small", Priority: 10, Fn: record("small")})
+	pool.Submit(Job{ID: "large", Priority: 1000, Fn: record("large")})
+	pool.Submit(Job{ID: "medium-1", Priority: 100, Fn: record("medium-1")})
+	pool.Submit(Job{ID: "medium-2", Priority: 100, Fn: record("medium-2")})
+	pool.Submit(Job{ID: "none", Fn: record("none")})
+
+	pool.Start(context.Background())
+	results, _ := pool.Wait()
+
+	if len(results) != 5 {
+		t.Fatalf("expected 5 results, got %d", len(results))
+	}
+	expected := []string{"large", "medium-1", "medium-2", "small", "none"}
+	for i, id := range expected {
+		if order[i] != id {
+			t.Fatalf("expected execution order %v, got %v", expected, order)
+		}
+	}
+}
+
+// TestPool_Stats tests per-worker utilization reporting
+func TestPool_Stats(t *testing.T) {
+	pool := NewPool(2)
+
+	if stats := pool.Stats(); stats.Elapsed != 0 || len(stats.Workers) != 2 {
+		t.Errorf("expected zero elapsed time and 2 workers before Start, got %+v", stats)
+	}
+
+	pool.Start(context.Background())
+	for i := 0; i < 4; i++ {
+		pool.Submit(Job{
+			ID: fmt.Sprintf("job-%d", i),
+			Fn: func(ctx context.Context) (interface{}, error) {
+				time.Sleep(20 * time.Millisecond)
+				return nil, nil
+			},
+		})
+	}
+	pool.Wait()
+
+	stats := pool.Stats()
+	var jobs int64
+	for _, w := range stats.Workers {
+		jobs += w.Jobs
+		if w.Utilization < 0 || w.Utilization > 1 {
+			t.Errorf("worker %d: utilization out of range: %f", w.ID, w.Utilization)
+		}
+	}
+	if jobs != 4 {
+		t.Errorf("expected 4 jobs across workers, got %d", jobs)
+	}
+	if stats.Utilization <= 0 {
+		t.Errorf("expected positive average utilization, got %f", stats.Utilization)
+	}
+	if stats.Queued != 0 {
+		t.Errorf("expected empty queue after Wait, got %d", stats.Queued)
+	}
+
+	// Elapsed is frozen after Wait
+	elapsed := stats.Elapsed
+	time.Sleep(5 * time.Millisecond)
+	if pool.Stats().Elapsed != elapsed {
+		t.Error("expected elapsed time to stay constant after Wait")
+	}
+}
@@ -273,6 +273,28 @@ func TestProgressTracker_ETAFallback(t *testing.T) {
 	}
 }
 
+// TestProgressTracker_Bytes tests byte-based percentage and ETA fallback
+func TestProgressTracker_Bytes(t *testing.T) {
+	pt := NewProgressTracker(2)
+	pt.SetTotalBytes(1000)
+
+	pt.startTime = time.Now().Add(-2 * time.Second)
+	pt.AddProcessedBytes(250)
+
+	progress := pt.GetProgressDetailed()
+	if progress.TotalBytes != 1000 || progress.ProcessedBytes != 250 {
+		t.Errorf("unexpected byte counters: %d/%d", progress.ProcessedBytes, progress.TotalBytes)
+	}
+	if progress.PercentBytes != 25.0 {
+		t.Errorf("expected PercentBytes=25.0, got %f", progress.PercentBytes)
+	}
+
+	// 250 bytes in 2s → remaining 750 bytes take ~6s
+	if progress.ETA < 5*time.Second || progress.ETA > 7*time.Second {
+		t.Errorf("expected byte-based ETA of ~6s, got %v", progress.ETA)
+	}
+}
+
 // TestProgressTracker_ConcurrentUpdates tests thread-safety
 func TestProgressTracker_ConcurrentUpdates(t *testing.T) {
 	pt := NewProgressTracker(100)
@@ -0,0 +1,119 @@
+package worker
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// jobQueue ist eine threadsichere Prioritäts-Warteschlange für Jobs.
+//
+// Jobs mit höherer Priority werden zuerst ausgegeben; bei gleicher Priorität
+// gilt die Submit-Reihenfolge (FIFO). Alle Worker entnehmen ihre Jobs aus
+// dieser einen zentralen Warteschlange: Ein frei gewordener Worker übernimmt
+// sofort den wichtigsten verbleibenden Job, ohne dass Jobs vorab einzelnen
+// Workern zugeteilt werden.
+type jobQueue struct {
+	mu     sync.Mutex
+	items  jobHeap
+	seq    uint64
+	notify chan struct{} // Signalisiert neue Jobs bzw. Schließen (Kapazität 1)
+	closed bool
+}
+
+// newJobQueue erstellt eine leere Warteschlange.
+func newJobQueue() *jobQueue {
+	return &jobQueue{notify: make(chan struct{}, 1)}
+}
+
+// push fügt einen Job hinzu. Gibt false zurück, wenn die Warteschlange geschlossen ist.
+func (q *jobQueue) push(job Job) bool {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return false
+	}
+	heap.Push(&q.items, queuedJob{job: job, seq: q.seq})
+	q.seq++
+	q.mu.Unlock()
+
+	q.signal()
+	return true
+}
+
+// pop entnimmt den Job mit der höchsten Priorität.
+//
+// Rückgabewerte:
+//   - item: Entnommener Job (nur gültig, wenn ok)
+//   - ok: true, wenn ein Job entnommen wurde
+//   - closed: true, wenn die Warteschlange geschlossen und leer ist
+func (q *jobQueue) pop() (item queuedJob, ok bool, closed bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.items) == 0 {
+		return queuedJob{}, false, q.closed
+	}
+	return heap.Pop(&q.items).(queuedJob), true, false
+}
+
+// requeue legt einen bereits entnommenen Job mit seiner ursprünglichen
+// Sequenznummer zurück (auch nach close, damit keine Jobs verloren gehen).
+func (q *jobQueue) requeue(item queuedJob) {
+	q.mu.Lock()
+	heap.Push(&q.items, item)
+	q.mu.Unlock()
+}
+
+// close schließt die Warteschlange für weitere Jobs; vorhandene Jobs bleiben erhalten.
+func (q *jobQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+
+	q.signal()
+}
+
+// len gibt die Anzahl wartender Jobs zurück.
+func (q *jobQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// signal weckt den Dispatcher (non-blocking).
+func (q *jobQueue) signal() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// queuedJob ist ein Job mit Sequenznummer für stabile Sortierung.
+type queuedJob struct {
+	job Job
+	seq uint64
+}
+
+// jobHeap implementiert heap.Interface (Max-Heap nach Priority, dann FIFO).
+type jobHeap []queuedJob
+
+func (h jobHeap) Len() int { return len(h) }
+
+func (h jobHeap) Less(i, j int) bool {
+	if h[i].job.Priority != h[j].job.Priority {
+		return h[i].job.Priority > h[j].job.Priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h jobHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *jobHeap) Push(x interface{}) { *h = append(*h, x.(queuedJob)) }
+
+func (h *jobHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
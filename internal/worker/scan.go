@@ -0,0 +1,109 @@
+package worker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+)
+
+// FileScan ist das Ergebnis des Vorab-Scans einer Datei.
+type FileScan struct {
+	Lines  int64          // Anzahl Zeilen (Schätzung der Rows, inkl. Leerzeilen)
+	Bytes  int64          // Dateigröße
+	Chunks []parser.Chunk // Chunk-Grenzen, falls die Datei geteilt geparst wird (sonst nil)
+	SHA256 string         // SHA-256-Checksumme als Hex-String (nur mit WithChecksums)
+}
+
+// ScanResult ist das Ergebnis des Vorab-Scans aller Import-Dateien.
+//
+// Die Zeilenzahl ist eine obere Schranke der zu importierenden Rows
+// (Leerzeilen und fehlerhafte Zeilen werden mitgezählt) und dient der
+// zeilenbasierten Fortschritts- und ETA-Berechnung.
+type ScanResult struct {
+	Files      map[string]FileScan // Scan-Ergebnis je Datei
+	TotalLines int64               // Summe der Zeilen
+	TotalBytes int64               // Summe der Dateigrößen
+	Duration   time.Duration       // Dauer des Scans
+}
+
+// CountLines zählt die Zeilen einer Datei über Newline-Zeichen, ohne sie zu
+// parsen. Eine letzte Zeile ohne abschließendes Newline wird mitgezählt.
+func CountLines(path string) (int64, error) {
+	lines, _, err := parser.ScanJSONL(path, 0)
+	return lines, err
+}
+
+// ScanTasks zählt Zeilen und Bytes aller Task-Dateien mit bis zu workers
+// parallelen Goroutines.
+//
+// Nicht lesbare Dateien gehen mit 0 Zeilen ein; der Fehler wird beim
+// eigentlichen Parsen gemeldet. Bei Context-Abbruch wird ctx.Err() zurückgegeben.
+func ScanTasks(ctx context.Context, tasks []ParseTask, workers int) (ScanResult, error) {
+	return scanTasks(ctx, tasks, workers, nil, false)
+}
+
+// scanTasks arbeitet wie ScanTasks und ermittelt im selben Lesedurchgang die
+// Chunk-Grenzen (FileScan.Chunks) der Dateien, für die chunkSize eine
+// Chunk-Größe > 0 liefert (nil = kein Chunking), sowie mit checksums die
+// SHA-256-Checksumme jeder Datei (FileScan.SHA256).
+func scanTasks(ctx context.Context, tasks []ParseTask, workers int, chunkSize func(ParseTask) int64, checksums bool) (ScanResult, error) {
+	start := time.Now()
+	if workers < 1 {
+		workers = 1
+	}
+
+	result := ScanResult{Files: make(map[string]FileScan, len(tasks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, workers)
+
+	for _, task := range tasks {
+		if ctx.Err() != nil {
+			break
+		}
+		var size int64
+		if chunkSize != nil {
+			size = chunkSize(task)
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(file string, size int64) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var scan FileScan
+			if info, err := os.Stat(file); err == nil {
+				scan.Bytes = info.Size()
+			}
+			var h hash.Hash
+			if checksums {
+				h = sha256.New()
+			}
+			if lines, chunks, err := parser.ScanJSONLHash(file, size, h); err == nil {
+				scan.Lines = lines
+				if len(chunks) > 1 {
+					scan.Chunks = chunks
+				}
+				if h != nil {
+					scan.SHA256 = hex.EncodeToString(h.Sum(nil))
+				}
+			}
+
+			mu.Lock()
+			result.Files[file] = scan
+			result.TotalLines += scan.Lines
+			result.TotalBytes += scan.Bytes
+			mu.Unlock()
+		}(task.File, size)
+	}
+	wg.Wait()
+
+	result.Duration = time.Since(start)
+	return result, ctx.Err()
+}
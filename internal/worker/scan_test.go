@@ -0,0 +1,139 @@
+package worker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+)
+
+// TestCountLines tests newline counting with and without trailing newline
+func TestCountLines(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+		want    int64
+	}{
+		{"empty", "", 0},
+		{"trailing newline", "{}\n{}\n{}\n", 3},
+		{"no trailing newline", "{}\n{}\n{}", 3},
+		{"blank lines counted", "{}\n\n{}\n", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name+".jsonl")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+			got, err := CountLines(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("CountLines() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := CountLines(filepath.Join(dir, "missing.jsonl")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+// TestScanTasks tests the aggregated pre-scan over multiple files
+func TestScanTasks(t *testing.T) {
+	dir := t.TempDir()
+	writeChunkTestFile(t, dir, "a.jsonl", 10)
+	writeChunkTestFile(t, dir, "b.jsonl", 25)
+
+	tasks := []ParseTask{
+		{File: filepath.Join(dir, "a.jsonl")},
+		{File: filepath.Join(dir, "b.jsonl")},
+		{File: filepath.Join(dir, "missing.jsonl")},
+	}
+	scan, err := ScanTasks(context.Background(), tasks, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if scan.TotalLines != 35 {
+		t.Errorf("expected 35 lines, got %d", scan.TotalLines)
+	}
+	info, _ := os.Stat(tasks[1].File)
+	if scan.Files[tasks[1].File].Bytes != info.Size() {
+		t.Errorf("expected %d bytes for b.jsonl, got %d", info.Size(), scan.Files[tasks[1].File].Bytes)
+	}
+	if missing := scan.Files[tasks[2].File]; missing.Lines != 0 || missing.Bytes != 0 {
+		t.Errorf("expected empty scan for missing file, got %+v", missing)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ScanTasks(ctx, tasks, 1); err == nil {
+		t.Error("expected error for cancelled context")
+	}
+}
+
+// TestScanTasks_Chunks tests that the pre-scan yields chunk boundaries for
+// large files in the same pass, matching parser.SplitJSONL
+func TestScanTasks_Chunks(t *testing.T) {
+	dir := t.TempDir()
+	large := writeChunkTestFile(t, dir, "large.jsonl", 2000)
+	small := writeChunkTestFile(t, dir, "small.jsonl", 10)
+
+	tasks := []ParseTask{{File: large}, {File: small}}
+	scan, err := scanTasks(context.Background(), tasks, 2, func(ParseTask) int64 { return 4096 }, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want, err := parser.SplitJSONL(large, 4096)
+	if err != nil {
+		t.Fatalf("SplitJSONL failed: %v", err)
+	}
+	if got := scan.Files[large].Chunks; len(got) < 2 || !reflect.DeepEqual(got, want) {
+		t.Errorf("expected chunks %v, got %v", want, got)
+	}
+	if scan.Files[large].Lines != 2000 {
+		t.Errorf("expected 2000 lines, got %d", scan.Files[large].Lines)
+	}
+	if chunks := scan.Files[small].Chunks; chunks != nil {
+		t.Errorf("expected no chunks for small file, got %v", chunks)
+	}
+}
+
+// TestScanTasks_Checksums tests that the pre-scan computes SHA-256 checksums
+// in the same pass only when requested
+func TestScanTasks_Checksums(t *testing.T) {
+	dir := t.TempDir()
+	file := writeChunkTestFile(t, dir, "large.jsonl", 2000)
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("failed to read test file: %v", err)
+	}
+	sum := sha256.Sum256(content)
+
+	tasks := []ParseTask{{File: file}}
+	scan, err := scanTasks(context.Background(), tasks, 1, func(ParseTask) int64 { return 4096 }, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := scan.Files[file].SHA256, hex.EncodeToString(sum[:]); got != want {
+		t.Errorf("expected checksum %s, got %s", want, got)
+	}
+
+	scan, err = ScanTasks(context.Background(), tasks, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := scan.Files[file].SHA256; got != "" {
+		t.Errorf("expected no checksum without request, got %s", got)
+	}
+}
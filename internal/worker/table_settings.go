@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+)
+
+// TableSettings sind tabellenspezifische Import-Einstellungen (siehe WithTableSettings).
+type TableSettings struct {
+	// BatchSize ist die Anzahl Zeilen pro INSERT-Statement (0 = DefaultInsertBatchSize)
+	BatchSize int
+	// ErrorMode parst die Dateien der Tabelle per parser.ErrorHandlingParser im
+	// angegebenen Modus (nil = ParseFile, Abbruch bei der ersten fehlerhaften Zeile)
+	ErrorMode *parser.ErrorMode
+	// MaxErrors bricht im parser.ErrorModeSkip nach so vielen fehlerhaften
+	// Zeilen ab (0 = unbegrenzt)
+	MaxErrors int
+	// OnConflict ist die Konfliktstrategie der INSERTs (leer = database.ConflictAbort)
+	OnConflict database.ConflictStrategy
+	// Skip schließt die Tabelle vom Import aus
+	Skip bool
+}
+
+// WithTableSettings setzt Import-Einstellungen je Tabellenname.
+//
+// ErrorMode und MaxErrors gelten für die Parse-Phase: Dateien solcher
+// Tabellen werden nicht in Chunks geteilt, im parser.ErrorModeSkip
+// übersprungene Zeilen zählt Progress.SkippedLines. BatchSize und OnConflict
+// gelten für die Insert-Phase (bei WithTargets nur für Ziele ohne eigene
+// ImportTarget.Settings). Tabellen mit Skip werden wie vom Tabellenfilter
+// ausgeschlossene Tabellen in ImportPlan.Skipped gemeldet.
+//
+// Tabellen ohne Eintrag werden mit den Standardeinstellungen importiert.
+func WithTableSettings(settings map[string]TableSettings) OrchestratorOption {
+	return func(o *Orchestrator) {
+		o.tableSettings = settings
+	}
+}
@@ -0,0 +1,171 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+)
+
+// appendLines hängt rohe Zeilen an eine JSONL-Datei an
+func appendLines(t *testing.T, path string, lines ...string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer func() { _ = f.Close() }()
+	for _, line := range lines {
+		if _, err := f.WriteString(line + "\n"); err != nil {
+			t.Fatalf("failed to append to %s: %v", path, err)
+		}
+	}
+}
+
+// TestOrchestrator_ImportAll_TableSettingsErrorMode testet ErrorModeSkip mit
+// und ohne Fehler-Schwelle
+func TestOrchestrator_ImportAll_TableSettingsErrorMode(t *testing.T) {
+	skip := parser.ErrorModeSkip
+	tests := []struct {
+		name       string
+		maxErrors  int
+		wantFailed int
+		wantRows   int
+		wantSkip   int64
+	}{
+		{name: "unlimited", wantRows: 2000, wantSkip: 2},
+		{name: "threshold", maxErrors: 2, wantFailed: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 2000 gültige Zeilen, dazwischen zwei fehlerhafte
+			tmpDir := t.TempDir()
+			path := writeChunkTestFile(t, tmpDir, "alpha.jsonl", 1000)
+			appendLines(t, path, "{broken", "not json")
+			for i := 1; i <= 1000; i++ {
+				appendLines(t, path, fmt.Sprintf(`{"id":%d,"name":"tail-%d"}`, 1000+i, i))
+			}
+
+			db := newTargetTestDB(t)
+			parsers := map[string]parser.Parser{
+				"alpha": parser.NewJSONLParser[chunkTestRow]("alpha", []string{"id", "name"}),
+			}
+			// Die kleine Chunk-Größe würde die Datei ohne ErrorMode teilen
+			orch := NewOrchestrator(db, NewPool(2), parsers, WithChunkSize(4096), WithTableSettings(map[string]TableSettings{
+				"alpha": {ErrorMode: &skip, MaxErrors: tt.maxErrors},
+			}))
+
+			progress, err := orch.ImportAll(context.Background(), tmpDir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			details := progress.GetProgressDetailed()
+			if int(details.FailedFiles) != tt.wantFailed || details.SkippedLines != tt.wantSkip {
+				t.Errorf("expected %d failed files and %d skipped lines, got %d and %d",
+					tt.wantFailed, tt.wantSkip, details.FailedFiles, details.SkippedLines)
+			}
+
+			var rows int
+			if err := db.Get(&rows, "SELECT COUNT(*) FROM alpha"); err != nil {
+				t.Fatalf("failed to count rows: %v", err)
+			}
+			if rows != tt.wantRows {
+				t.Errorf("expected %d rows, got %d", tt.wantRows, rows)
+			}
+		})
+	}
+}
+
+// batchRecorder merkt sich die eingefügten Zeilen nach jedem Batch
+type batchRecorder struct {
+	NopObserver
+	rows []int
+}
+
+func (r *batchRecorder) OnInsertProgress(progress InsertProgress) {
+	r.rows = append(r.rows, progress.Rows)
+}
+
+// TestOrchestrator_ImportAll_TableSettingsInsert testet Konfliktstrategie,
+// Batch-Größe und Skip je Tabelle
+func TestOrchestrator_ImportAll_TableSettingsInsert(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := writeChunkTestFile(t, tmpDir, "alpha.jsonl", 10)
+	appendLines(t, path, `{"id":3,"name":"duplicate"}`)
+	writeChunkTestFile(t, tmpDir, "beta.jsonl", 5)
+
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	for _, table := range []string{"alpha", "beta"} {
+		if _, err := db.Exec("CREATE TABLE " + table + " (id INTEGER PRIMARY KEY, name TEXT)"); err != nil {
+			t.Fatalf("failed to create table %s: %v", table, err)
+		}
+	}
+
+	parsers := map[string]parser.Parser{
+		"alpha": parser.NewJSONLParser[chunkTestRow]("alpha", []string{"id", "name"}),
+		"beta":  parser.NewJSONLParser[chunkTestRow]("beta", []string{"id", "name"}),
+	}
+	observer := &batchRecorder{}
+	orch := NewOrchestrator(db, NewPool(2), parsers, WithObserver(observer), WithTableSettings(map[string]TableSettings{
+		"alpha": {BatchSize: 4, OnConflict: database.ConflictReplace},
+		"beta":  {Skip: true},
+	}))
+
+	plan, err := orch.Discover(tmpDir)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if len(plan.Tasks) != 1 || len(plan.Skipped) != 1 || plan.Skipped[0].Table != "beta" {
+		t.Fatalf("expected alpha task and beta skipped, got %+v", plan)
+	}
+
+	if _, err := orch.ImportAll(context.Background(), tmpDir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var name string
+	if err := db.Get(&name, "SELECT name FROM alpha WHERE id = 3"); err != nil {
+		t.Fatalf("failed to query alpha: %v", err)
+	}
+	if name != "duplicate" {
+		t.Errorf("expected replaced row, got name %q", name)
+	}
+	if rows := observer.rows; len(rows) != 3 || rows[0] != 4 || rows[2] != 11 {
+		t.Errorf("expected 3 batches of up to 4 rows, got %v", rows)
+	}
+}
+
+// TestOrchestrator_ImportAll_TargetSettings testet Insert-Einstellungen je Ziel
+func TestOrchestrator_ImportAll_TargetSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeChunkTestFile(t, tmpDir, "alpha.jsonl", 10)
+	writeChunkTestFile(t, tmpDir, "beta.jsonl", 5)
+
+	full := newTargetTestDB(t)
+	slim := newTargetTestDB(t)
+	parsers := map[string]parser.Parser{
+		"alpha": parser.NewJSONLParser[chunkTestRow]("alpha", []string{"id", "name"}),
+		"beta":  parser.NewJSONLParser[chunkTestRow]("beta", []string{"id", "name"}),
+	}
+	orch := NewOrchestrator(full, NewPool(2), parsers, WithTargets(
+		ImportTarget{Name: "full", Writer: NewDBWriter(full)},
+		ImportTarget{Name: "slim", Writer: NewDBWriter(slim), Settings: map[string]TableSettings{"beta": {Skip: true}}},
+	))
+
+	if _, err := orch.ImportAll(context.Background(), tmpDir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stats := orch.TargetStats()
+	if stats[0].InsertedRows != 15 || stats[1].InsertedRows != 10 {
+		t.Errorf("expected 15 rows in full and 10 in slim, got %+v", stats)
+	}
+}
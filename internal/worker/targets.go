@@ -0,0 +1,144 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+)
+
+// ImportTarget ist eine Ziel-Datenbank für ImportAll (siehe WithTargets).
+type ImportTarget struct {
+	Name   string              // Bezeichnung für Fehler und Statistiken, z.B. Profilname
+	Writer *DBWriter           // Single-Writer-Zugang zur Ziel-Datenbank
+	Tables *parser.TableFilter // Tabellen dieses Ziels (nil = alle)
+	// Settings sind die Insert-Einstellungen dieses Ziels je Tabelle
+	// (BatchSize, OnConflict, Skip); nil = die von WithTableSettings
+	Settings map[string]TableSettings
+}
+
+// settingsFor liefert die Einstellungen des Ziels für table.
+func (t ImportTarget) settingsFor(o *Orchestrator, table string) TableSettings {
+	if t.Settings != nil {
+		return t.Settings[table]
+	}
+	return o.tableSettings[table]
+}
+
+// selects prüft, ob das Ziel Daten der Tabelle erhält.
+func (t ImportTarget) selects(o *Orchestrator, table string) bool {
+	return t.Tables.Match(table) && !t.settingsFor(o, table).Skip
+}
+
+// TargetStats fasst das Ergebnis der Insert-Phase für ein ImportTarget zusammen.
+type TargetStats struct {
+	Name          string
+	InsertedFiles int
+	FailedFiles   int
+	InsertedRows  int64
+}
+
+// WithTargets importiert in mehrere Datenbanken, wobei das SDE nur einmal
+// geparst wird.
+//
+// Geparst werden alle Dateien, deren Tabelle mindestens ein Ziel auswählt
+// (zusätzlich eingeschränkt durch WithTableFilter). In Phase 2 wird jedes
+// Parse-Ergebnis nacheinander in alle Ziele eingefügt, deren Filter die
+// Tabelle auswählt. Schlägt das Insert in einem Ziel fehl, wird die Datei als
+// fehlgeschlagen gemeldet (OnFileFailed); die übrigen Ziele erhalten die
+// Daten trotzdem. OnInsertProgress wird je Ziel gemeldet, OnFileInserted
+// einmal pro Datei.
+//
+// Ohne Ziele schreibt ImportAll wie bisher über den eigenen Writer in db.
+func WithTargets(targets ...ImportTarget) OrchestratorOption {
+	return func(o *Orchestrator) {
+		o.targets = append(o.targets, targets...)
+	}
+}
+
+// TargetStats liefert die Insert-Statistik je Ziel (in der Reihenfolge von
+// WithTargets) nach ImportAll; ohne Ziele nil.
+func (o *Orchestrator) TargetStats() []TargetStats {
+	if len(o.targets) == 0 {
+		return nil
+	}
+	stats := make([]TargetStats, len(o.targets))
+	copy(stats, o.targetStats)
+	return stats
+}
+
+// selectsTable prüft, ob Dateien der Tabelle geparst werden.
+func (o *Orchestrator) selectsTable(table string) bool {
+	if !o.tables.Match(table) || o.tableSettings[table].Skip {
+		return false
+	}
+	if len(o.targets) == 0 {
+		return true
+	}
+	for _, target := range o.targets {
+		if target.selects(o, table) {
+			return true
+		}
+	}
+	return false
+}
+
+// insertResult fügt ein Parse-Ergebnis in alle passenden Ziele ein (ohne
+// Ziele über den eigenen Writer) und liefert die eingefügten Zeilen.
+func (o *Orchestrator) insertResult(ctx context.Context, result ParseResultData) (int, error) {
+	if len(o.targets) == 0 {
+		return o.insertInto(ctx, o.writer, result, o.tableSettings[result.Table])
+	}
+
+	if o.targetStats == nil {
+		o.targetStats = make([]TargetStats, len(o.targets))
+		for i, target := range o.targets {
+			o.targetStats[i].Name = target.Name
+		}
+	}
+
+	rows, inserted := 0, false
+	var firstErr error
+	for i, target := range o.targets {
+		if !target.selects(o, result.Table) {
+			continue
+		}
+		n, err := o.insertInto(ctx, target.Writer, result, target.settingsFor(o, result.Table))
+		if err != nil {
+			o.targetStats[i].FailedFiles++
+			if firstErr == nil {
+				firstErr = fmt.Errorf("target %s: %w", target.Name, err)
+			}
+			continue
+		}
+		o.targetStats[i].InsertedFiles++
+		o.targetStats[i].InsertedRows += int64(n)
+		if !inserted {
+			rows, inserted = n, true
+		}
+	}
+	return rows, firstErr
+}
+
+// insertInto fügt ein Parse-Ergebnis mit Batch-Größe und Konfliktstrategie
+// aus settings über writer ein.
+func (o *Orchestrator) insertInto(ctx context.Context, writer *DBWriter, result ParseResultData, settings TableSettings) (int, error) {
+	batchSize := settings.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultInsertBatchSize
+	}
+	insert := &InsertJob{
+		Writer:     writer,
+		Table:      result.Table,
+		Columns:    result.Columns,
+		Rows:       result.Records,
+		BatchSize:  batchSize,
+		OnConflict: settings.OnConflict,
+		Progress:   o.insertProgress(result.File, result.Table, batchSize),
+	}
+	res, err := insert.Execute(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.(InsertResult).RowsAffected, nil
+}
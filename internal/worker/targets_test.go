@@ -0,0 +1,120 @@
+package worker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/parser"
+	"github.com/jmoiron/sqlx"
+)
+
+// newTargetTestDB erstellt eine In-Memory-Datenbank mit den Tabellen alpha und beta
+func newTargetTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+	return newMemoryTestDB(t,
+		"CREATE TABLE alpha (id INTEGER, name TEXT)",
+		"CREATE TABLE beta (id INTEGER, name TEXT)",
+	)
+}
+
+// TestOrchestrator_ImportAll_Targets testet den Import in mehrere Ziele mit einem Parse-Durchlauf
+func TestOrchestrator_ImportAll_Targets(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeChunkTestFile(t, tmpDir, "alpha.jsonl", 10)
+	writeChunkTestFile(t, tmpDir, "beta.jsonl", 5)
+	writeChunkTestFile(t, tmpDir, "gamma.jsonl", 3)
+
+	full := newTargetTestDB(t)
+	slim := newTargetTestDB(t)
+	slimFilter, err := parser.NewTableFilter([]string{"alpha"}, nil)
+	if err != nil {
+		t.Fatalf("failed to create filter: %v", err)
+	}
+	betaOnly, err := parser.NewTableFilter([]string{"beta"}, nil)
+	if err != nil {
+		t.Fatalf("failed to create filter: %v", err)
+	}
+
+	parsers := map[string]parser.Parser{
+		"alpha": parser.NewJSONLParser[chunkTestRow]("alpha", []string{"id", "name"}),
+		"beta":  parser.NewJSONLParser[chunkTestRow]("beta", []string{"id", "name"}),
+		"gamma": parser.NewJSONLParser[chunkTestRow]("gamma", []string{"id", "name"}),
+	}
+	orch := NewOrchestrator(full, NewPool(2), parsers, WithTargets(
+		ImportTarget{Name: "full", Writer: NewDBWriter(full), Tables: betaOnly},
+		ImportTarget{Name: "slim", Writer: NewDBWriter(slim), Tables: slimFilter},
+	))
+
+	// gamma wird von keinem Ziel ausgewählt und daher nicht geparst
+	plan, err := orch.Discover(tmpDir)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if len(plan.Tasks) != 2 || len(plan.Skipped) != 1 || plan.Skipped[0].Table != "gamma" {
+		t.Fatalf("expected alpha and beta tasks and gamma skipped, got %+v", plan)
+	}
+
+	progress, err := orch.ImportAll(context.Background(), tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, inserted, failed, _ := progress.GetProgress(); inserted != 2 || failed != 0 {
+		t.Errorf("expected 2 inserted files, got inserted=%d failed=%d", inserted, failed)
+	}
+
+	counts := func(db *sqlx.DB) (alpha, beta int) {
+		_ = db.Get(&alpha, "SELECT COUNT(*) FROM alpha")
+		_ = db.Get(&beta, "SELECT COUNT(*) FROM beta")
+		return alpha, beta
+	}
+	if alpha, beta := counts(full); alpha != 0 || beta != 5 {
+		t.Errorf("full: expected 0 alpha and 5 beta rows, got %d and %d", alpha, beta)
+	}
+	if alpha, beta := counts(slim); alpha != 10 || beta != 0 {
+		t.Errorf("slim: expected 10 alpha and 0 beta rows, got %d and %d", alpha, beta)
+	}
+
+	stats := orch.TargetStats()
+	if len(stats) != 2 || stats[0].Name != "full" || stats[0].InsertedRows != 5 || stats[1].InsertedFiles != 1 || stats[1].InsertedRows != 10 {
+		t.Errorf("unexpected target stats: %+v", stats)
+	}
+}
+
+// TestOrchestrator_ImportAll_TargetFailure testet, dass ein fehlerhaftes Ziel die übrigen nicht blockiert
+func TestOrchestrator_ImportAll_TargetFailure(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeChunkTestFile(t, tmpDir, "alpha.jsonl", 4)
+
+	good := newTargetTestDB(t)
+	broken, err := database.NewDB(":memory:") // ohne Tabellen
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	defer func() { _ = broken.Close() }()
+
+	parsers := map[string]parser.Parser{
+		"alpha": parser.NewJSONLParser[chunkTestRow]("alpha", []string{"id", "name"}),
+	}
+	orch := NewOrchestrator(good, NewPool(1), parsers, WithTargets(
+		ImportTarget{Name: "broken", Writer: NewDBWriter(broken)},
+		ImportTarget{Name: "good", Writer: NewDBWriter(good)},
+	))
+
+	progress, err := orch.ImportAll(context.Background(), tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, failed, _ := progress.GetProgress(); failed != 1 {
+		t.Errorf("expected the file to be reported as failed, got failed=%d", failed)
+	}
+
+	var count int
+	if err := good.Get(&count, "SELECT COUNT(*) FROM alpha"); err != nil || count != 4 {
+		t.Errorf("expected 4 rows in good target, got %d (%v)", count, err)
+	}
+	stats := orch.TargetStats()
+	if stats[0].FailedFiles != 1 || stats[1].InsertedFiles != 1 {
+		t.Errorf("unexpected target stats: %+v", stats)
+	}
+}
@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Sternrassler/EVE-SDE-Database-Builder/internal/database"
+	"github.com/jmoiron/sqlx"
+)
+
+// DefaultInsertBatchSize ist die Anzahl Zeilen pro INSERT-Statement, wenn
+// InsertJob.BatchSize nicht gesetzt ist.
+const DefaultInsertBatchSize = 1000
+
+// DBWriter ist ein gemeinsamer Single-Writer-Zugang zur Datenbank.
+//
+// SQLite erlaubt nur einen Writer gleichzeitig. DBWriter serialisiert alle
+// Schreibzugriffe über einen Mutex, sodass InsertJobs und eigene Jobs (z.B.
+// Post-Processing) gefahrlos aus mehreren Goroutines – auch aus Workern des
+// Pools – ausgeführt werden können.
+//
+// Beispiel:
+//
+//	writer := worker.NewDBWriter(db)
+//	job := &worker.InsertJob{Writer: writer, Table: "types", Columns: cols, Rows: records}
+//	result, err := job.Execute(ctx)
+type DBWriter struct {
+	db *sqlx.DB
+	mu sync.Mutex
+}
+
+// NewDBWriter erstellt einen DBWriter für die übergebene Datenbankverbindung.
+func NewDBWriter(db *sqlx.DB) *DBWriter {
+	return &DBWriter{db: db}
+}
+
+// DB gibt die zugrunde liegende Datenbankverbindung zurück (nur für Lesezugriffe).
+func (w *DBWriter) DB() *sqlx.DB {
+	return w.db
+}
+
+// Insert fügt rows per database.BatchInsert in table ein.
+//
+// Der Aufruf hält den Writer-Lock für die gesamte Transaktion.
+func (w *DBWriter) Insert(ctx context.Context, table string, columns []string, rows [][]interface{}, batchSize int) error {
+	return w.InsertWithProgress(ctx, table, columns, rows, batchSize, nil)
+}
+
+// InsertWithProgress entspricht Insert und meldet den Fortschritt nach jedem
+// Batch an progress (darf nil sein).
+func (w *DBWriter) InsertWithProgress(ctx context.Context, table string, columns []string, rows [][]interface{}, batchSize int, progress database.ProgressCallback) error {
+	return w.Do(ctx, func(db *sqlx.DB) error {
+		return database.BatchInsertWithProgress(ctx, db, table, columns, rows, batchSize, progress)
+	})
+}
+
+// InsertWithOptions entspricht Insert mit Batch-Größe, Konfliktstrategie und
+// Fortschritts-Callback aus opts (siehe database.BatchInsertWithOptions).
+func (w *DBWriter) InsertWithOptions(ctx context.Context, table string, columns []string, rows [][]interface{}, opts database.BatchOptions) error {
+	return w.Do(ctx, func(db *sqlx.DB) error {
+		return database.BatchInsertWithOptions(ctx, db, table, columns, rows, opts)
+	})
+}
+
+// Do führt fn exklusiv aus, d.h. ohne parallel laufende Schreibzugriffe
+// anderer Jobs. Gedacht für eigene Schreib-Jobs wie Post-Processing.
+//
+// Ein bereits gecancelter Context verhindert die Ausführung von fn.
+func (w *DBWriter) Do(ctx context.Context, fn func(db *sqlx.DB) error) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("db writer: %w", err)
+	}
+	return fn(w.db)
+}
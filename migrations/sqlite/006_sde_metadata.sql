@@ -0,0 +1,12 @@
+-- Migration: 006_sde_metadata.sql
+-- Description: Create _sde table (SDE export metadata from _sde.jsonl)
+-- Source: RIFT SDE Schema (https://sde.riftforeve.online/)
+-- ADR Reference: ADR-001 (SQLite-Only), ADR-002 (Database Layer Design)
+
+-- Single-row table: the constant _key with ON CONFLICT REPLACE makes every
+-- re-import replace the metadata row instead of adding another one.
+CREATE TABLE IF NOT EXISTS _sde (
+    _key TEXT NOT NULL DEFAULT 'sde' PRIMARY KEY ON CONFLICT REPLACE CHECK (_key = 'sde'),
+    version TEXT,
+    releaseDate TEXT
+);
@@ -0,0 +1,64 @@
+--
+-- PostgreSQL dump generated by EVE SDE Database Builder
+--
+-- Tables: 2
+-- Load with: psql -v ON_ERROR_STOP=1 -f <file>
+--
+
+SET client_encoding = 'UTF8';
+SET standard_conforming_strings = on;
+
+BEGIN;
+
+CREATE SCHEMA IF NOT EXISTS "sde";
+SET search_path TO "sde";
+
+DROP TABLE IF EXISTS "items" CASCADE;
+DROP TABLE IF EXISTS "itemTags" CASCADE;
+
+CREATE TABLE "itemTags" (
+    "itemID" BIGINT NOT NULL,
+    "tag" TEXT NOT NULL,
+    "ownerID" BIGINT,
+    PRIMARY KEY ("itemID", "tag")
+);
+
+CREATE TABLE "items" (
+    "itemID" BIGINT,
+    "name" TEXT NOT NULL DEFAULT 'unnamed',
+    "description" TEXT,
+    "price" DOUBLE PRECISION DEFAULT 0,
+    "published" BOOLEAN DEFAULT TRUE,
+    "data" BYTEA,
+    "amount" NUMERIC,
+    "code" TEXT,
+    "created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    "misc" TEXT,
+    PRIMARY KEY ("itemID")
+);
+
+COPY "itemTags" ("itemID", "tag", "ownerID") FROM stdin;
+1	ore	\N
+1	mineral	7
+\.
+
+COPY "items" ("itemID", "name", "description", "price", "published", "data", "amount", "code", "created", "misc") FROM stdin;
+1	Tab\tand "quotes"	Line one\nline two\\with backslash\r	1.5e+06	true	\\x00ff10	12	A-1	2024-01-02 03:04:05	5
+2	Empty		1.5e-07	false	\N	0.5	\N	\N	text
+3	Nulls	\N	\N	\N	\N	\N	\N	\N	\N
+\.
+
+-- Indexes
+CREATE UNIQUE INDEX "idx_itemTags_tag" ON "itemTags" ("tag", "itemID");
+CREATE INDEX "idx_items_name" ON "items" ("name" DESC, "price");
+ALTER TABLE "items" ADD UNIQUE ("code");
+
+-- Foreign keys
+ALTER TABLE "itemTags" ADD FOREIGN KEY ("itemID") REFERENCES "items" ("itemID");
+
+-- Skipped
+-- foreign key "itemTags" ("ownerID") references "owners", which is not exported
+-- expression index "idx_items_lower" on "items"
+-- partial index "idx_items_published" on "items"
+
+COMMIT;
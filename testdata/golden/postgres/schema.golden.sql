@@ -0,0 +1,270 @@
+--
+-- PostgreSQL dump generated by EVE SDE Database Builder
+--
+-- Tables: 16
+-- Load with: psql -v ON_ERROR_STOP=1 -f <file>
+--
+
+SET client_encoding = 'UTF8';
+SET standard_conforming_strings = on;
+
+BEGIN;
+
+CREATE TABLE "_sde" (
+    "_key" TEXT NOT NULL DEFAULT 'sde',
+    "version" TEXT,
+    "releaseDate" TEXT,
+    PRIMARY KEY ("_key")
+);
+
+CREATE TABLE "dogmaAttributes" (
+    "attributeID" BIGINT,
+    "attributeName" TEXT,
+    "description" TEXT,
+    "iconID" BIGINT,
+    "defaultValue" DOUBLE PRECISION,
+    "published" BIGINT,
+    "displayName" TEXT,
+    "unitID" BIGINT,
+    "stackable" BIGINT,
+    "highIsGood" BIGINT,
+    PRIMARY KEY ("attributeID")
+);
+
+CREATE TABLE "dogmaEffects" (
+    "effectID" BIGINT,
+    "effectName" TEXT,
+    "effectCategory" BIGINT,
+    "preExpression" BIGINT,
+    "postExpression" BIGINT,
+    "description" TEXT,
+    "guid" TEXT,
+    "iconID" BIGINT,
+    "isOffensive" BIGINT,
+    "isAssistance" BIGINT,
+    "durationAttributeID" BIGINT,
+    "trackingSpeedAttributeID" BIGINT,
+    "dischargeAttributeID" BIGINT,
+    "rangeAttributeID" BIGINT,
+    "falloffAttributeID" BIGINT,
+    "disallowAutoRepeat" BIGINT,
+    "published" BIGINT,
+    "displayName" TEXT,
+    "isWarpSafe" BIGINT,
+    "rangeChance" BIGINT,
+    "electronicChance" BIGINT,
+    "propulsionChance" BIGINT,
+    "distribution" BIGINT,
+    "sfxName" TEXT,
+    "npcUsageChanceAttributeID" BIGINT,
+    "npcActivationChanceAttributeID" BIGINT,
+    "fittingUsageChanceAttributeID" BIGINT,
+    "modifierInfo" TEXT,
+    PRIMARY KEY ("effectID")
+);
+
+CREATE TABLE "dogmaTypeAttributes" (
+    "typeID" BIGINT,
+    "attributeID" BIGINT,
+    "valueInt" BIGINT,
+    "valueFloat" DOUBLE PRECISION,
+    PRIMARY KEY ("typeID", "attributeID")
+);
+
+CREATE TABLE "dogmaTypeEffects" (
+    "typeID" BIGINT,
+    "effectID" BIGINT,
+    "isDefault" BIGINT,
+    PRIMARY KEY ("typeID", "effectID")
+);
+
+CREATE TABLE "industryActivities" (
+    "blueprintTypeID" BIGINT,
+    "activityID" BIGINT,
+    "time" BIGINT,
+    PRIMARY KEY ("blueprintTypeID", "activityID")
+);
+
+CREATE TABLE "industryActivityMaterials" (
+    "blueprintTypeID" BIGINT,
+    "activityID" BIGINT,
+    "materialTypeID" BIGINT,
+    "quantity" BIGINT,
+    PRIMARY KEY ("blueprintTypeID", "activityID", "materialTypeID")
+);
+
+CREATE TABLE "industryActivityProducts" (
+    "blueprintTypeID" BIGINT,
+    "activityID" BIGINT,
+    "productTypeID" BIGINT,
+    "quantity" BIGINT,
+    PRIMARY KEY ("blueprintTypeID", "activityID", "productTypeID")
+);
+
+CREATE TABLE "industryBlueprints" (
+    "blueprintTypeID" BIGINT,
+    "maxProductionLimit" BIGINT,
+    PRIMARY KEY ("blueprintTypeID")
+);
+
+CREATE TABLE "invGroups" (
+    "groupID" BIGINT,
+    "categoryID" BIGINT,
+    "groupName" TEXT NOT NULL,
+    "iconID" BIGINT,
+    "useBasePrice" BIGINT,
+    "anchored" BIGINT,
+    "anchorable" BIGINT,
+    "fittableNonSingleton" BIGINT,
+    "published" BIGINT,
+    PRIMARY KEY ("groupID")
+);
+
+CREATE TABLE "invTypes" (
+    "typeID" BIGINT,
+    "typeName" TEXT NOT NULL,
+    "groupID" BIGINT,
+    "description" TEXT,
+    "mass" DOUBLE PRECISION,
+    "volume" DOUBLE PRECISION,
+    "capacity" DOUBLE PRECISION,
+    "portionSize" BIGINT,
+    "raceID" BIGINT,
+    "basePrice" DOUBLE PRECISION,
+    "published" BIGINT,
+    "marketGroupID" BIGINT,
+    "iconID" BIGINT,
+    "soundID" BIGINT,
+    "graphicID" BIGINT,
+    PRIMARY KEY ("typeID")
+);
+
+CREATE TABLE "mapConstellations" (
+    "constellationID" BIGINT,
+    "constellationName" TEXT,
+    "regionID" BIGINT,
+    "x" DOUBLE PRECISION,
+    "y" DOUBLE PRECISION,
+    "z" DOUBLE PRECISION,
+    "factionID" BIGINT,
+    PRIMARY KEY ("constellationID")
+);
+
+CREATE TABLE "mapPlanets" (
+    "planetID" BIGINT,
+    "planetName" TEXT,
+    "solarSystemID" BIGINT,
+    "typeID" BIGINT,
+    "x" DOUBLE PRECISION,
+    "y" DOUBLE PRECISION,
+    "z" DOUBLE PRECISION,
+    PRIMARY KEY ("planetID")
+);
+
+CREATE TABLE "mapRegions" (
+    "regionID" BIGINT,
+    "regionName" TEXT,
+    "x" DOUBLE PRECISION,
+    "y" DOUBLE PRECISION,
+    "z" DOUBLE PRECISION,
+    "factionID" BIGINT,
+    PRIMARY KEY ("regionID")
+);
+
+CREATE TABLE "mapSolarSystems" (
+    "solarSystemID" BIGINT,
+    "solarSystemName" TEXT,
+    "regionID" BIGINT,
+    "constellationID" BIGINT,
+    "x" DOUBLE PRECISION,
+    "y" DOUBLE PRECISION,
+    "z" DOUBLE PRECISION,
+    "security" DOUBLE PRECISION,
+    "securityClass" TEXT,
+    PRIMARY KEY ("solarSystemID")
+);
+
+CREATE TABLE "mapStargates" (
+    "stargateID" BIGINT,
+    "solarSystemID" BIGINT,
+    "destinationID" BIGINT,
+    PRIMARY KEY ("stargateID")
+);
+
+COPY "_sde" ("_key", "version", "releaseDate") FROM stdin;
+\.
+
+COPY "dogmaAttributes" ("attributeID", "attributeName", "description", "iconID", "defaultValue", "published", "displayName", "unitID", "stackable", "highIsGood") FROM stdin;
+\.
+
+COPY "dogmaEffects" ("effectID", "effectName", "effectCategory", "preExpression", "postExpression", "description", "guid", "iconID", "isOffensive", "isAssistance", "durationAttributeID", "trackingSpeedAttributeID", "dischargeAttributeID", "rangeAttributeID", "falloffAttributeID", "disallowAutoRepeat", "published", "displayName", "isWarpSafe", "rangeChance", "electronicChance", "propulsionChance", "distribution", "sfxName", "npcUsageChanceAttributeID", "npcActivationChanceAttributeID", "fittingUsageChanceAttributeID", "modifierInfo") FROM stdin;
+\.
+
+COPY "dogmaTypeAttributes" ("typeID", "attributeID", "valueInt", "valueFloat") FROM stdin;
+34	4	\N	1.5e-07
+\.
+
+COPY "dogmaTypeEffects" ("typeID", "effectID", "isDefault") FROM stdin;
+\.
+
+COPY "industryActivities" ("blueprintTypeID", "activityID", "time") FROM stdin;
+\.
+
+COPY "industryActivityMaterials" ("blueprintTypeID", "activityID", "materialTypeID", "quantity") FROM stdin;
+\.
+
+COPY "industryActivityProducts" ("blueprintTypeID", "activityID", "productTypeID", "quantity") FROM stdin;
+\.
+
+COPY "industryBlueprints" ("blueprintTypeID", "maxProductionLimit") FROM stdin;
+\.
+
+COPY "invGroups" ("groupID", "categoryID", "groupName", "iconID", "useBasePrice", "anchored", "anchorable", "fittableNonSingleton", "published") FROM stdin;
+\.
+
+COPY "invTypes" ("typeID", "typeName", "groupID", "description", "mass", "volume", "capacity", "portionSize", "raceID", "basePrice", "published", "marketGroupID", "iconID", "soundID", "graphicID") FROM stdin;
+34	Tritanium	18	\N	0	0.01	\N	\N	\N	\N	1	\N	\N	\N	\N
+35	Pyerite	18	\N	0	0.01	\N	\N	\N	\N	1	\N	\N	\N	\N
+\.
+
+COPY "mapConstellations" ("constellationID", "constellationName", "regionID", "x", "y", "z", "factionID") FROM stdin;
+\.
+
+COPY "mapPlanets" ("planetID", "planetName", "solarSystemID", "typeID", "x", "y", "z") FROM stdin;
+\.
+
+COPY "mapRegions" ("regionID", "regionName", "x", "y", "z", "factionID") FROM stdin;
+\.
+
+COPY "mapSolarSystems" ("solarSystemID", "solarSystemName", "regionID", "constellationID", "x", "y", "z", "security", "securityClass") FROM stdin;
+\.
+
+COPY "mapStargates" ("stargateID", "solarSystemID", "destinationID") FROM stdin;
+\.
+
+-- Indexes
+CREATE INDEX "idx_dogmaAttributes_attributeName" ON "dogmaAttributes" ("attributeName");
+CREATE INDEX "idx_dogmaEffects_effectCategory" ON "dogmaEffects" ("effectCategory");
+CREATE INDEX "idx_dogmaEffects_effectName" ON "dogmaEffects" ("effectName");
+CREATE INDEX "idx_dogmaTypeAttributes_attributeID" ON "dogmaTypeAttributes" ("attributeID");
+CREATE INDEX "idx_dogmaTypeAttributes_typeID" ON "dogmaTypeAttributes" ("typeID");
+CREATE INDEX "idx_dogmaTypeEffects_effectID" ON "dogmaTypeEffects" ("effectID");
+CREATE INDEX "idx_dogmaTypeEffects_typeID" ON "dogmaTypeEffects" ("typeID");
+CREATE INDEX "idx_industryActivities_activityID" ON "industryActivities" ("activityID");
+CREATE INDEX "idx_industryActivities_blueprintTypeID" ON "industryActivities" ("blueprintTypeID");
+CREATE INDEX "idx_industryActivityMaterials_blueprintTypeID" ON "industryActivityMaterials" ("blueprintTypeID");
+CREATE INDEX "idx_industryActivityMaterials_materialTypeID" ON "industryActivityMaterials" ("materialTypeID");
+CREATE INDEX "idx_industryActivityProducts_blueprintTypeID" ON "industryActivityProducts" ("blueprintTypeID");
+CREATE INDEX "idx_industryActivityProducts_productTypeID" ON "industryActivityProducts" ("productTypeID");
+CREATE INDEX "idx_invGroups_categoryID" ON "invGroups" ("categoryID");
+CREATE INDEX "idx_invTypes_groupID" ON "invTypes" ("groupID");
+CREATE INDEX "idx_invTypes_marketGroupID" ON "invTypes" ("marketGroupID");
+CREATE INDEX "idx_mapConstellations_regionID" ON "mapConstellations" ("regionID");
+CREATE INDEX "idx_mapPlanets_solarSystemID" ON "mapPlanets" ("solarSystemID");
+CREATE INDEX "idx_mapPlanets_typeID" ON "mapPlanets" ("typeID");
+CREATE INDEX "idx_mapSolarSystems_constellationID" ON "mapSolarSystems" ("constellationID");
+CREATE INDEX "idx_mapSolarSystems_regionID" ON "mapSolarSystems" ("regionID");
+CREATE INDEX "idx_mapStargates_destinationID" ON "mapStargates" ("destinationID");
+CREATE INDEX "idx_mapStargates_solarSystemID" ON "mapStargates" ("solarSystemID");
+
+COMMIT;